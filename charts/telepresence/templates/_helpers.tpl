@@ -86,7 +86,15 @@ RBAC rules required to create an intercept in a namespace; excludes any rules th
   verbs: ["create"]
 - apiGroups:
   - "apps"
-  resources: ["deployments", "replicasets", "statefulsets"]
+  resources: ["deployments", "replicasets", "statefulsets", "daemonsets"]
+  verbs: ["get", "list", "update", "patch"]
+- apiGroups:
+  - "batch"
+  resources: ["jobs", "cronjobs"]
+  verbs: ["get", "list", "update", "patch"]
+- apiGroups:
+  - "argoproj.io"
+  resources: ["rollouts"]
   verbs: ["get", "list", "update", "patch"]
 - apiGroups:
   - "getambassador.io"
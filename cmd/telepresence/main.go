@@ -52,7 +52,9 @@ func main() {
 		cmd.AddCommand(userd.Command(commands.GetCommands, []userd.DaemonService{}, []trafficmgr.SessionService{}))
 		cmd.AddCommand(rootd.Command())
 		if err := cmd.ExecuteContext(ctx); err != nil {
-			fmt.Fprintf(cmd.ErrOrStderr(), "%s: error: %v\n", cmd.CommandPath(), err)
+			if !cli.ErrorReported(err) {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s: error: %v\n", cmd.CommandPath(), err)
+			}
 			os.Exit(1)
 		}
 	} else {
@@ -67,11 +69,13 @@ func main() {
 			os.Exit(1)
 		}
 		cmd = cli.Command(ctx)
-		cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
-			return errcat.User.New(err)
+		cmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+			return cli.ReportError(c, errcat.User.New(err))
 		})
 		if err := cmd.ExecuteContext(ctx); err != nil {
-			fmt.Fprintf(cmd.ErrOrStderr(), "%s: error: %v\n", cmd.CommandPath(), err)
+			if !cli.ErrorReported(err) {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s: error: %v\n", cmd.CommandPath(), err)
+			}
 			if errcat.GetCategory(err) > errcat.NoDaemonLogs {
 				summarizeLogs(ctx, cmd)
 				// If the user gets here, it might be an actual bug that they found, so
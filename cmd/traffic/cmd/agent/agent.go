@@ -8,6 +8,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -34,19 +35,42 @@ type Config struct {
 	ManagerHost string `env:"_TEL_AGENT_MANAGER_HOST,default=traffic-manager"`
 	ManagerPort int32  `env:"_TEL_AGENT_MANAGER_PORT,default=8081"`
 	APIPort     int32  `env:"TELEPRESENCE_API_PORT,default="`
+
+	// MetricsPort is the port that the agent serves its Prometheus metrics on. Zero disables the metrics.
+	MetricsPort int32 `env:"_TEL_AGENT_METRICS_PORT,default=9899"`
+
+	// AdditionalAppPorts are app ports that the agent fronts in addition to the AppPort. The
+	// agent port of the n-th additional app port is AgentPort + n.
+	AdditionalAppPorts []int32 `env:"_TEL_AGENT_ADDITIONAL_APP_PORTS,default="`
+
+	// AgentProtocol is the protocol of the agent port of the AppPort, either TCP or UDP.
+	AgentProtocol string `env:"_TEL_AGENT_PROTOCOL,default=TCP"`
+
+	// AdditionalAppProtocols are the protocols of the agent ports of the AdditionalAppPorts. Ports
+	// that have no protocol in the list use TCP.
+	AdditionalAppProtocols []string `env:"_TEL_AGENT_ADDITIONAL_APP_PROTOCOLS,default="`
+
+	// EgressDestinations are "host:port" destinations of outbound TCP connections made by the app. The
+	// agent port of the n-th destination is the one that follows the agent ports of the app ports.
+	EgressDestinations []string `env:"_TEL_AGENT_EGRESS_DESTINATIONS,default="`
 }
 
 var skipKeys = map[string]bool{
 	// Keys found in the Config
-	"_TEL_AGENT_NAME":         true,
-	"_TEL_AGENT_NAMESPACE":    true,
-	"_TEL_AGENT_POD_IP":       true,
-	"_TEL_AGENT_PORT":         true,
-	"_TEL_AGENT_APP_MOUNTS":   true,
-	"_TEL_AGENT_APP_PORT":     true,
-	"_TEL_AGENT_MANAGER_HOST": true,
-	"_TEL_AGENT_MANAGER_PORT": true,
-	"_TEL_AGENT_LOG_LEVEL":    true,
+	"_TEL_AGENT_NAME":                     true,
+	"_TEL_AGENT_NAMESPACE":                true,
+	"_TEL_AGENT_POD_IP":                   true,
+	"_TEL_AGENT_PORT":                     true,
+	"_TEL_AGENT_APP_MOUNTS":               true,
+	"_TEL_AGENT_APP_PORT":                 true,
+	"_TEL_AGENT_ADDITIONAL_APP_PORTS":     true,
+	"_TEL_AGENT_PROTOCOL":                 true,
+	"_TEL_AGENT_ADDITIONAL_APP_PROTOCOLS": true,
+	"_TEL_AGENT_EGRESS_DESTINATIONS":      true,
+	"_TEL_AGENT_MANAGER_HOST":             true,
+	"_TEL_AGENT_MANAGER_PORT":             true,
+	"_TEL_AGENT_METRICS_PORT":             true,
+	"_TEL_AGENT_LOG_LEVEL":                true,
 
 	// Keys that aren't useful when running on the local machine
 	"HOME":     true,
@@ -235,6 +259,11 @@ func Main(ctx context.Context, args ...string) error {
 			Product: "telepresence",
 			Version: version.Version,
 		},
+		{
+			Name:    "route",
+			Product: "telepresence",
+			Version: version.Version,
+		},
 	}
 	info.Mechanisms = mechanisms
 
@@ -256,21 +285,49 @@ func Main(ctx context.Context, args ...string) error {
 		dlog.Info(ctx, "Not starting sftp-server ($APP_MOUNTS is empty or $USER is set)")
 	}
 
-	forwarderChan := make(chan *forwarder.Forwarder)
+	// The forwarders of the app ports and the forwarders of the egress destinations
+	type agentForwarders struct {
+		app    []*forwarder.Forwarder
+		egress []*forwarder.Forwarder
+	}
+	forwarderChan := make(chan *agentForwarders)
 
-	// Manage the forwarder
+	// Manage the forwarders, one for each app port and one for each egress destination
 	g.Go("forward", func(ctx context.Context) error {
 		ctx = tunnel.WithPool(ctx, tunnel.NewPool())
-		lisAddr, err := net.ResolveTCPAddr("tcp", fmt.Sprintf(":%d", config.AgentPort))
-		if err != nil {
-			close(forwarderChan)
-			return err
+		appPorts := append([]int32{config.AppPort}, config.AdditionalAppPorts...)
+		forwarders := make([]*forwarder.Forwarder, len(appPorts))
+		for i, appPort := range appPorts {
+			lisAddr, err := agentListenAddr(config.appPortProtocol(i), config.AgentPort+int32(i))
+			if err != nil {
+				close(forwarderChan)
+				return err
+			}
+			forwarders[i] = forwarder.NewForwarder(lisAddr, "", appPort)
 		}
+		egressForwarders := make([]*forwarder.Forwarder, len(config.EgressDestinations))
+		for i, dest := range config.EgressDestinations {
+			f, err := egressForwarder(dest, config.AgentPort+int32(len(appPorts)+i))
+			if err != nil {
+				close(forwarderChan)
+				return err
+			}
+			egressForwarders[i] = f
+		}
+		forwarderChan <- &agentForwarders{app: forwarders, egress: egressForwarders}
 
-		forwarder := forwarder.NewForwarder(lisAddr, "", config.AppPort)
-		forwarderChan <- forwarder
-
-		return forwarder.Serve(ctx)
+		fg := dgroup.NewGroup(ctx, dgroup.GroupConfig{})
+		for _, f := range forwarders {
+			f := f
+			_, appPort := f.Target()
+			fg.Go(fmt.Sprintf("forward-%d", appPort), f.Serve)
+		}
+		for _, f := range egressForwarders {
+			f := f
+			host, port := f.Target()
+			fg.Go(fmt.Sprintf("egress-%s:%d", host, port), f.Serve)
+		}
+		return fg.Wait()
 	})
 
 	// Talk to the Traffic Manager
@@ -281,19 +338,28 @@ func Main(ctx context.Context, args ...string) error {
 		ticker := time.NewTicker(5 * time.Second)
 		defer ticker.Stop()
 
-		forwarder := <-forwarderChan
-		if forwarder == nil {
+		forwarders := <-forwarderChan
+		if forwarders == nil {
 			return nil
 		}
 
 		sftpPort := <-sftpPortCh
-		state := NewState(forwarder, config.ManagerHost, config.Namespace, config.PodIP, sftpPort)
+		state := NewState(forwarders.app, forwarders.egress, config.ManagerHost, config.Namespace, config.PodIP, sftpPort)
 
 		if config.APIPort != 0 {
 			dgroup.ParentGroup(ctx).Go("API-server", func(ctx context.Context) error {
 				return restapi.NewServer(state.AgentState()).ListenAndServe(ctx, int(config.APIPort))
 			})
 		}
+		if mp, ok := state.AgentState().(restapi.MetricsProvider); ok && config.MetricsPort != 0 {
+			dgroup.ParentGroup(ctx).Go("metrics", func(ctx context.Context) error {
+				// The metrics are optional, so failing to serve them must not stop the agent
+				if err := serveMetrics(ctx, mp, config.MetricsPort); err != nil {
+					dlog.Errorf(ctx, "unable to serve metrics on port %d: %v", config.MetricsPort, err)
+				}
+				return nil
+			})
+		}
 
 		for {
 			if err := TalkToManager(ctx, gRPCAddress, info, state); err != nil {
@@ -311,3 +377,45 @@ func Main(ctx context.Context, args ...string) error {
 	// Wait for exit
 	return g.Wait()
 }
+
+// appPortProtocol returns the protocol of the agent port of the n-th app port, where the AppPort is the
+// 0th and the AdditionalAppPorts follow.
+func (c *Config) appPortProtocol(n int) string {
+	if n == 0 {
+		return c.AgentProtocol
+	}
+	if n <= len(c.AdditionalAppProtocols) && c.AdditionalAppProtocols[n-1] != "" {
+		return c.AdditionalAppProtocols[n-1]
+	}
+	return "TCP"
+}
+
+// agentListenAddr returns the address that an agent port with the given protocol listens to.
+func agentListenAddr(protocol string, agentPort int32) (net.Addr, error) {
+	switch network := strings.ToLower(protocol); network {
+	case "tcp":
+		return net.ResolveTCPAddr(network, fmt.Sprintf(":%d", agentPort))
+	case "udp":
+		return net.ResolveUDPAddr(network, fmt.Sprintf(":%d", agentPort))
+	default:
+		return nil, fmt.Errorf("unsupported agent protocol %q", protocol)
+	}
+}
+
+// egressForwarder returns a forwarder that listens to the given agent port and forwards to the given "host:port"
+// destination. The agent init container redirects the app's outbound connections to the destination to that port.
+func egressForwarder(dest string, agentPort int32) (*forwarder.Forwarder, error) {
+	host, portStr, err := net.SplitHostPort(dest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid egress destination %q: %w", dest, err)
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid egress destination %q: %w", dest, err)
+	}
+	lisAddr, err := net.ResolveTCPAddr("tcp", fmt.Sprintf(":%d", agentPort))
+	if err != nil {
+		return nil, err
+	}
+	return forwarder.NewForwarder(lisAddr, host, int32(port)), nil
+}
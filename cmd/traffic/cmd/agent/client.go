@@ -11,10 +11,12 @@ import (
 	"time"
 
 	"github.com/blang/semver"
+	"github.com/miekg/dns"
 	"google.golang.org/grpc"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 
 	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/pkg/dnsproxy"
 	"github.com/TinderBackend/telepresence/v2/pkg/install"
 	"github.com/TinderBackend/telepresence/v2/pkg/iputil"
 	"github.com/TinderBackend/telepresence/v2/pkg/log"
@@ -111,6 +113,13 @@ func TalkToManager(ctx context.Context, address string, info *rpc.AgentInfo, sta
 	}
 	go lookupHostWaitLoop(ctx, manager, session, lrStream)
 
+	// Deal with DNS requests dispatched to this agent during intercepts
+	drStream, err := manager.WatchLookupDNS(ctx, session)
+	if err != nil {
+		return err
+	}
+	go lookupDNSWaitLoop(ctx, manager, session, drStream)
+
 	// Deal with dial requests from the manager
 	dialerStream, err := manager.WatchDial(ctx, session)
 	if err != nil {
@@ -219,6 +228,46 @@ func lookupAndRespond(ctx context.Context, manager rpc.ManagerClient, session *r
 	}
 }
 
+func lookupDNSWaitLoop(ctx context.Context, manager rpc.ManagerClient, session *rpc.SessionInfo, lookupDNSStream rpc.Manager_WatchLookupDNSClient) {
+	for ctx.Err() == nil {
+		dr, err := lookupDNSStream.Recv()
+		if err != nil {
+			if ctx.Err() == nil && !errors.Is(err, io.EOF) {
+				dlog.Debugf(ctx, "dns request stream recv: %+v", err)
+			}
+			return
+		}
+		go lookupDNSAndRespond(ctx, manager, session, dr)
+	}
+}
+
+func lookupDNSAndRespond(ctx context.Context, manager rpc.ManagerClient, session *rpc.SessionInfo, dr *rpc.DNSRequest) {
+	qType := uint16(dr.Type)
+	qtn := dns.TypeToString[qType]
+	dlog.Debugf(ctx, "DNSRequest for %s %s", qtn, dr.Name)
+
+	rrs, rCode, err := dnsproxy.Lookup(ctx, qType, dr.Name)
+	if err != nil {
+		dlog.Errorf(ctx, "LookupDNS: %v", err)
+		return
+	}
+	dlog.Debugf(ctx, "DNS response for %s %s -> %s %s", qtn, dr.Name, dns.RcodeToString[rCode], rrs)
+	response, err := dnsproxy.ToRPC(rrs, rCode)
+	if err != nil {
+		dlog.Errorf(ctx, "LookupDNS: %v", err)
+		return
+	}
+	if _, err = manager.AgentLookupDNSResponse(ctx, &rpc.DNSAgentResponse{
+		Session:  session,
+		Request:  dr,
+		Response: response,
+	}); err != nil {
+		if ctx.Err() == nil {
+			dlog.Debugf(ctx, "dns response: %+v %v", err, response)
+		}
+	}
+}
+
 // GetLogLevel will return the log level that this agent should use
 func GetLogLevel() string {
 	level, ok := os.LookupEnv(install.EnvPrefix + "LOG_LEVEL")
@@ -0,0 +1,83 @@
+package agent
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/TinderBackend/telepresence/v2/pkg/restapi"
+	"github.com/datawire/dlib/dhttp"
+)
+
+var (
+	connectionsDesc = prometheus.NewDesc(
+		"telepresence_agent_connections_total",
+		"Number of connections that the traffic-agent has opened to the app (forwarded), and to the clients of intercepts (intercepted).",
+		[]string{"type"}, nil,
+	)
+	bytesDesc = prometheus.NewDesc(
+		"telepresence_agent_bytes_total",
+		"Number of bytes that the forwarded and the intercepted connections of the traffic-agent have transferred in both directions.",
+		[]string{"type"}, nil,
+	)
+)
+
+// metrics are the Prometheus metrics of a traffic-agent.
+type metrics struct {
+	intercepts prometheus.Gauge
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		intercepts: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "telepresence_agent_intercepts",
+			Help: "Number of active intercepts served by the traffic-agent.",
+		}),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (s *state) Describe(ch chan<- *prometheus.Desc) {
+	ch <- connectionsDesc
+	ch <- bytesDesc
+	s.metrics.intercepts.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (s *state) Collect(ch chan<- prometheus.Metric) {
+	var fwdConns, fwdBytes, icpConns, icpBytes uint64
+	for _, f := range s.allForwarders() {
+		st := f.Stats()
+		fwdConns += st.ForwardedConnections
+		fwdBytes += st.ForwardedBytes
+		icpConns += st.InterceptedConnections
+		icpBytes += st.InterceptedBytes
+	}
+	ch <- prometheus.MustNewConstMetric(connectionsDesc, prometheus.CounterValue, float64(fwdConns), "forwarded")
+	ch <- prometheus.MustNewConstMetric(connectionsDesc, prometheus.CounterValue, float64(icpConns), "intercepted")
+	ch <- prometheus.MustNewConstMetric(bytesDesc, prometheus.CounterValue, float64(fwdBytes), "forwarded")
+	ch <- prometheus.MustNewConstMetric(bytesDesc, prometheus.CounterValue, float64(icpBytes), "intercepted")
+	s.metrics.intercepts.Collect(ch)
+}
+
+// MetricsHandler implements restapi.MetricsProvider
+func (s *state) MetricsHandler() http.Handler {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		s,
+	)
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{})
+}
+
+// serveMetrics serves the metrics of the given provider on /metrics of the given port.
+func serveMetrics(ctx context.Context, mp restapi.MetricsProvider, port int32) error {
+	mux := http.NewServeMux()
+	mux.Handle(restapi.EndPointMetrics, mp.MetricsHandler())
+	sc := &dhttp.ServerConfig{Handler: mux}
+	return sc.ListenAndServe(ctx, ":"+strconv.Itoa(int(port)))
+}
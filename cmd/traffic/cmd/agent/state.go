@@ -3,12 +3,16 @@ package agent
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"strconv"
 
 	"github.com/blang/semver"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/TinderBackend/telepresence/rpc/v2/manager"
 	"github.com/TinderBackend/telepresence/v2/pkg/forwarder"
+	"github.com/TinderBackend/telepresence/v2/pkg/matcher"
 	"github.com/TinderBackend/telepresence/v2/pkg/restapi"
 	"github.com/datawire/dlib/dlog"
 )
@@ -21,31 +25,44 @@ type State interface {
 
 // State of the Traffic Agent.
 type state struct {
-	forwarder   *forwarder.Forwarder
+	forwarders  []*forwarder.Forwarder
+	egress      []*forwarder.Forwarder
 	managerHost string
 	appHost     string
 	appPort     int32
-	chosenID    string
+	chosenIDs   map[string]struct{}
 	namespace   string
 	podIP       string
 	sftpPort    int32
+	metrics     *metrics
 }
 
 func (s *state) InterceptInfo(ctx context.Context, callerID, path string, headers http.Header) (*restapi.InterceptInfo, error) {
-	// The OSS agent is either intercepting or it isn't. There's no way to tell what it is that's being intercepted.
-	return s.forwarder.InterceptInfo(), nil
+	var ii *restapi.InterceptInfo
+	for _, f := range s.allForwarders() {
+		if ii = f.InterceptInfo(path, headers); ii.Intercepted {
+			break
+		}
+	}
+	return ii, nil
 }
 
-func NewState(forwarder *forwarder.Forwarder, managerHost, namespace, podIP string, sftpPort int32) State {
-	host, port := forwarder.Target()
+// NewState creates the state of a Traffic Agent that uses one forwarder for each of the app ports that it
+// fronts. The first forwarder is the one that serves the agent's primary app port. The egress forwarders
+// forward the app's outbound connections to their targets, unless they are intercepted.
+func NewState(forwarders, egress []*forwarder.Forwarder, managerHost, namespace, podIP string, sftpPort int32) State {
+	host, port := forwarders[0].Target()
 	return &state{
-		forwarder:   forwarder,
+		forwarders:  forwarders,
+		egress:      egress,
 		managerHost: managerHost,
 		appHost:     host,
 		appPort:     port,
 		namespace:   namespace,
 		podIP:       podIP,
 		sftpPort:    sftpPort,
+		chosenIDs:   make(map[string]struct{}),
+		metrics:     newMetrics(),
 	}
 }
 
@@ -53,57 +70,238 @@ func (s *state) AgentState() restapi.AgentState {
 	return s
 }
 
+// allForwarders returns the forwarders of the app ports followed by the egress forwarders.
+func (s *state) allForwarders() []*forwarder.Forwarder {
+	return append(s.forwarders[:len(s.forwarders):len(s.forwarders)], s.egress...)
+}
+
 func (s *state) SetManager(sessionInfo *manager.SessionInfo, manager manager.ManagerClient, version semver.Version) {
-	s.forwarder.SetManager(sessionInfo, manager, version)
+	for _, f := range s.allForwarders() {
+		f.SetManager(sessionInfo, manager, version)
+	}
 }
 
-func (s *state) HandleIntercepts(ctx context.Context, cepts []*manager.InterceptInfo) []*manager.ReviewInterceptRequest {
-	var chosenIntercept, activeIntercept *manager.InterceptInfo
+// hasAppPort returns true if one of the forwarders of this agent forwards to the given app port.
+func (s *state) hasAppPort(appPort int32) bool {
+	for _, f := range s.forwarders {
+		if _, port := f.Target(); port == appPort {
+			return true
+		}
+	}
+	return false
+}
 
-	dlog.Debug(ctx, "HandleIntercepts called")
+// egressDestination returns the "host:port" of the given egress forwarder.
+func egressDestination(f *forwarder.Forwarder) string {
+	host, port := f.Target()
+	return net.JoinHostPort(host, strconv.Itoa(int(port)))
+}
 
-	// Find the chosen intercept if it still exists
-	if s.chosenID != "" {
-		for _, cept := range cepts {
-			if cept.Id == s.chosenID {
-				chosenIntercept = cept
-				break
-			}
+// hasEgress returns true if one of the egress forwarders of this agent forwards to the given destination.
+func (s *state) hasEgress(dest string) bool {
+	for _, f := range s.egress {
+		if egressDestination(f) == dest {
+			return true
 		}
+	}
+	return false
+}
+
+// chosenIntercept is an intercept that this agent has chosen to serve, together with the
+// matcher that decides what requests it receives and the app ports that it intercepts.
+type chosenIntercept struct {
+	*manager.InterceptInfo
+	matcher matcher.Request
 
-		if chosenIntercept != nil {
-			// The chosen intercept still exists
-			if chosenIntercept.Disposition == manager.InterceptDispositionType_ACTIVE {
-				// and is active
-				activeIntercept = chosenIntercept
+	// ports maps the intercepted app ports to the ports on the intercept's target host
+	ports map[int32]int32
+
+	// egress is the intercepted egress destination. An intercept with an egress destination
+	// intercepts no app ports.
+	egress string
+}
+
+// newChosenIntercept returns a chosenIntercept for the given intercept, or an error if the mechanism
+// arguments of the intercept are invalid or if the intercept names a port that this agent doesn't front.
+func (s *state) newChosenIntercept(cept *manager.InterceptInfo) (*chosenIntercept, error) {
+	rm, err := forwarder.InterceptMatcher(cept)
+	if err != nil {
+		return nil, err
+	}
+	spec := cept.Spec
+	if spec.EgressDestination != "" {
+		host, port, err := net.SplitHostPort(spec.EgressDestination)
+		if err != nil {
+			return nil, fmt.Errorf("invalid egress destination %q: %w", spec.EgressDestination, err)
+		}
+		dest := net.JoinHostPort(host, port)
+		if !s.hasEgress(dest) {
+			return nil, fmt.Errorf("egress destination %s is not served by this traffic-agent", dest)
+		}
+		return &chosenIntercept{InterceptInfo: cept, matcher: rm, egress: dest}, nil
+	}
+	ports := make(map[int32]int32, 1+len(spec.ServicePorts))
+	addPort := func(containerPort, targetPort int32) error {
+		if containerPort == 0 {
+			containerPort = s.appPort
+		}
+		if !s.hasAppPort(containerPort) {
+			return fmt.Errorf("container port %d is not served by this traffic-agent", containerPort)
+		}
+		if _, ok := ports[containerPort]; ok {
+			return fmt.Errorf("container port %d is intercepted more than once", containerPort)
+		}
+		ports[containerPort] = targetPort
+		return nil
+	}
+	if err = addPort(spec.ContainerPort, spec.TargetPort); err != nil {
+		return nil, err
+	}
+	for _, sp := range spec.ServicePorts {
+		if err = addPort(sp.ContainerPort, sp.TargetPort); err != nil {
+			return nil, err
+		}
+	}
+	return &chosenIntercept{InterceptInfo: cept, matcher: rm, ports: ports}, nil
+}
+
+// conflictingIntercept returns the first of the given chosen intercepts that intercepts one of the
+// given app ports or the given egress destination and would match some of the requests that are matched
+// by the given intercept, or nil if no such intercept exists. Mirror intercepts only receive copies of
+// the traffic, so they never conflict.
+func conflictingIntercept(chosen []*chosenIntercept, ci *chosenIntercept) *chosenIntercept {
+	if ci.Spec.Mirror {
+		return nil
+	}
+	for _, cc := range chosen {
+		if cc.Spec.Mirror {
+			continue
+		}
+		if !sharePort(cc.ports, ci.ports) && (ci.egress == "" || cc.egress != ci.egress) {
+			continue
+		}
+		if cc.matcher == nil || ci.matcher == nil || cc.matcher.Overlaps(ci.matcher) {
+			return cc
+		}
+	}
+	return nil
+}
+
+func sharePort(a, b map[int32]int32) bool {
+	for p := range a {
+		if _, ok := b[p]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// interceptsForPort returns the intercepts of the given chosen intercepts that intercept the given app port. The
+// target port of each returned intercept is the one that traffic for that app port is routed to.
+func interceptsForPort(chosen []*chosenIntercept, appPort int32) []*manager.InterceptInfo {
+	var ics []*manager.InterceptInfo
+	for _, ci := range chosen {
+		targetPort, ok := ci.ports[appPort]
+		if !ok {
+			continue
+		}
+		ii := ci.InterceptInfo
+		if targetPort != ii.Spec.TargetPort {
+			ii = proto.Clone(ii).(*manager.InterceptInfo)
+			ii.Spec.TargetPort = targetPort
+		}
+		ics = append(ics, ii)
+	}
+	return ics
+}
+
+// interceptsForEgress returns the intercepts of the given chosen intercepts that intercept the given egress destination.
+func interceptsForEgress(chosen []*chosenIntercept, dest string) []*manager.InterceptInfo {
+	var ics []*manager.InterceptInfo
+	for _, ci := range chosen {
+		if ci.egress == dest {
+			ics = append(ics, ci.InterceptInfo)
+		}
+	}
+	return ics
+}
+
+func (s *state) HandleIntercepts(ctx context.Context, cepts []*manager.InterceptInfo) []*manager.ReviewInterceptRequest {
+	dlog.Debug(ctx, "HandleIntercepts called")
+
+	// Find the chosen intercepts that still exist
+	var chosen []*chosenIntercept
+	found := make(map[string]struct{}, len(s.chosenIDs))
+	for _, cept := range cepts {
+		if _, ok := s.chosenIDs[cept.Id]; ok {
+			found[cept.Id] = struct{}{}
+			if ci, err := s.newChosenIntercept(cept); err == nil {
+				chosen = append(chosen, ci)
 			}
-		} else {
+		}
+	}
+	for id := range s.chosenIDs {
+		if _, ok := found[id]; !ok {
 			// The chosen intercept was deleted by the user
-			dlog.Info(ctx, "The previously-active intercept has been deleted")
-			s.chosenID = ""
-		}
-	} else {
-		// Attach to already ACTIVE intercept if there is one.
-		for _, cept := range cepts {
-			if cept.Disposition == manager.InterceptDispositionType_ACTIVE {
-				chosenIntercept = cept
-				s.chosenID = cept.Id
-				activeIntercept = cept
-				break
-			}
+			dlog.Infof(ctx, "The previously-chosen intercept %q has been deleted", id)
+			delete(s.chosenIDs, id)
 		}
 	}
 
-	// Update forwarding
-	s.forwarder.SetIntercepting(activeIntercept)
+	// Attach to already ACTIVE intercepts that don't conflict with the chosen ones.
+	for _, cept := range cepts {
+		if _, ok := s.chosenIDs[cept.Id]; ok || cept.Disposition != manager.InterceptDispositionType_ACTIVE {
+			continue
+		}
+		if ci, err := s.newChosenIntercept(cept); err == nil && conflictingIntercept(chosen, ci) == nil {
+			s.chosenIDs[cept.Id] = struct{}{}
+			chosen = append(chosen, ci)
+		}
+	}
+
+	// Update forwarding. A suspended intercept that is unavailable while suspended is forwarded too, so
+	// that the forwarder can answer the requests that it would receive.
+	var activeIntercepts, forwardedIntercepts []*chosenIntercept
+	for _, ci := range chosen {
+		switch {
+		case ci.Disposition == manager.InterceptDispositionType_ACTIVE:
+			activeIntercepts = append(activeIntercepts, ci)
+			forwardedIntercepts = append(forwardedIntercepts, ci)
+		case ci.Disposition == manager.InterceptDispositionType_SUSPENDED && ci.UnavailableWhenSuspended && !ci.Spec.Mirror:
+			forwardedIntercepts = append(forwardedIntercepts, ci)
+		}
+	}
+	for _, f := range s.forwarders {
+		_, appPort := f.Target()
+		f.SetIntercepting(interceptsForPort(forwardedIntercepts, appPort))
+	}
+	for _, f := range s.egress {
+		f.SetIntercepting(interceptsForEgress(forwardedIntercepts, egressDestination(f)))
+	}
+	s.metrics.intercepts.Set(float64(len(activeIntercepts)))
 
 	// Review waiting intercepts
 	reviews := []*manager.ReviewInterceptRequest{}
 	for _, cept := range cepts {
 		if cept.Disposition == manager.InterceptDispositionType_WAITING {
+			ci, err := s.newChosenIntercept(cept)
+			if err != nil {
+				// The mechanism arguments or ports are not understood by this agent, so reject this one.
+				dlog.Infof(ctx, "Setting intercept %q as AGENT_ERROR; %v", cept.Id, err)
+				delete(s.chosenIDs, cept.Id)
+				reviews = append(reviews, &manager.ReviewInterceptRequest{
+					Id:          cept.Id,
+					Disposition: manager.InterceptDispositionType_AGENT_ERROR,
+					Message:     err.Error(),
+				})
+				continue
+			}
+			mechArgsDesc := forwarder.MechanismArgsDesc(ci.matcher)
+
 			// This intercept is ready to be active
-			switch {
-			case chosenIntercept != nil && cept.Id == chosenIntercept.Id:
+			_, isChosen := s.chosenIDs[cept.Id]
+			switch conflict := conflictingIntercept(chosen, ci); {
+			case isChosen:
 				// We've already chosen this one and marked it active, but it's not
 				// active yet in this snapshot.  We could probably just do nothing
 				// and it would probably change to ACTIVE in the very next snapshot
@@ -116,38 +314,40 @@ func (s *state) HandleIntercepts(ctx context.Context, cepts []*manager.Intercept
 					Disposition:       manager.InterceptDispositionType_ACTIVE,
 					PodIp:             s.podIP,
 					SftpPort:          s.sftpPort,
-					MechanismArgsDesc: "all TCP connections",
+					MechanismArgsDesc: mechArgsDesc,
+					Metadata:          forwarder.InterceptMetadata(cept),
 				})
-			case chosenIntercept == nil:
-				// We don't have an intercept in play, so choose this one. All
-				// agents will get intercepts in the same order every time, so
-				// this will yield a consistent result. Note that the intercept
-				// will not become active at this time. That will happen later,
-				// once the manager assigns a port.
+			case conflict == nil:
+				// None of the intercepts in play will receive the requests that this
+				// intercept matches, so choose this one. All agents will get intercepts
+				// in the same order every time, so this will yield a consistent result.
+				// Note that the intercept will not become active at this time. That will
+				// happen later, once the manager assigns a port.
 				dlog.Infof(ctx, "Setting intercept %q as ACTIVE", cept.Id)
-				s.chosenID = cept.Id
-				chosenIntercept = cept
+				s.chosenIDs[cept.Id] = struct{}{}
+				chosen = append(chosen, ci)
 				reviews = append(reviews, &manager.ReviewInterceptRequest{
 					Id:                cept.Id,
 					Disposition:       manager.InterceptDispositionType_ACTIVE,
 					PodIp:             s.podIP,
 					SftpPort:          s.sftpPort,
-					MechanismArgsDesc: "all TCP connections",
+					MechanismArgsDesc: mechArgsDesc,
+					Metadata:          forwarder.InterceptMetadata(cept),
 				})
 			default:
-				// We already have an intercept in play, so reject this one.
-				dlog.Infof(ctx, "Setting intercept %q as AGENT_ERROR; as it conflicts with %q as the current chosen-to-be-ACTIVE intercept", cept.Id, s.chosenID)
+				// An intercept in play overlaps with this one, so reject this one.
+				dlog.Infof(ctx, "Setting intercept %q as AGENT_ERROR; as it conflicts with %q as a chosen-to-be-ACTIVE intercept", cept.Id, conflict.Id)
 				var msg string
-				if chosenIntercept.Disposition == manager.InterceptDispositionType_ACTIVE {
-					msg = fmt.Sprintf("Conflicts with the currently-served intercept %q", s.chosenID)
+				if conflict.Disposition == manager.InterceptDispositionType_ACTIVE {
+					msg = fmt.Sprintf("Conflicts with the currently-served intercept %q", conflict.Id)
 				} else {
-					msg = fmt.Sprintf("Conflicts with the currently-waiting-to-be-served intercept %q", s.chosenID)
+					msg = fmt.Sprintf("Conflicts with the currently-waiting-to-be-served intercept %q", conflict.Id)
 				}
 				reviews = append(reviews, &manager.ReviewInterceptRequest{
 					Id:                cept.Id,
 					Disposition:       manager.InterceptDispositionType_AGENT_ERROR,
 					Message:           msg,
-					MechanismArgsDesc: "all TCP connections",
+					MechanismArgsDesc: mechArgsDesc,
 				})
 			}
 		}
@@ -157,5 +357,10 @@ func (s *state) HandleIntercepts(ctx context.Context, cepts []*manager.Intercept
 }
 
 func (s *state) Intercepting() bool {
-	return s.forwarder.Intercepting()
+	for _, f := range s.allForwarders() {
+		if f.Intercepting() {
+			return true
+		}
+	}
+	return false
 }
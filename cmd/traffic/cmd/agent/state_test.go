@@ -3,6 +3,8 @@ package agent_test
 import (
 	"context"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
 	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/agent"
 	"github.com/TinderBackend/telepresence/v2/pkg/forwarder"
+	"github.com/TinderBackend/telepresence/v2/pkg/restapi"
 	"github.com/datawire/dlib/dlog"
 )
 
@@ -21,7 +24,7 @@ const (
 )
 
 func makeFS(t *testing.T) (*forwarder.Forwarder, agent.State) {
-	lAddr, err := net.ResolveTCPAddr("tcp", ":1111")
+	lAddr, err := net.ResolveTCPAddr("tcp", ":0")
 	assert.NoError(t, err)
 
 	f := forwarder.NewForwarder(lAddr, appHost, appPort)
@@ -36,7 +39,7 @@ func makeFS(t *testing.T) (*forwarder.Forwarder, agent.State) {
 		return port == appPort
 	}, 1*time.Second, 10*time.Millisecond)
 
-	s := agent.NewState(f, mgrHost, "default", "xyz", 0)
+	s := agent.NewState([]*forwarder.Forwarder{f}, nil, mgrHost, "default", "xyz", 0)
 
 	return f, s
 }
@@ -137,3 +140,354 @@ func TestState_HandleIntercepts(t *testing.T) {
 	a.Len(reviews, 0)
 	a.False(f.Intercepting())
 }
+
+func TestState_HandleIntercepts_HTTP(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+	a := assert.New(t)
+	f, s := makeFS(t)
+
+	cepts := []*rpc.InterceptInfo{
+		{
+			Spec: &rpc.InterceptSpec{
+				Name:      "cept1Name",
+				Client:    "user@host1",
+				Agent:     "agentName",
+				Mechanism: "route",
+				MechanismArgs: []string{
+					"--header=auto",
+					"--meta=key=value",
+				},
+				Namespace: "default",
+			},
+			Id:          "intercept-01",
+			Disposition: rpc.InterceptDispositionType_WAITING,
+		},
+		{
+			Spec: &rpc.InterceptSpec{
+				Name:          "cept2Name",
+				Client:        "user@host2",
+				Agent:         "agentName",
+				Mechanism:     "route",
+				MechanismArgs: []string{"--header=bad"},
+				Namespace:     "default",
+			},
+			Id:          "intercept-02",
+			Disposition: rpc.InterceptDispositionType_WAITING,
+		},
+	}
+
+	// Bad mechanism arguments are rejected, good ones are described
+
+	reviews := s.HandleIntercepts(ctx, cepts)
+	a.Len(reviews, 2)
+	a.Equal(rpc.InterceptDispositionType_ACTIVE, reviews[0].Disposition)
+	a.Equal("HTTP requests with headers\n  X-Telepresence-Intercept-Id == intercept-01", reviews[0].MechanismArgsDesc)
+	a.Equal(map[string]string{"key": "value"}, reviews[0].Metadata)
+	a.Equal(rpc.InterceptDispositionType_AGENT_ERROR, reviews[1].Disposition)
+	a.Contains(reviews[1].Message, "NAME=VALUE")
+
+	// Only matching requests are intercepted
+
+	cepts[0].Disposition = rpc.InterceptDispositionType_ACTIVE
+	cepts[0].Metadata = reviews[0].Metadata
+	s.HandleIntercepts(ctx, cepts)
+	a.True(f.Intercepting())
+
+	ii, err := s.AgentState().InterceptInfo(ctx, "", "/", http.Header{"X-Telepresence-Intercept-Id": {"intercept-01"}})
+	a.NoError(err)
+	a.True(ii.Intercepted)
+	a.Equal("value", ii.Metadata["key"])
+
+	ii, err = s.AgentState().InterceptInfo(ctx, "", "/", http.Header{"X-Telepresence-Intercept-Id": {"intercept-02"}})
+	a.NoError(err)
+	a.False(ii.Intercepted)
+
+	// A suspended intercept falls back to the app, unless it must be unavailable while suspended
+
+	cepts[0].Disposition = rpc.InterceptDispositionType_SUSPENDED
+	a.Empty(s.HandleIntercepts(ctx, cepts[:1]))
+	a.False(f.Intercepting())
+
+	cepts[0].UnavailableWhenSuspended = true
+	a.Empty(s.HandleIntercepts(ctx, cepts[:1]))
+	a.True(f.Intercepting())
+
+	// The intercept is reviewed again when its client session is resumed
+
+	cepts[0].Disposition = rpc.InterceptDispositionType_WAITING
+	reviews = s.HandleIntercepts(ctx, cepts[:1])
+	a.Len(reviews, 1)
+	a.Equal(rpc.InterceptDispositionType_ACTIVE, reviews[0].Disposition)
+}
+
+func TestState_HandleIntercepts_Multiple(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+	a := assert.New(t)
+	f, s := makeFS(t)
+
+	makeCept := func(id string, args ...string) *rpc.InterceptInfo {
+		return &rpc.InterceptInfo{
+			Spec: &rpc.InterceptSpec{
+				Name:          id + "Name",
+				Client:        "user@" + id,
+				Agent:         "agentName",
+				Mechanism:     "route",
+				MechanismArgs: args,
+				Namespace:     "default",
+			},
+			Id:          id,
+			Disposition: rpc.InterceptDispositionType_WAITING,
+		}
+	}
+	cepts := []*rpc.InterceptInfo{
+		makeCept("intercept-01", "--header=auto"),
+		makeCept("intercept-02", "--header=auto"),
+		makeCept("intercept-03", "--header=all", "--path-prefix=/api"),
+		makeCept("intercept-04", "--header=all", "--path-prefix=/api/v1"),
+	}
+
+	// Intercepts with distinct match rules can coexist, overlapping ones conflict
+
+	reviews := s.HandleIntercepts(ctx, cepts)
+	a.Len(reviews, 4)
+	a.Equal(rpc.InterceptDispositionType_ACTIVE, reviews[0].Disposition)
+	a.Equal(rpc.InterceptDispositionType_ACTIVE, reviews[1].Disposition)
+	a.Equal(rpc.InterceptDispositionType_AGENT_ERROR, reviews[2].Disposition)
+	a.Equal("Conflicts with the currently-waiting-to-be-served intercept \"intercept-01\"", reviews[2].Message)
+	a.Equal(rpc.InterceptDispositionType_AGENT_ERROR, reviews[3].Disposition)
+
+	// Requests are routed to the intercept that matches them
+
+	cepts = cepts[:2]
+	cepts[0].Disposition = rpc.InterceptDispositionType_ACTIVE
+	cepts[1].Disposition = rpc.InterceptDispositionType_ACTIVE
+	reviews = s.HandleIntercepts(ctx, cepts)
+	a.Len(reviews, 0)
+	a.True(f.Intercepting())
+
+	for _, cept := range cepts {
+		ii, err := s.AgentState().InterceptInfo(ctx, "", "/", http.Header{"X-Telepresence-Intercept-Id": {cept.Id}})
+		a.NoError(err)
+		a.True(ii.Intercepted)
+	}
+	ii, err := s.AgentState().InterceptInfo(ctx, "", "/", nil)
+	a.NoError(err)
+	a.False(ii.Intercepted)
+
+	// A removed intercept no longer receives requests
+
+	reviews = s.HandleIntercepts(ctx, cepts[1:])
+	a.Len(reviews, 0)
+	ii, err = s.AgentState().InterceptInfo(ctx, "", "/", http.Header{"X-Telepresence-Intercept-Id": {"intercept-01"}})
+	a.NoError(err)
+	a.False(ii.Intercepted)
+
+	// Handle resets state on an empty intercept list
+
+	reviews = s.HandleIntercepts(ctx, nil)
+	a.Len(reviews, 0)
+	a.False(f.Intercepting())
+}
+
+func TestState_HandleIntercepts_MultiPort(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+	a := assert.New(t)
+
+	const grpcPort int32 = 5001
+	lAddr, err := net.ResolveTCPAddr("tcp", ":0")
+	a.NoError(err)
+	f1 := forwarder.NewForwarder(lAddr, appHost, appPort)
+	f2 := forwarder.NewForwarder(lAddr, appHost, grpcPort)
+	for _, f := range []*forwarder.Forwarder{f1, f2} {
+		l, err := f.Listen(ctx)
+		a.NoError(err)
+		go func(f *forwarder.Forwarder) {
+			_ = f.ServeListener(ctx, l)
+		}(f)
+	}
+	s := agent.NewState([]*forwarder.Forwarder{f1, f2}, nil, mgrHost, "default", "xyz", 0)
+
+	makeCept := func(id string, containerPort int32, servicePorts ...*rpc.InterceptPort) *rpc.InterceptInfo {
+		return &rpc.InterceptInfo{
+			Spec: &rpc.InterceptSpec{
+				Name:          id + "Name",
+				Client:        "user@" + id,
+				Agent:         "agentName",
+				Mechanism:     "tcp",
+				Namespace:     "default",
+				TargetPort:    8080,
+				ContainerPort: containerPort,
+				ServicePorts:  servicePorts,
+			},
+			Id:          id,
+			Disposition: rpc.InterceptDispositionType_WAITING,
+		}
+	}
+	cepts := []*rpc.InterceptInfo{
+		makeCept("intercept-01", 0, &rpc.InterceptPort{ServicePortIdentifier: "grpc", TargetPort: 9090, ContainerPort: grpcPort}),
+		makeCept("intercept-02", grpcPort),
+		makeCept("intercept-03", 6000),
+	}
+
+	// An intercept of both ports conflicts with a later intercept of one of them, and ports
+	// that the agent doesn't front are rejected.
+
+	reviews := s.HandleIntercepts(ctx, cepts)
+	a.Len(reviews, 3)
+	a.Equal(rpc.InterceptDispositionType_ACTIVE, reviews[0].Disposition)
+	a.Equal(rpc.InterceptDispositionType_AGENT_ERROR, reviews[1].Disposition)
+	a.Equal("Conflicts with the currently-waiting-to-be-served intercept \"intercept-01\"", reviews[1].Message)
+	a.Equal(rpc.InterceptDispositionType_AGENT_ERROR, reviews[2].Disposition)
+	a.Equal("container port 6000 is not served by this traffic-agent", reviews[2].Message)
+
+	// Both forwarders intercept once the intercept is active
+
+	cepts = cepts[:1]
+	cepts[0].Disposition = rpc.InterceptDispositionType_ACTIVE
+	reviews = s.HandleIntercepts(ctx, cepts)
+	a.Len(reviews, 0)
+	a.True(f1.Intercepting())
+	a.True(f2.Intercepting())
+
+	// Intercepts of different ports don't conflict
+
+	cepts = []*rpc.InterceptInfo{makeCept("intercept-04", appPort), makeCept("intercept-05", grpcPort)}
+	reviews = s.HandleIntercepts(ctx, cepts)
+	a.Len(reviews, 2)
+	a.Equal(rpc.InterceptDispositionType_ACTIVE, reviews[0].Disposition)
+	a.Equal(rpc.InterceptDispositionType_ACTIVE, reviews[1].Disposition)
+	a.False(f1.Intercepting())
+	a.False(f2.Intercepting())
+
+	cepts[1].Disposition = rpc.InterceptDispositionType_ACTIVE
+	reviews = s.HandleIntercepts(ctx, cepts)
+	a.Len(reviews, 1)
+	a.False(f1.Intercepting())
+	a.True(f2.Intercepting())
+
+	reviews = s.HandleIntercepts(ctx, nil)
+	a.Len(reviews, 0)
+	a.False(f2.Intercepting())
+}
+
+func TestState_HandleIntercepts_Egress(t *testing.T) {
+	a := assert.New(t)
+	ctx := dlog.NewTestContext(t, false)
+	lAddr, err := net.ResolveTCPAddr("tcp", ":0")
+	a.NoError(err)
+	app := forwarder.NewForwarder(lAddr, appHost, appPort)
+	egress := forwarder.NewForwarder(lAddr, "api.example.com", 443)
+	for _, f := range []*forwarder.Forwarder{app, egress} {
+		l, err := f.Listen(ctx)
+		a.NoError(err)
+		go func(f *forwarder.Forwarder) {
+			_ = f.ServeListener(ctx, l)
+		}(f)
+	}
+	s := agent.NewState([]*forwarder.Forwarder{app}, []*forwarder.Forwarder{egress}, mgrHost, "default", "xyz", 0)
+
+	makeCept := func(id, dest string) *rpc.InterceptInfo {
+		return &rpc.InterceptInfo{
+			Spec: &rpc.InterceptSpec{
+				Name:              id + "Name",
+				Client:            "user@" + id,
+				Agent:             "agentName",
+				Mechanism:         "tcp",
+				Namespace:         "default",
+				TargetPort:        8080,
+				EgressDestination: dest,
+			},
+			Id:          id,
+			Disposition: rpc.InterceptDispositionType_WAITING,
+		}
+	}
+	cepts := []*rpc.InterceptInfo{
+		makeCept("intercept-01", "api.example.com:443"),
+		makeCept("intercept-02", "db.example.com:5432"),
+	}
+
+	// Destinations that the agent doesn't forward to are rejected
+
+	reviews := s.HandleIntercepts(ctx, cepts)
+	a.Len(reviews, 2)
+	a.Equal(rpc.InterceptDispositionType_ACTIVE, reviews[0].Disposition)
+	a.Equal(rpc.InterceptDispositionType_AGENT_ERROR, reviews[1].Disposition)
+	a.Equal("egress destination db.example.com:5432 is not served by this traffic-agent", reviews[1].Message)
+
+	// Only the egress forwarder intercepts once the intercept is active
+
+	cepts = cepts[:1]
+	cepts[0].Disposition = rpc.InterceptDispositionType_ACTIVE
+	reviews = s.HandleIntercepts(ctx, cepts)
+	a.Len(reviews, 0)
+	a.True(egress.Intercepting())
+	a.False(app.Intercepting())
+
+	reviews = s.HandleIntercepts(ctx, nil)
+	a.Len(reviews, 0)
+	a.False(egress.Intercepting())
+}
+
+func TestState_HandleIntercepts_Mirror(t *testing.T) {
+	a := assert.New(t)
+	ctx := dlog.NewTestContext(t, false)
+	f, s := makeFS(t)
+
+	makeCept := func(id string, mirror bool) *rpc.InterceptInfo {
+		return &rpc.InterceptInfo{
+			Spec: &rpc.InterceptSpec{
+				Name:       id + "Name",
+				Client:     "user@" + id,
+				Agent:      "agentName",
+				Mechanism:  "tcp",
+				Namespace:  "default",
+				TargetPort: 8080,
+				Mirror:     mirror,
+			},
+			Id:          id,
+			Disposition: rpc.InterceptDispositionType_WAITING,
+		}
+	}
+
+	// Mirrors receive copies of the traffic, so they neither conflict with each other nor with an intercept
+	// that receives all connections.
+
+	cepts := []*rpc.InterceptInfo{makeCept("intercept-01", false), makeCept("intercept-02", true), makeCept("intercept-03", true)}
+	reviews := s.HandleIntercepts(ctx, cepts)
+	a.Len(reviews, 3)
+	for _, review := range reviews {
+		a.Equal(rpc.InterceptDispositionType_ACTIVE, review.Disposition)
+	}
+
+	for _, cept := range cepts {
+		cept.Disposition = rpc.InterceptDispositionType_ACTIVE
+	}
+	reviews = s.HandleIntercepts(ctx, cepts)
+	a.Len(reviews, 0)
+	a.True(f.Intercepting())
+
+	reviews = s.HandleIntercepts(ctx, nil)
+	a.Len(reviews, 0)
+	a.False(f.Intercepting())
+}
+
+func TestState_Metrics(t *testing.T) {
+	_, s := makeFS(t)
+	mp, ok := s.AgentState().(restapi.MetricsProvider)
+	if !assert.True(t, ok) {
+		return
+	}
+	rec := httptest.NewRecorder()
+	mp.MetricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, restapi.EndPointMetrics, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	for _, m := range []string{
+		`telepresence_agent_connections_total{type="forwarded"} 0`,
+		`telepresence_agent_connections_total{type="intercepted"} 0`,
+		`telepresence_agent_bytes_total{type="forwarded"} 0`,
+		`telepresence_agent_bytes_total{type="intercepted"} 0`,
+		`telepresence_agent_intercepts 0`,
+	} {
+		assert.Contains(t, body, m)
+	}
+}
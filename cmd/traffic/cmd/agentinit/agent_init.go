@@ -16,41 +16,76 @@ import (
 
 const nat = "nat"
 const inboundChain = "TEL_INBOUND"
+const outboundChain = "TEL_OUTBOUND"
 
 type config struct {
 	AgentPort     int    `env:"AGENT_PORT,required"`
 	AppPort       int    `env:"APP_PORT,required"`
 	AgentProtocol string `env:"AGENT_PROTOCOL,required"`
+
+	// AdditionalAppPorts are redirected to AGENT_PORT + n, where n is the 1-based index of the port.
+	AdditionalAppPorts []int `env:"ADDITIONAL_APP_PORTS,default="`
+
+	// AdditionalAppProtocols are the protocols of the AdditionalAppPorts. Ports that have no protocol in
+	// the list use TCP.
+	AdditionalAppProtocols []string `env:"ADDITIONAL_APP_PROTOCOLS,default="`
+
+	// EgressDestinations are "host:port" destinations of outbound TCP connections. Connections to the n-th
+	// destination are redirected to the agent port that follows the ports used for the app ports.
+	EgressDestinations []string `env:"EGRESS_DESTINATIONS,default="`
+}
+
+// appPortProtocols returns the protocol of each app port, starting with the AppPort.
+func (cfg *config) appPortProtocols() []string {
+	protos := make([]string, 1+len(cfg.AdditionalAppPorts))
+	protos[0] = cfg.AgentProtocol
+	for i := range cfg.AdditionalAppPorts {
+		protos[i+1] = "TCP"
+		if i < len(cfg.AdditionalAppProtocols) && cfg.AdditionalAppProtocols[i] != "" {
+			protos[i+1] = cfg.AdditionalAppProtocols[i]
+		}
+	}
+	return protos
 }
 
 func configureIptables(ctx context.Context, iptables *iptables.IPTables, loopback string, cfg config) error {
-	// These iptables rules implement routing such that a packet directed to the appPort will hit the agentPort instead.
+	// These iptables rules implement routing such that a packet directed to an appPort will hit its agentPort instead.
 	// If there's no mesh this is simply request -> agent -> app (or intercept)
 	// However, if there's a service mesh we want to make sure we don't bypass the mesh, so the traffic will flow request -> mesh -> agent -> app
-	appPort := strconv.Itoa(cfg.AppPort)
-	agentPort := strconv.Itoa(cfg.AgentPort)
+	appPorts := append([]int{cfg.AppPort}, cfg.AdditionalAppPorts...)
+	appProtos := cfg.appPortProtocols()
+	var protos []string
+	for _, proto := range appProtos {
+		if !contains(protos, proto) {
+			protos = append(protos, proto)
+		}
+	}
 	agentUID := strconv.Itoa(os.Getuid())
 	// Clearing the inbound chain will create it if it doesn't exist, or clear it out if it does.
 	err := iptables.ClearChain(nat, inboundChain)
 	if err != nil {
 		return fmt.Errorf("failed to clear chain %s: %w", inboundChain, err)
 	}
-	// Use our inbound chain to direct traffic coming into the app port to the agent port.
-	err = iptables.AppendUnique(nat, inboundChain,
-		"-p", cfg.AgentProtocol, "--dport", appPort,
-		"-j", "REDIRECT", "--to-ports", agentPort)
-	if err != nil {
-		return fmt.Errorf("failed to append rule to %s: %w", inboundChain, err)
+	// Use our inbound chain to direct traffic coming into each app port to its agent port.
+	for i, appPort := range appPorts {
+		err = iptables.AppendUnique(nat, inboundChain,
+			"-p", appProtos[i], "--dport", strconv.Itoa(appPort),
+			"-j", "REDIRECT", "--to-ports", strconv.Itoa(cfg.AgentPort+i))
+		if err != nil {
+			return fmt.Errorf("failed to append rule to %s: %w", inboundChain, err)
+		}
 	}
 	// Direct everything coming into PREROUTING into our own inbound chain.
 	// We do this as an append instead of an insert because this will prevent us from interfering with a service mesh
 	// if one exists. If a service mesh exists, its PREROUTING rules will kick in before ours, ensuring traffic
 	// coming into the pod does not bypass the mesh.
-	err = iptables.AppendUnique(nat, "PREROUTING",
-		"-p", cfg.AgentProtocol,
-		"-j", inboundChain)
-	if err != nil {
-		return fmt.Errorf("failed to append prerouting rule to direct to %s: %w", inboundChain, err)
+	for _, proto := range protos {
+		err = iptables.AppendUnique(nat, "PREROUTING",
+			"-p", proto,
+			"-j", inboundChain)
+		if err != nil {
+			return fmt.Errorf("failed to append prerouting rule to direct to %s: %w", inboundChain, err)
+		}
 	}
 	// Any traffic heading out of the loopback and into the app port (other than traffic from the agent) needs to
 	// be redirected to the agent. This will ensure that if there's a service mesh, when the mesh's proxy goes to
@@ -65,27 +100,73 @@ func configureIptables(ctx context.Context, iptables *iptables.IPTables, loopbac
 	// it needs to be redirected. This is so that if the traffic agent requests its own IP, it doesn't just
 	// serve the app but actually goes through the agent, and thus through any intercepts.
 	// This is needed to support requesting an intercepted pod by IP (or to intercept a headless service).
-	err = iptables.Insert(nat, "OUTPUT", 1,
-		"-o", loopback,
-		"-p", cfg.AgentProtocol,
-		"!", "-d", "127.0.0.1/32",
-		"-m", "owner", "--gid-owner", agentUID,
-		"-j", inboundChain)
-	if err != nil {
-		return fmt.Errorf("failed to insert --gid-owner rule in OUTPUT: %w", err)
+	for _, proto := range protos {
+		err = iptables.Insert(nat, "OUTPUT", 1,
+			"-o", loopback,
+			"-p", proto,
+			"!", "-d", "127.0.0.1/32",
+			"-m", "owner", "--gid-owner", agentUID,
+			"-j", inboundChain)
+		if err != nil {
+			return fmt.Errorf("failed to insert --gid-owner rule in OUTPUT: %w", err)
+		}
 	}
 	// Finally, any other traffic heading out of the traffic agent should pass by unperturbed -- it should obviously not be
 	// redirected back into the agent, but it also should not pass through a mesh proxy.
 	// This will include not just agent->manager traffic but also the agent requesting 127.0.0.1:appPort to serve the application
-	err = iptables.Insert(nat, "OUTPUT", 2,
+	err = iptables.Insert(nat, "OUTPUT", 1+len(protos),
 		"-m", "owner", "--gid-owner", agentUID,
 		"-j", "RETURN")
 	if err != nil {
 		return fmt.Errorf("failed to insert --gid-owner rule in OUTPUT: %w", err)
 	}
+	return configureEgress(iptables, agentUID, cfg.AgentPort+len(appPorts), cfg.EgressDestinations)
+}
+
+// configureEgress adds rules that redirect outbound TCP connections to the given destinations to consecutive agent
+// ports, starting with firstPort. Connections made by the agent itself are not redirected, so that the agent can
+// connect to the destinations when they aren't intercepted. iptables resolves a host name when the rule is added, so
+// the rule keeps matching the addresses that the host had when the init container ran.
+func configureEgress(iptables *iptables.IPTables, agentUID string, firstPort int, destinations []string) error {
+	if len(destinations) == 0 {
+		return nil
+	}
+	err := iptables.ClearChain(nat, outboundChain)
+	if err != nil {
+		return fmt.Errorf("failed to clear chain %s: %w", outboundChain, err)
+	}
+	for i, dest := range destinations {
+		host, port, err := net.SplitHostPort(dest)
+		if err != nil {
+			return fmt.Errorf("invalid egress destination %q: %w", dest, err)
+		}
+		err = iptables.AppendUnique(nat, outboundChain,
+			"-p", "tcp", "-d", host, "--dport", port,
+			"-j", "REDIRECT", "--to-ports", strconv.Itoa(firstPort+i))
+		if err != nil {
+			return fmt.Errorf("failed to append rule to %s: %w", outboundChain, err)
+		}
+	}
+	// Insert first in OUTPUT, so that the connections are redirected before they reach a service mesh.
+	err = iptables.Insert(nat, "OUTPUT", 1,
+		"-p", "tcp",
+		"-m", "owner", "!", "--gid-owner", agentUID,
+		"-j", outboundChain)
+	if err != nil {
+		return fmt.Errorf("failed to insert rule in OUTPUT to direct to %s: %w", outboundChain, err)
+	}
 	return nil
 }
 
+func contains(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
 func findLoopback(ctx context.Context) (string, error) {
 	ifaces, err := net.Interfaces()
 	if err != nil {
@@ -17,6 +17,11 @@ func validateClient(client *rpc.ClientInfo) string {
 	case client.Version == "":
 		return "version must not be empty"
 	}
+	for _, rule := range client.EgressRules {
+		if rule.Workload == "" || rule.Namespace == "" {
+			return "egress rule workload and namespace must not be empty"
+		}
+	}
 
 	return ""
 }
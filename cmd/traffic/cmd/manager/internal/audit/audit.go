@@ -0,0 +1,159 @@
+// Package audit records the lifecycle of intercepts, so that it's possible to find out who intercepted
+// what and when.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/watchable"
+	"github.com/datawire/dlib/dlog"
+)
+
+// Action is a transition in the lifecycle of an intercept.
+type Action string
+
+const (
+	ActionCreate   = Action("create")
+	ActionActivate = Action("activate")
+	ActionFail     = Action("fail")
+	ActionSuspend  = Action("suspend")
+	ActionRemove   = Action("remove")
+)
+
+// Entry describes one transition of an intercept. It's written to the audit log as one line of JSON.
+type Entry struct {
+	Time          time.Time `json:"time"`
+	Action        Action    `json:"action"`
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	Client        string    `json:"client"`
+	Namespace     string    `json:"namespace"`
+	Workload      string    `json:"workload"`
+	WorkloadKind  string    `json:"workloadKind,omitempty"`
+	Mechanism     string    `json:"mechanism"`
+	MechanismArgs []string  `json:"mechanismArgs,omitempty"`
+	Disposition   string    `json:"disposition"`
+	Message       string    `json:"message,omitempty"`
+}
+
+// Auditor writes an Entry to the audit log, and creates a Kubernetes Event on the intercepted workload, for
+// each transition of an intercept.
+type Auditor struct {
+	log    io.Writer
+	events bool
+	now    func() time.Time
+}
+
+// NewAuditor returns an Auditor that writes to the given log, unless it's nil, and that creates Kubernetes
+// Events when events is true.
+func NewAuditor(log io.Writer, events bool) *Auditor {
+	return &Auditor{log: log, events: events, now: time.Now}
+}
+
+// OpenLog opens the audit log with the given path for appending. The path "-" denotes stdout, and an
+// empty path means that no audit log is written, in which case nil is returned.
+func OpenLog(path string) (io.Writer, error) {
+	switch path {
+	case "":
+		return nil, nil
+	case "-":
+		return os.Stdout, nil
+	default:
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open audit log: %w", err)
+		}
+		return f, nil
+	}
+}
+
+// Run records the transitions of the intercepts in the given snapshots until the channel is closed. The
+// intercepts of the first snapshot are not recorded, because they were recorded by the traffic-manager
+// that created them.
+func (a *Auditor) Run(ctx context.Context, snapshots <-chan watchable.InterceptMapSnapshot) error {
+	var known map[string]*rpc.InterceptInfo
+	for snapshot := range snapshots {
+		if known != nil {
+			for _, e := range transitions(known, snapshot.State, a.now()) {
+				a.record(ctx, e)
+			}
+		}
+		known = snapshot.State
+	}
+	return nil
+}
+
+func (a *Auditor) record(ctx context.Context, e *Entry) {
+	if a.log != nil {
+		if err := json.NewEncoder(a.log).Encode(e); err != nil {
+			dlog.Errorf(ctx, "unable to write audit log: %v", err)
+		}
+	}
+	if a.events {
+		if err := createEvent(ctx, e); err != nil {
+			dlog.Warnf(ctx, "unable to create %s event for intercept %s: %v", e.Action, e.Name, err)
+		}
+	}
+}
+
+// transitions returns the entries that describe how the intercepts changed from prev to cur.
+func transitions(prev, cur map[string]*rpc.InterceptInfo, now time.Time) []*Entry {
+	var entries []*Entry
+	for _, id := range sortedKeys(cur) {
+		ii := cur[id]
+		p, ok := prev[id]
+		if !ok {
+			entries = append(entries, newEntry(now, ActionCreate, ii))
+		} else if p.Disposition == ii.Disposition {
+			continue
+		}
+		switch {
+		case ii.Disposition == rpc.InterceptDispositionType_ACTIVE:
+			entries = append(entries, newEntry(now, ActionActivate, ii))
+		case ii.Disposition == rpc.InterceptDispositionType_SUSPENDED:
+			entries = append(entries, newEntry(now, ActionSuspend, ii))
+		case ii.Disposition > rpc.InterceptDispositionType_WAITING:
+			entries = append(entries, newEntry(now, ActionFail, ii))
+		}
+	}
+	for _, id := range sortedKeys(prev) {
+		if _, ok := cur[id]; !ok {
+			entries = append(entries, newEntry(now, ActionRemove, prev[id]))
+		}
+	}
+	return entries
+}
+
+func newEntry(now time.Time, action Action, ii *rpc.InterceptInfo) *Entry {
+	spec := ii.Spec
+	return &Entry{
+		Time:          now,
+		Action:        action,
+		ID:            ii.Id,
+		Name:          spec.Name,
+		Client:        spec.Client,
+		Namespace:     spec.Namespace,
+		Workload:      spec.Agent,
+		WorkloadKind:  spec.WorkloadKind,
+		Mechanism:     spec.Mechanism,
+		MechanismArgs: spec.MechanismArgs,
+		Disposition:   ii.Disposition.String(),
+		Message:       ii.Message,
+	}
+}
+
+func sortedKeys(m map[string]*rpc.InterceptInfo) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,93 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/watchable"
+	"github.com/TinderBackend/telepresence/v2/pkg/k8sapi"
+	"github.com/datawire/dlib/dlog"
+)
+
+func intercept(disposition rpc.InterceptDispositionType, message string) *rpc.InterceptInfo {
+	return &rpc.InterceptInfo{
+		Id: "session:alice-hello",
+		Spec: &rpc.InterceptSpec{
+			Name:          "alice-hello",
+			Client:        "alice@laptop",
+			Agent:         "hello",
+			WorkloadKind:  "Deployment",
+			Namespace:     "default",
+			Mechanism:     "http",
+			MechanismArgs: []string{"--http-header=x-user=alice"},
+		},
+		Disposition: disposition,
+		Message:     message,
+	}
+}
+
+func TestAuditor(t *testing.T) {
+	ki := fake.NewSimpleClientset(&apps.Deployment{
+		ObjectMeta: meta.ObjectMeta{Name: "hello", Namespace: "default", UID: "hello-uid"},
+	})
+	ctx := k8sapi.WithK8sInterface(dlog.NewTestContext(t, false), ki)
+
+	snapshots := []map[string]*rpc.InterceptInfo{
+		// The intercepts that exist when the auditor starts are not recorded
+		{},
+		{"session:alice-hello": intercept(rpc.InterceptDispositionType_WAITING, "")},
+		{"session:alice-hello": intercept(rpc.InterceptDispositionType_ACTIVE, "")},
+		{"session:alice-hello": intercept(rpc.InterceptDispositionType_SUSPENDED, "Client session is suspended")},
+		{"session:alice-hello": intercept(rpc.InterceptDispositionType_WAITING, "Waiting for Agent approval")},
+		{"session:alice-hello": intercept(rpc.InterceptDispositionType_AGENT_ERROR, "agent crashed")},
+		{},
+	}
+	ch := make(chan watchable.InterceptMapSnapshot, len(snapshots))
+	for _, s := range snapshots {
+		ch <- watchable.InterceptMapSnapshot{State: s}
+	}
+	close(ch)
+
+	log := &bytes.Buffer{}
+	a := NewAuditor(log, true)
+	a.now = func() time.Time { return time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC) }
+	require.NoError(t, a.Run(ctx, ch))
+
+	var actions []Action
+	dec := json.NewDecoder(log)
+	for dec.More() {
+		var e Entry
+		require.NoError(t, dec.Decode(&e))
+		assert.Equal(t, "alice@laptop", e.Client)
+		assert.Equal(t, "http", e.Mechanism)
+		assert.Equal(t, []string{"--http-header=x-user=alice"}, e.MechanismArgs)
+		actions = append(actions, e.Action)
+	}
+	assert.Equal(t, []Action{ActionCreate, ActionActivate, ActionSuspend, ActionFail, ActionRemove}, actions)
+
+	events, err := ki.CoreV1().Events("default").List(ctx, meta.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, events.Items, 5)
+	reasons := make(map[string]*core.Event)
+	for i := range events.Items {
+		ev := &events.Items[i]
+		assert.Equal(t, "hello-uid", string(ev.InvolvedObject.UID))
+		assert.Equal(t, "alice@laptop", ev.Annotations[ClientAnnotation])
+		reasons[ev.Reason] = ev
+	}
+	require.Contains(t, reasons, "InterceptFailed")
+	failed := reasons["InterceptFailed"]
+	assert.Equal(t, core.EventTypeWarning, failed.Type)
+	assert.Equal(t, "Intercept alice-hello of alice@laptop using the http mechanism --http-header=x-user=alice "+
+		"failed: AGENT_ERROR: agent crashed", failed.Message)
+}
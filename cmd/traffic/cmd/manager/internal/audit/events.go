@@ -0,0 +1,107 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/TinderBackend/telepresence/v2/pkg/k8sapi"
+)
+
+// Annotations of the Events that describe the intercept that they concern.
+const (
+	ClientAnnotation        = "telepresence.io/client"
+	MechanismAnnotation     = "telepresence.io/mechanism"
+	MechanismArgsAnnotation = "telepresence.io/mechanism-args"
+)
+
+// eventReasons are the reasons of the Events of each Action.
+var eventReasons = map[Action]string{
+	ActionCreate:   "InterceptCreated",
+	ActionActivate: "InterceptActivated",
+	ActionFail:     "InterceptFailed",
+	ActionSuspend:  "InterceptSuspended",
+	ActionRemove:   "InterceptRemoved",
+}
+
+// apiVersions are the API versions of the workload kinds that aren't in the apps/v1 group.
+var apiVersions = map[string]string{
+	"Job":     "batch/v1",
+	"CronJob": "batch/v1",
+	"Rollout": "argoproj.io/v1alpha1",
+}
+
+// createEvent creates an Event on the intercepted workload that describes the given entry.
+func createEvent(ctx context.Context, e *Entry) error {
+	wl, err := k8sapi.GetWorkload(ctx, e.Workload, e.Namespace, e.WorkloadKind)
+	if err != nil {
+		return err
+	}
+	apiVersion, ok := apiVersions[wl.GetKind()]
+	if !ok {
+		apiVersion = "apps/v1"
+	}
+	eventType := core.EventTypeNormal
+	if e.Action == ActionFail {
+		eventType = core.EventTypeWarning
+	}
+	now := meta.NewTime(e.Time)
+	ev := &core.Event{
+		ObjectMeta: meta.ObjectMeta{
+			// Several events may share the time of their entry, so the name uses the current time instead
+			Name:      fmt.Sprintf("%s.%x", e.Workload, time.Now().UnixNano()),
+			Namespace: e.Namespace,
+			Annotations: map[string]string{
+				ClientAnnotation:        e.Client,
+				MechanismAnnotation:     e.Mechanism,
+				MechanismArgsAnnotation: strings.Join(e.MechanismArgs, " "),
+			},
+		},
+		InvolvedObject: core.ObjectReference{
+			Kind:            wl.GetKind(),
+			APIVersion:      apiVersion,
+			Name:            wl.GetName(),
+			Namespace:       wl.GetNamespace(),
+			UID:             wl.GetUID(),
+			ResourceVersion: wl.GetResourceVersion(),
+		},
+		Reason:              eventReasons[e.Action],
+		Message:             eventMessage(e),
+		Type:                eventType,
+		Source:              core.EventSource{Component: "traffic-manager"},
+		FirstTimestamp:      now,
+		LastTimestamp:       now,
+		Count:               1,
+		ReportingController: "telepresence.io/traffic-manager",
+	}
+	_, err = k8sapi.GetK8sInterface(ctx).CoreV1().Events(e.Namespace).Create(ctx, ev, meta.CreateOptions{})
+	return err
+}
+
+func eventMessage(e *Entry) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Intercept %s of %s using the %s mechanism", e.Name, e.Client, e.Mechanism)
+	if len(e.MechanismArgs) > 0 {
+		fmt.Fprintf(&sb, " %s", strings.Join(e.MechanismArgs, " "))
+	}
+	switch e.Action {
+	case ActionCreate:
+		sb.WriteString(" was created")
+	case ActionActivate:
+		sb.WriteString(" is active")
+	case ActionFail:
+		fmt.Fprintf(&sb, " failed: %s", e.Disposition)
+		if e.Message != "" {
+			fmt.Fprintf(&sb, ": %s", e.Message)
+		}
+	case ActionSuspend:
+		sb.WriteString(" is suspended")
+	case ActionRemove:
+		sb.WriteString(" was removed")
+	}
+	return sb.String()
+}
@@ -0,0 +1,31 @@
+package authz
+
+import (
+	"context"
+	"fmt"
+
+	auth "k8s.io/api/authentication/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/pkg/k8sapi"
+)
+
+// VerifyToken verifies the given bearer token using a TokenReview and returns the Kubernetes identity that
+// it authenticates.
+func VerifyToken(ctx context.Context, token string) (*rpc.KubeIdentity, error) {
+	tr, err := k8sapi.GetK8sInterface(ctx).AuthenticationV1().TokenReviews().Create(ctx, &auth.TokenReview{
+		Spec: auth.TokenReviewSpec{Token: token},
+	}, meta.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to review token: %w", err)
+	}
+	if !tr.Status.Authenticated {
+		msg := tr.Status.Error
+		if msg == "" {
+			msg = "token is not authenticated"
+		}
+		return nil, fmt.Errorf("invalid token: %s", msg)
+	}
+	return &rpc.KubeIdentity{Username: tr.Status.User.Username, Groups: tr.Status.User.Groups}, nil
+}
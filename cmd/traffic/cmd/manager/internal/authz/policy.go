@@ -0,0 +1,154 @@
+// Package authz decides which clients that may intercept what, based on an intercept policy.
+package authz
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/pkg/k8sapi"
+)
+
+// PolicyKey is the key of the policy in the data of its ConfigMap.
+const PolicyKey = "policy.yaml"
+
+// Policy is an intercept policy. An intercept is allowed when it matches at least one of the rules.
+type Policy struct {
+	Rules []*Rule `json:"rules"`
+}
+
+// Rule allows intercepts made by the clients that it matches, of the workloads that it matches, using the
+// mechanisms that it matches. A rule without users, groups, or clients matches all clients, and an empty
+// list of namespaces or mechanisms, or an empty workload selector, matches everything. Names are matched
+// using shell file name patterns, e.g. "dev-*".
+type Rule struct {
+	// Users are matched against the Kubernetes user name that the client's identity was verified as.
+	Users []string `json:"users,omitempty"`
+
+	// Groups are matched against the Kubernetes groups that the client's identity was verified as.
+	Groups []string `json:"groups,omitempty"`
+
+	// Clients are matched against the name that the client reports, i.e. "user@hostname". That name
+	// isn't verified, so this is only suitable in clusters where all users are trusted.
+	Clients []string `json:"clients,omitempty"`
+
+	// Namespaces are matched against the namespace of the intercepted workload.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// WorkloadSelector is a label selector, e.g. "team=payments,tier!=db", that the labels of the
+	// intercepted workload must match.
+	WorkloadSelector string `json:"workloadSelector,omitempty"`
+
+	// Mechanisms are matched against the mechanism of the intercept, e.g. "tcp" or "http".
+	Mechanisms []string `json:"mechanisms,omitempty"`
+
+	selector labels.Selector
+}
+
+// Request describes an intercept that a client wants to create.
+type Request struct {
+	Client         *rpc.ClientInfo
+	Spec           *rpc.InterceptSpec
+	WorkloadLabels map[string]string
+}
+
+// ParsePolicy parses the given YAML into a Policy.
+func ParsePolicy(data []byte) (*Policy, error) {
+	p := &Policy{}
+	if err := yaml.UnmarshalStrict(data, p); err != nil {
+		return nil, fmt.Errorf("invalid intercept policy: %w", err)
+	}
+	for i, r := range p.Rules {
+		var err error
+		if r.selector, err = labels.Parse(r.WorkloadSelector); err != nil {
+			return nil, fmt.Errorf("invalid workloadSelector in intercept policy rule %d: %w", i+1, err)
+		}
+		for _, ps := range [][]string{r.Users, r.Groups, r.Clients, r.Namespaces, r.Mechanisms} {
+			for _, pattern := range ps {
+				if _, err = path.Match(pattern, ""); err != nil {
+					return nil, fmt.Errorf("invalid pattern %q in intercept policy rule %d: %w", pattern, i+1, err)
+				}
+			}
+		}
+	}
+	return p, nil
+}
+
+// LoadPolicy loads the policy from the ConfigMap with the given name and namespace.
+func LoadPolicy(ctx context.Context, namespace, name string) (*Policy, error) {
+	cm, err := k8sapi.GetK8sInterface(ctx).CoreV1().ConfigMaps(namespace).Get(ctx, name, meta.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get intercept policy: %w", err)
+	}
+	data, ok := cm.Data[PolicyKey]
+	if !ok {
+		return nil, fmt.Errorf("ConfigMap %s.%s has no %s", name, namespace, PolicyKey)
+	}
+	return ParsePolicy([]byte(data))
+}
+
+// NeedsWorkloadLabels returns true if the policy uses the labels of the intercepted workload.
+func (p *Policy) NeedsWorkloadLabels() bool {
+	for _, r := range p.Rules {
+		if r.WorkloadSelector != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows returns true if at least one rule of the policy allows the given request.
+func (p *Policy) Allows(req *Request) bool {
+	for _, r := range p.Rules {
+		if r.allows(req) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) allows(req *Request) bool {
+	if len(r.Users)+len(r.Groups)+len(r.Clients) > 0 && !r.matchesClient(req.Client) {
+		return false
+	}
+	return matchesAny(r.Namespaces, req.Spec.Namespace) &&
+		matchesAny(r.Mechanisms, req.Spec.Mechanism) &&
+		(r.selector == nil || r.selector.Empty() || r.selector.Matches(labels.Set(req.WorkloadLabels)))
+}
+
+func (r *Rule) matchesClient(client *rpc.ClientInfo) bool {
+	if id := client.KubeIdentity; id != nil {
+		if matchesOne(r.Users, id.Username) {
+			return true
+		}
+		for _, g := range id.Groups {
+			if matchesOne(r.Groups, g) {
+				return true
+			}
+		}
+	}
+	return matchesOne(r.Clients, client.Name)
+}
+
+// matchesAny returns true if the given list of patterns is empty or if one of its patterns matches the given name.
+func matchesAny(patterns []string, name string) bool {
+	return len(patterns) == 0 || matchesOne(patterns, name)
+}
+
+// matchesOne returns true if one of the given patterns matches the given name.
+func matchesOne(patterns []string, name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
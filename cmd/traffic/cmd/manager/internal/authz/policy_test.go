@@ -0,0 +1,113 @@
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	auth "k8s.io/api/authentication/v1"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/pkg/k8sapi"
+)
+
+const testPolicy = `
+rules:
+- groups: ["payments-devs"]
+  namespaces: ["payments", "payments-*"]
+  workloadSelector: "team=payments"
+- users: ["system:serviceaccount:ci:*"]
+  mechanisms: ["http"]
+- clients: ["bob@*"]
+  namespaces: ["sandbox"]
+`
+
+func TestPolicy_Allows(t *testing.T) {
+	p, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+	assert.True(t, p.NeedsWorkloadLabels())
+
+	dev := &rpc.ClientInfo{Name: "alice@laptop", KubeIdentity: &rpc.KubeIdentity{Username: "alice", Groups: []string{"payments-devs"}}}
+	ci := &rpc.ClientInfo{Name: "ci@runner", KubeIdentity: &rpc.KubeIdentity{Username: "system:serviceaccount:ci:deployer"}}
+	bob := &rpc.ClientInfo{Name: "bob@laptop"}
+	payments := map[string]string{"team": "payments"}
+
+	tests := []struct {
+		name    string
+		client  *rpc.ClientInfo
+		ns      string
+		mech    string
+		labels  map[string]string
+		allowed bool
+	}{
+		{"group, namespace, and labels match", dev, "payments-staging", "tcp", payments, true},
+		{"labels don't match", dev, "payments", "tcp", map[string]string{"team": "orders"}, false},
+		{"namespace doesn't match", dev, "orders", "tcp", payments, false},
+		{"user and mechanism match", ci, "orders", "http", nil, true},
+		{"mechanism doesn't match", ci, "orders", "tcp", nil, false},
+		{"client name matches", bob, "sandbox", "tcp", nil, true},
+		{"unverified client doesn't match users or groups", &rpc.ClientInfo{Name: "alice@laptop"}, "payments", "tcp", payments, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &Request{
+				Client:         tt.client,
+				Spec:           &rpc.InterceptSpec{Agent: "hello", Namespace: tt.ns, Mechanism: tt.mech},
+				WorkloadLabels: tt.labels,
+			}
+			assert.Equal(t, tt.allowed, p.Allows(req))
+		})
+	}
+}
+
+func TestParsePolicy_invalid(t *testing.T) {
+	for _, bad := range []string{
+		"rules: [{workloadSelector: 'team in payments'}]",
+		"rules: [{namespaces: ['[dev']}]",
+		"rules: [{user: [alice]}]",
+	} {
+		_, err := ParsePolicy([]byte(bad))
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	ki := fake.NewSimpleClientset(&core.ConfigMap{
+		ObjectMeta: meta.ObjectMeta{Name: "policy", Namespace: "ambassador"},
+		Data:       map[string]string{PolicyKey: testPolicy},
+	})
+	ctx := k8sapi.WithK8sInterface(context.Background(), ki)
+
+	p, err := LoadPolicy(ctx, "ambassador", "policy")
+	require.NoError(t, err)
+	assert.Len(t, p.Rules, 3)
+
+	_, err = LoadPolicy(ctx, "ambassador", "missing")
+	assert.Error(t, err)
+}
+
+func TestVerifyToken(t *testing.T) {
+	ki := fake.NewSimpleClientset()
+	ki.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		tr := action.(k8stesting.CreateAction).GetObject().(*auth.TokenReview)
+		if tr.Spec.Token == "good" {
+			tr.Status = auth.TokenReviewStatus{Authenticated: true, User: auth.UserInfo{Username: "alice", Groups: []string{"devs"}}}
+		}
+		return true, tr, nil
+	})
+	ctx := k8sapi.WithK8sInterface(context.Background(), ki)
+
+	id, err := VerifyToken(ctx, "good")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", id.Username)
+	assert.Equal(t, []string{"devs"}, id.Groups)
+
+	_, err = VerifyToken(ctx, "bad")
+	assert.Error(t, err)
+}
@@ -0,0 +1,154 @@
+// Package crd reconciles the telepresence.io/v1alpha1 Intercept custom resources into intercepts of the
+// traffic-manager.
+package crd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+)
+
+// InterceptResource is the resource of the Intercept custom resource definition.
+var InterceptResource = schema.GroupVersionResource{
+	Group:    "telepresence.io",
+	Version:  "v1alpha1",
+	Resource: "intercepts",
+}
+
+// defaultDialTimeout is the timeout of the traffic-manager's dial to the target when the Intercept doesn't
+// specify one. It's the same as the client's default.
+const defaultDialTimeout = 3 * time.Second
+
+// SessionID is the ID of the client session of the intercepts that are declared using Intercept resources.
+// No client owns that session, so the traffic-manager dials the target of those intercepts itself.
+const SessionID = "intercept-resources"
+
+// Intercept is an intercept that is declared using a custom resource.
+type Intercept struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   InterceptSpec   `json:"spec"`
+	Status InterceptStatus `json:"status,omitempty"`
+}
+
+// InterceptSpec mirrors the rpc.InterceptSpec fields that make sense for an intercept that has no client.
+type InterceptSpec struct {
+	// Workload is the name of the intercepted workload, in the namespace of the Intercept
+	Workload string `json:"workload"`
+
+	// WorkloadKind is the kind of the intercepted workload. Defaults to Deployment
+	WorkloadKind string `json:"workloadKind,omitempty"`
+
+	// Mechanism decides which requests that are intercepted. Defaults to "tcp"
+	Mechanism string `json:"mechanism,omitempty"`
+
+	// MechanismArgs are the CLI-style flags of the mechanism, e.g. "--http-header=x-user=alice"
+	MechanismArgs []string `json:"mechanismArgs,omitempty"`
+
+	// ServicePortIdentifier is the name or number of the intercepted service port
+	ServicePortIdentifier string `json:"servicePortIdentifier,omitempty"`
+
+	// ContainerPort is the container port that the service port maps to
+	ContainerPort int32 `json:"containerPort,omitempty"`
+
+	// TargetHost is the IP address or host name that intercepted traffic is sent to
+	TargetHost string `json:"targetHost"`
+
+	// TargetPort is the port that intercepted traffic is sent to
+	TargetPort int32 `json:"targetPort"`
+
+	// Mirror sends copies of the intercepted traffic to the target instead of taking it over
+	Mirror bool `json:"mirror,omitempty"`
+
+	// DialTimeout is the timeout of the dial to the target, e.g. "5s". Defaults to 3s
+	DialTimeout string `json:"dialTimeout,omitempty"`
+}
+
+// InterceptStatus reports the state of the intercept that an Intercept is reconciled into.
+type InterceptStatus struct {
+	Disposition string `json:"disposition,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+// fromUnstructured converts the given unstructured object into an Intercept.
+func fromUnstructured(u *unstructured.Unstructured) (*Intercept, error) {
+	ic := &Intercept{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, ic); err != nil {
+		return nil, fmt.Errorf("invalid Intercept %s.%s: %w", u.GetName(), u.GetNamespace(), err)
+	}
+	return ic, nil
+}
+
+// interceptName returns the name of the intercept of the Intercept with the given name and namespace. A
+// namespace never contains dots, so the name is unique.
+func interceptName(name, namespace string) string {
+	return name + "." + namespace
+}
+
+// interceptSpec returns the spec of the intercept that the given Intercept declares. A target host that
+// isn't an IP address is resolved, because the traffic-agent identifies the connections that it sends
+// to the target using the target's IP. The given current IP is retained when the host still resolves to
+// it, so that a host with several IPs doesn't cause the intercept to change.
+func interceptSpec(ctx context.Context, ic *Intercept, currentIP string) (*rpc.InterceptSpec, error) {
+	s := &ic.Spec
+	if s.Workload == "" {
+		return nil, fmt.Errorf("spec.workload is required")
+	}
+	if s.TargetHost == "" {
+		return nil, fmt.Errorf("spec.targetHost is required")
+	}
+	if s.TargetPort <= 0 || s.TargetPort > 0xffff {
+		return nil, fmt.Errorf("spec.targetPort %d is not a valid port", s.TargetPort)
+	}
+	spec := &rpc.InterceptSpec{
+		Name:                  interceptName(ic.Name, ic.Namespace),
+		Client:                SessionID,
+		Agent:                 s.Workload,
+		WorkloadKind:          s.WorkloadKind,
+		Namespace:             ic.Namespace,
+		Mechanism:             s.Mechanism,
+		MechanismArgs:         s.MechanismArgs,
+		TargetHost:            s.TargetHost,
+		TargetPort:            s.TargetPort,
+		ServicePortIdentifier: s.ServicePortIdentifier,
+		ContainerPort:         s.ContainerPort,
+		Mirror:                s.Mirror,
+		DialTimeout:           int64(defaultDialTimeout),
+	}
+	if spec.WorkloadKind == "" {
+		spec.WorkloadKind = "Deployment"
+	}
+	if spec.Mechanism == "" {
+		spec.Mechanism = "tcp"
+	}
+	if s.DialTimeout != "" {
+		d, err := time.ParseDuration(s.DialTimeout)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("spec.dialTimeout %q is not a valid duration", s.DialTimeout)
+		}
+		spec.DialTimeout = int64(d)
+	}
+	if net.ParseIP(s.TargetHost) == nil {
+		ips, err := net.DefaultResolver.LookupIP(ctx, "ip", s.TargetHost)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve spec.targetHost %q: %w", s.TargetHost, err)
+		}
+		spec.TargetHost = ips[0].String()
+		for _, ip := range ips {
+			if ip.String() == currentIP {
+				spec.TargetHost = currentIP
+				break
+			}
+		}
+	}
+	return spec, nil
+}
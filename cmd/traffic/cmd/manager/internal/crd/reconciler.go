@@ -0,0 +1,212 @@
+package crd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/watchable"
+	"github.com/datawire/dlib/dlog"
+)
+
+// resyncPeriod is how often all Intercept resources are reconciled, regardless of whether they changed.
+const resyncPeriod = time.Minute
+
+// expiredDisposition is the disposition that the status of an Intercept resource reports when its
+// intercept has expired.
+const expiredDisposition = "EXPIRED"
+
+// State is the part of the traffic-manager's state that the Reconciler uses.
+type State interface {
+	GetIntercept(interceptID string) (*rpc.InterceptInfo, bool)
+	RemoveIntercept(interceptID string) bool
+	WatchIntercepts(ctx context.Context, filter func(sessionID string, intercept *rpc.InterceptInfo) bool) <-chan watchable.InterceptMapSnapshot
+}
+
+// AddFunc adds the intercept that an Intercept resource declares to the traffic-manager's state. The
+// traffic-manager's AddFunc applies the same intercept policy and maximum time-to-live to the intercept
+// as it applies to the intercepts that clients create.
+type AddFunc func(ctx context.Context, spec *rpc.InterceptSpec) (*rpc.InterceptInfo, error)
+
+// Reconciler adds an intercept to the traffic-manager's state for each Intercept resource, removes the
+// intercepts of Intercept resources that are deleted, and reports the disposition and message of each
+// intercept in the status of its resource.
+type Reconciler struct {
+	client     dynamic.Interface
+	state      State
+	add        AddFunc
+	namespaces []string
+
+	// added are the intercepts that were last added for the resources, by intercept ID. They tell an
+	// intercept that has expired, and mustn't be added again, from one that was never added.
+	added map[string]addedIntercept
+}
+
+// addedIntercept is an intercept that was added for the resource with the given UID.
+type addedIntercept struct {
+	uid types.UID
+	ii  *rpc.InterceptInfo
+}
+
+// expired returns true if the intercept was added for the given resource with the given spec, and
+// has expired.
+func (a addedIntercept) expired(u *unstructured.Unstructured, spec *rpc.InterceptSpec) bool {
+	return a.ii != nil && a.uid == u.GetUID() && proto.Equal(a.ii.Spec, spec) &&
+		a.ii.ExpiresAt != nil && !a.ii.ExpiresAt.AsTime().After(time.Now())
+}
+
+// NewReconciler returns a Reconciler of the Intercept resources in the given namespaces, or in all
+// namespaces when no namespaces are given. Intercepts are added using the given function.
+func NewReconciler(client dynamic.Interface, state State, add AddFunc, namespaces []string) *Reconciler {
+	if len(namespaces) == 0 {
+		namespaces = []string{meta.NamespaceAll}
+	}
+	return &Reconciler{client: client, state: state, add: add, namespaces: namespaces, added: make(map[string]addedIntercept)}
+}
+
+// Run reconciles the Intercept resources until the given context is cancelled.
+func (r *Reconciler) Run(ctx context.Context) error {
+	changed := make(chan struct{}, 1)
+	poke := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { poke() },
+		UpdateFunc: func(interface{}, interface{}) { poke() },
+		DeleteFunc: func(interface{}) { poke() },
+	}
+
+	stores := make([]cache.Store, len(r.namespaces))
+	for i, ns := range r.namespaces {
+		f := dynamicinformer.NewFilteredDynamicSharedInformerFactory(r.client, resyncPeriod, ns, nil)
+		informer := f.ForResource(InterceptResource).Informer()
+		informer.AddEventHandler(handler)
+		stores[i] = informer.GetStore()
+		f.Start(ctx.Done())
+		for _, synced := range f.WaitForCacheSync(ctx.Done()) {
+			if !synced {
+				return nil
+			}
+		}
+	}
+
+	intercepts := r.state.WatchIntercepts(ctx, func(_ string, ii *rpc.InterceptInfo) bool {
+		return ii.ClientSession.SessionId == SessionID
+	})
+	var current map[string]*rpc.InterceptInfo
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case snapshot, ok := <-intercepts:
+			if !ok {
+				return nil
+			}
+			current = snapshot.State
+		case <-changed:
+		}
+		var resources []*unstructured.Unstructured
+		for _, store := range stores {
+			for _, obj := range store.List() {
+				if u, ok := obj.(*unstructured.Unstructured); ok {
+					resources = append(resources, u)
+				}
+			}
+		}
+		r.reconcile(ctx, resources, current)
+	}
+}
+
+// interceptID returns the ID that the state gives to the intercept with the given name.
+func interceptID(name string) string {
+	return SessionID + ":" + name
+}
+
+// reconcile adds, replaces, and removes intercepts so that they match the given Intercept resources, and
+// updates the status of each resource. The given intercepts are the ones that were added for resources, as
+// seen by the last snapshot of the state. Intercepts that were added after that snapshot are looked up in
+// the state. An intercept that has expired isn't added again until its resource changes or is recreated.
+func (r *Reconciler) reconcile(ctx context.Context, resources []*unstructured.Unstructured, intercepts map[string]*rpc.InterceptInfo) {
+	declared := make(map[string]struct{}, len(resources))
+	for _, u := range resources {
+		name := interceptName(u.GetName(), u.GetNamespace())
+		id := interceptID(name)
+		declared[id] = struct{}{}
+		ii, ok := r.state.GetIntercept(id)
+		ic, err := fromUnstructured(u)
+		var spec *rpc.InterceptSpec
+		if err == nil {
+			spec, err = interceptSpec(ctx, ic, ii.GetSpec().GetTargetHost())
+		}
+		if err != nil {
+			r.state.RemoveIntercept(id)
+			r.updateStatus(ctx, u, ic, InterceptStatus{Disposition: rpc.InterceptDispositionType_BAD_ARGS.String(), Message: err.Error()})
+			continue
+		}
+
+		if ok && !proto.Equal(ii.Spec, spec) {
+			dlog.Infof(ctx, "Intercept resource %s changed, replacing its intercept", name)
+			r.state.RemoveIntercept(id)
+			ok = false
+		}
+		if !ok && r.added[id].expired(u, spec) {
+			r.updateStatus(ctx, u, ic, InterceptStatus{
+				Disposition: expiredDisposition,
+				Message:     fmt.Sprintf("intercept expired at %s", r.added[id].ii.ExpiresAt.AsTime().Format(time.RFC3339)),
+			})
+			continue
+		}
+		if !ok {
+			dlog.Infof(ctx, "Adding intercept for Intercept resource %s", name)
+			if ii, err = r.add(ctx, spec); err != nil {
+				r.updateStatus(ctx, u, ic, InterceptStatus{Disposition: rpc.InterceptDispositionType_BAD_ARGS.String(), Message: err.Error()})
+				continue
+			}
+			r.added[id] = addedIntercept{uid: u.GetUID(), ii: ii}
+		}
+		r.updateStatus(ctx, u, ic, InterceptStatus{Disposition: ii.Disposition.String(), Message: ii.Message})
+	}
+
+	for id, ii := range intercepts {
+		if _, ok := declared[id]; !ok {
+			dlog.Infof(ctx, "Intercept resource %s was deleted, removing its intercept", ii.Spec.Name)
+			r.state.RemoveIntercept(id)
+		}
+	}
+	for id := range r.added {
+		if _, ok := declared[id]; !ok {
+			delete(r.added, id)
+		}
+	}
+}
+
+// updateStatus updates the status of the given resource unless it already has the given status.
+func (r *Reconciler) updateStatus(ctx context.Context, u *unstructured.Unstructured, ic *Intercept, status InterceptStatus) {
+	if ic != nil && ic.Status == status {
+		return
+	}
+	u = u.DeepCopy()
+	if err := unstructured.SetNestedStringMap(u.Object, map[string]string{
+		"disposition": status.Disposition,
+		"message":     status.Message,
+	}, "status"); err != nil {
+		dlog.Errorf(ctx, "unable to set status of Intercept resource %s.%s: %v", u.GetName(), u.GetNamespace(), err)
+		return
+	}
+	_, err := r.client.Resource(InterceptResource).Namespace(u.GetNamespace()).UpdateStatus(ctx, u, meta.UpdateOptions{})
+	if err != nil {
+		dlog.Errorf(ctx, "unable to update status of Intercept resource %s.%s: %v", u.GetName(), u.GetNamespace(), err)
+	}
+}
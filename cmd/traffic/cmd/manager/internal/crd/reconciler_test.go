@@ -0,0 +1,164 @@
+package crd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/state"
+	"github.com/datawire/dlib/dlog"
+)
+
+func newIntercept(name string, spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "telepresence.io/v1alpha1",
+		"kind":       "Intercept",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": "default",
+		},
+		"spec": spec,
+	}}
+}
+
+// addFunc returns an AddFunc that adds intercepts to the given state, and makes them expire after
+// the given time-to-live unless it's zero.
+func addFunc(s *state.State, ttl time.Duration) AddFunc {
+	return func(_ context.Context, spec *rpc.InterceptSpec) (*rpc.InterceptInfo, error) {
+		ii, err := s.AddIntercept(SessionID, "", spec)
+		if err != nil || ttl == 0 {
+			return ii, err
+		}
+		return s.UpdateIntercept(ii.Id, func(ii *rpc.InterceptInfo) {
+			ii.ExpiresAt = timestamppb.New(time.Now().Add(ttl))
+		}), nil
+	}
+}
+
+func TestReconciler(t *testing.T) {
+	ctx, cancel := context.WithCancel(dlog.NewTestContext(t, false))
+	defer cancel()
+
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{InterceptResource: "InterceptList"},
+		newIntercept("preview", map[string]interface{}{
+			"workload":      "hello",
+			"mechanism":     "http",
+			"mechanismArgs": []interface{}{"--http-header=x-user=alice"},
+			"targetHost":    "10.0.0.5",
+			"targetPort":    int64(8080),
+		}),
+		newIntercept("broken", map[string]interface{}{
+			"workload": "hello",
+		}))
+	resources := client.Resource(InterceptResource).Namespace("default")
+	s := state.NewState(ctx)
+	go func() {
+		_ = NewReconciler(client, s, addFunc(s, 0), nil).Run(ctx)
+	}()
+
+	status := func(name string) string {
+		u, err := resources.Get(ctx, name, meta.GetOptions{})
+		if err != nil {
+			return ""
+		}
+		d, _, _ := unstructured.NestedString(u.Object, "status", "disposition")
+		return d
+	}
+
+	// The intercept is added. No agent exists for it, and that's reported in the status
+	id := interceptID("preview.default")
+	require.Eventually(t, func() bool { return status("preview") == rpc.InterceptDispositionType_NO_AGENT.String() }, 5*time.Second, 10*time.Millisecond)
+	ii, ok := s.GetIntercept(id)
+	require.True(t, ok)
+	assert.Equal(t, SessionID, ii.ClientSession.SessionId)
+	assert.Equal(t, "hello", ii.Spec.Agent)
+	assert.Equal(t, "default", ii.Spec.Namespace)
+	assert.Equal(t, "Deployment", ii.Spec.WorkloadKind)
+	assert.Equal(t, []string{"--http-header=x-user=alice"}, ii.Spec.MechanismArgs)
+	assert.Equal(t, int64(defaultDialTimeout), ii.Spec.DialTimeout)
+
+	// An invalid resource isn't added, and the reason is reported in its status
+	require.Eventually(t, func() bool { return status("broken") == rpc.InterceptDispositionType_BAD_ARGS.String() }, 5*time.Second, 10*time.Millisecond)
+	_, ok = s.GetIntercept(interceptID("broken.default"))
+	assert.False(t, ok)
+
+	// A changed resource replaces its intercept
+	u, err := resources.Get(ctx, "preview", meta.GetOptions{})
+	require.NoError(t, err)
+	require.NoError(t, unstructured.SetNestedField(u.Object, int64(9090), "spec", "targetPort"))
+	_, err = resources.Update(ctx, u, meta.UpdateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		ii, ok := s.GetIntercept(id)
+		return ok && ii.Spec.TargetPort == 9090
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// A deleted resource removes its intercept
+	require.NoError(t, resources.Delete(ctx, "preview", meta.DeleteOptions{}))
+	require.Eventually(t, func() bool {
+		_, ok := s.GetIntercept(id)
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestReconcilerExpired(t *testing.T) {
+	ctx, cancel := context.WithCancel(dlog.NewTestContext(t, false))
+	defer cancel()
+
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{InterceptResource: "InterceptList"},
+		newIntercept("preview", map[string]interface{}{
+			"workload":   "hello",
+			"targetHost": "10.0.0.5",
+			"targetPort": int64(8080),
+		}))
+	resources := client.Resource(InterceptResource).Namespace("default")
+	s := state.NewState(ctx)
+	go func() {
+		_ = NewReconciler(client, s, addFunc(s, 100*time.Millisecond), nil).Run(ctx)
+	}()
+
+	status := func(name string) string {
+		u, err := resources.Get(ctx, name, meta.GetOptions{})
+		if err != nil {
+			return ""
+		}
+		d, _, _ := unstructured.NestedString(u.Object, "status", "disposition")
+		return d
+	}
+
+	id := interceptID("preview.default")
+	require.Eventually(t, func() bool {
+		_, ok := s.GetIntercept(id)
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// An expired intercept isn't added again
+	time.Sleep(200 * time.Millisecond)
+	s.ExpireIntercepts(ctx, time.Now())
+	require.Eventually(t, func() bool { return status("preview") == expiredDisposition }, 5*time.Second, 10*time.Millisecond)
+	_, ok := s.GetIntercept(id)
+	assert.False(t, ok)
+
+	// A changed resource adds a new intercept
+	u, err := resources.Get(ctx, "preview", meta.GetOptions{})
+	require.NoError(t, err)
+	require.NoError(t, unstructured.SetNestedField(u.Object, int64(9090), "spec", "targetPort"))
+	_, err = resources.Update(ctx, u, meta.UpdateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		ii, ok := s.GetIntercept(id)
+		return ok && ii.Spec.TargetPort == 9090
+	}, 5*time.Second, 10*time.Millisecond)
+}
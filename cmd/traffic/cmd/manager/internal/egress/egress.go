@@ -0,0 +1,39 @@
+// Package egress decides which traffic-agent originates the outbound connections of a client, so that
+// those connections leave with the network identity of a chosen pod.
+package egress
+
+import (
+	"net"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/pkg/iputil"
+)
+
+// NamespaceMatcher tells if an IP belongs to a namespace.
+type NamespaceMatcher interface {
+	// InNamespace returns true if a pod or a service in the given namespace has the given IP.
+	InNamespace(ip net.IP, namespace string) bool
+}
+
+// Match returns the first of the given rules that applies to the destination, or nil if no rule
+// applies. Rules that match on namespaces never apply when the NamespaceMatcher is nil.
+func Match(rules []*rpc.EgressRule, dest net.IP, nm NamespaceMatcher) *rpc.EgressRule {
+	for _, r := range rules {
+		if len(r.Subnets) == 0 && len(r.Namespaces) == 0 {
+			return r
+		}
+		for _, sn := range r.Subnets {
+			if iputil.IPNetFromRPC(sn).Contains(dest) {
+				return r
+			}
+		}
+		if nm != nil {
+			for _, ns := range r.Namespaces {
+				if nm.InNamespace(dest, ns) {
+					return r
+				}
+			}
+		}
+	}
+	return nil
+}
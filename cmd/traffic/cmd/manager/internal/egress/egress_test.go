@@ -0,0 +1,87 @@
+package egress
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/pkg/iputil"
+	"github.com/TinderBackend/telepresence/v2/pkg/k8sapi"
+	"github.com/datawire/dlib/dlog"
+)
+
+type namespaceMap map[string]string
+
+func (m namespaceMap) InNamespace(ip net.IP, namespace string) bool {
+	return m[ip.String()] == namespace
+}
+
+func subnet(t *testing.T, cidr string) *rpc.IPNet {
+	_, sn, err := net.ParseCIDR(cidr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return iputil.IPNetToRPC(sn)
+}
+
+func TestMatch(t *testing.T) {
+	db := &rpc.EgressRule{Workload: "db-proxy", Namespace: "data", Subnets: []*rpc.IPNet{subnet(t, "10.10.0.0/16")}}
+	payments := &rpc.EgressRule{Workload: "gateway", Namespace: "payments", Namespaces: []string{"payments"}}
+	other := &rpc.EgressRule{Workload: "echo", Namespace: "default"}
+	nm := namespaceMap{"10.1.0.5": "payments"}
+
+	tests := []struct {
+		name  string
+		rules []*rpc.EgressRule
+		dest  string
+		nm    NamespaceMatcher
+		want  *rpc.EgressRule
+	}{
+		{"subnet", []*rpc.EgressRule{db, payments}, "10.10.3.4", nm, db},
+		{"namespace", []*rpc.EgressRule{db, payments}, "10.1.0.5", nm, payments},
+		{"no match", []*rpc.EgressRule{db, payments}, "10.1.0.6", nm, nil},
+		{"no namespace matcher", []*rpc.EgressRule{db, payments}, "10.1.0.5", nil, nil},
+		{"catch-all", []*rpc.EgressRule{db, payments, other}, "10.1.0.6", nm, other},
+		{"first rule wins", []*rpc.EgressRule{other, db}, "10.10.3.4", nm, other},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Match(tt.rules, net.ParseIP(tt.dest), tt.nm))
+		})
+	}
+}
+
+func TestIPIndex(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+	ctx = k8sapi.WithK8sInterface(ctx, fake.NewSimpleClientset(
+		&core.Pod{
+			ObjectMeta: meta.ObjectMeta{Name: "gateway", Namespace: "payments"},
+			Status:     core.PodStatus{PodIPs: []core.PodIP{{IP: "10.1.0.5"}}},
+		},
+		&core.Pod{
+			ObjectMeta: meta.ObjectMeta{Name: "node-exporter", Namespace: "payments"},
+			Spec:       core.PodSpec{HostNetwork: true},
+			Status:     core.PodStatus{PodIPs: []core.PodIP{{IP: "192.168.1.10"}}},
+		},
+		&core.Service{
+			ObjectMeta: meta.ObjectMeta{Name: "gateway", Namespace: "payments"},
+			Spec:       core.ServiceSpec{ClusterIPs: []string{"10.96.0.20"}},
+		},
+		&core.Service{
+			ObjectMeta: meta.ObjectMeta{Name: "headless", Namespace: "payments"},
+			Spec:       core.ServiceSpec{ClusterIPs: []string{"None"}},
+		},
+	))
+
+	nm := NewIPIndex(ctx)
+	assert.Eventually(t, func() bool { return nm.InNamespace(net.ParseIP("10.1.0.5"), "payments") }, 5*time.Second, 50*time.Millisecond)
+	assert.True(t, nm.InNamespace(net.ParseIP("10.96.0.20"), "payments"))
+	assert.False(t, nm.InNamespace(net.ParseIP("192.168.1.10"), "payments"))
+	assert.False(t, nm.InNamespace(net.ParseIP("10.1.0.5"), "default"))
+}
@@ -0,0 +1,118 @@
+package egress
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/TinderBackend/telepresence/v2/pkg/k8sapi"
+	"github.com/datawire/dlib/dlog"
+)
+
+const (
+	ipIndexName  = "ip"
+	syncTimeout  = 5 * time.Second
+	resyncPeriod = 10 * time.Minute
+)
+
+// ipIndex is a NamespaceMatcher that indexes the IPs of the pods and services of a namespace. The
+// informers of a namespace are started when the namespace is first asked for.
+type ipIndex struct {
+	sync.Mutex
+	ctx        context.Context
+	namespaces map[string]*namespaceIndex
+}
+
+type namespaceIndex struct {
+	synced    chan struct{}
+	informers []cache.SharedIndexInformer
+}
+
+// NewIPIndex returns a NamespaceMatcher that uses informers for the pods and the services of the
+// namespaces. The informers run until the given context is cancelled.
+func NewIPIndex(ctx context.Context) NamespaceMatcher {
+	return &ipIndex{ctx: ctx, namespaces: make(map[string]*namespaceIndex)}
+}
+
+func (x *ipIndex) InNamespace(ip net.IP, namespace string) bool {
+	x.Lock()
+	ni, ok := x.namespaces[namespace]
+	if !ok {
+		ni = x.startLocked(namespace)
+		x.namespaces[namespace] = ni
+	}
+	x.Unlock()
+
+	select {
+	case <-ni.synced:
+	case <-x.ctx.Done():
+		return false
+	}
+	key := ip.String()
+	for _, inf := range ni.informers {
+		if objs, err := inf.GetIndexer().ByIndex(ipIndexName, key); err == nil && len(objs) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (x *ipIndex) startLocked(namespace string) *namespaceIndex {
+	ctx := x.ctx
+	f := informers.NewSharedInformerFactoryWithOptions(k8sapi.GetK8sInterface(ctx), resyncPeriod, informers.WithNamespace(namespace))
+	pods := f.Core().V1().Pods().Informer()
+	svcs := f.Core().V1().Services().Informer()
+	_ = pods.AddIndexers(cache.Indexers{ipIndexName: podIPs})
+	_ = svcs.AddIndexers(cache.Indexers{ipIndexName: serviceIPs})
+	f.Start(ctx.Done())
+
+	ni := &namespaceIndex{synced: make(chan struct{}), informers: []cache.SharedIndexInformer{pods, svcs}}
+	go func() {
+		defer close(ni.synced)
+		tc, cancel := context.WithTimeout(ctx, syncTimeout)
+		defer cancel()
+		if !cache.WaitForCacheSync(tc.Done(), pods.HasSynced, svcs.HasSynced) {
+			// The index is used anyway. It will be complete once the informers have synced.
+			dlog.Warnf(ctx, "timeout waiting for the pods and services of namespace %s to sync", namespace)
+		}
+	}()
+	return ni
+}
+
+func podIPs(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*core.Pod)
+	if !ok || pod.Spec.HostNetwork {
+		// A pod on the host network has the IP of its node
+		return nil, nil
+	}
+	ips := make([]string, len(pod.Status.PodIPs))
+	for i, pip := range pod.Status.PodIPs {
+		ips[i] = pip.IP
+	}
+	return normalizeIPs(ips), nil
+}
+
+func serviceIPs(obj interface{}) ([]string, error) {
+	svc, ok := obj.(*core.Service)
+	if !ok {
+		return nil, nil
+	}
+	return normalizeIPs(svc.Spec.ClusterIPs), nil
+}
+
+// normalizeIPs returns the given IPs in the format of net.IP.String. Strings that aren't IPs, such as
+// the "None" of a headless service, are skipped.
+func normalizeIPs(ss []string) []string {
+	var ips []string
+	for _, s := range ss {
+		if ip := net.ParseIP(s); ip != nil {
+			ips = append(ips, ip.String())
+		}
+	}
+	return ips
+}
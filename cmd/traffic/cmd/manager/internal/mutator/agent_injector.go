@@ -75,9 +75,10 @@ func agentInjector(ctx context.Context, req *admission.AdmissionRequest) ([]patc
 		return nil, err
 	}
 
-	// The ServicePortAnnotation is expected to contain a string that identifies the service port.
+	// The ServicePortAnnotation is expected to contain a string that identifies the service port. The agent
+	// will front that port and all other ports of the service that map to the same container.
 	portNameOrNumber := pod.Annotations[install.ServicePortAnnotation]
-	servicePort, appContainer, containerPortIndex, err := install.FindMatchingPort(pod.Spec.Containers, portNameOrNumber, svc)
+	servicePorts, appContainer, containerPortIndexes, err := install.FindMatchingPorts(pod.Spec.Containers, portNameOrNumber, svc)
 	if err != nil {
 		err := fmt.Errorf("unable to find port to intercept; try the %s annotation: %w", install.ServicePortAnnotation, err)
 		dlog.Error(ctx, err)
@@ -88,47 +89,77 @@ func agentInjector(ctx context.Context, req *admission.AdmissionRequest) ([]patc
 		return nil, nil
 	}
 
+	// The agent also fronts the destinations of outbound connections that can be intercepted. They use the
+	// agent ports that follow the ones used for the service ports.
+	egress, err := install.EgressDestinations(pod.Annotations)
+	if err != nil {
+		dlog.Error(ctx, err)
+		return nil, err
+	}
+
 	env := managerutil.GetEnv(ctx)
 	ports := appContainer.Ports
 	for i := range ports {
-		if ports[i].ContainerPort == env.AgentPort {
-			err := fmt.Errorf("the %s pod container %s is exposing the same port (%d) as the %s sidecar", refPodName, appContainer.Name, env.AgentPort, install.AgentContainerName)
+		if cp := ports[i].ContainerPort; cp >= env.AgentPort && cp < env.AgentPort+int32(len(servicePorts)+len(egress)) {
+			err := fmt.Errorf("the %s pod container %s is exposing the same port (%d) as the %s sidecar", refPodName, appContainer.Name, cp, install.AgentContainerName)
 			dlog.Info(ctx, err)
 			return nil, err
 		}
 	}
 
-	var appPort core.ContainerPort
-	switch {
-	case containerPortIndex >= 0:
-		appPort = appContainer.Ports[containerPortIndex]
-	case servicePort.TargetPort.Type == intstr.Int:
-		appPort = core.ContainerPort{
-			Protocol:      servicePort.Protocol,
-			ContainerPort: servicePort.TargetPort.IntVal,
+	agentPorts := make([]install.AgentPort, len(servicePorts))
+	for i, servicePort := range servicePorts {
+		var appPort core.ContainerPort
+		switch {
+		case containerPortIndexes[i] >= 0:
+			appPort = appContainer.Ports[containerPortIndexes[i]]
+		case servicePort.TargetPort.Type == intstr.Int:
+			appPort = core.ContainerPort{
+				Protocol:      servicePort.Protocol,
+				ContainerPort: servicePort.TargetPort.IntVal,
+			}
+		default:
+			// This really shouldn't have happened: the target port is a string, but we weren't able to
+			// find a corresponding container port. This should've been caught in FindMatchingPorts, but in
+			// case it isn't, just return an error.
+			return nil, fmt.Errorf("container port unexpectedly not found in %s", refPodName)
+		}
+		proto := servicePort.Protocol
+		if proto == "" {
+			proto = appPort.Protocol
+		}
+		agentPort := core.ContainerPort{
+			Protocol:      proto,
+			ContainerPort: env.AgentPort + int32(i),
 		}
-	default:
-		// This really shouldn't have happened: the target port is a string, but we weren't able to
-		// find a corresponding container port. This should've been caught in FindMatchingPort, but in
-		// case it isn't, just return an error.
-		return nil, fmt.Errorf("container port unexpectedly not found in %s", refPodName)
+		if servicePort.TargetPort.Type == intstr.String {
+			agentPort.Name = servicePort.TargetPort.StrVal
+		}
+		agentPorts[i] = install.AgentPort{Port: agentPort, AppPort: int(appPort.ContainerPort)}
 	}
 
 	// Create patch operations to add the traffic-agent sidecar
 	dlog.Infof(ctx, "Injecting %s into pod %s", install.AgentContainerName, refPodName)
 
 	var patches []patchOperation
-	if servicePort.TargetPort.Type == intstr.Int || svc.Spec.ClusterIP == "None" {
-		patches = addInitContainer(ctx, &pod, servicePort, &appPort, patches)
-	} else {
-		patches = hidePorts(&pod, appContainer, servicePort.TargetPort.StrVal, patches)
+	needsInitContainer := svc.Spec.ClusterIP == "None" || len(egress) > 0
+	for i, servicePort := range servicePorts {
+		switch {
+		case servicePort.TargetPort.Type == intstr.Int:
+			needsInitContainer = true
+		case svc.Spec.ClusterIP != "None":
+			patches = hidePorts(&pod, appContainer, servicePort.TargetPort.StrVal, i, patches)
+		}
+	}
+	if needsInitContainer {
+		patches = addInitContainer(ctx, &pod, agentPorts, egress, patches)
 	}
 	tpEnv := make(map[string]string)
 	if env.APIPort != 0 {
 		tpEnv["TELEPRESENCE_API_PORT"] = strconv.Itoa(int(env.APIPort))
 	}
 	patches = addTPEnv(&pod, appContainer, tpEnv, patches)
-	patches, err = addAgentContainer(ctx, svc, &pod, servicePort, appContainer, &appPort, podName, podNamespace, patches)
+	patches, err = addAgentContainer(ctx, svc, &pod, servicePorts[0], appContainer, agentPorts, egress, podName, podNamespace, patches)
 	if err != nil {
 		return nil, err
 	}
@@ -136,20 +167,12 @@ func agentInjector(ctx context.Context, req *admission.AdmissionRequest) ([]patc
 	return patches, nil
 }
 
-func addInitContainer(ctx context.Context, pod *core.Pod, svcPort *core.ServicePort, appPort *core.ContainerPort, patches []patchOperation) []patchOperation {
+func addInitContainer(ctx context.Context, pod *core.Pod, agentPorts []install.AgentPort, egress []string, patches []patchOperation) []patchOperation {
 	env := managerutil.GetEnv(ctx)
-	proto := svcPort.Protocol
-	if proto == "" {
-		proto = appPort.Protocol
-	}
-	containerPort := core.ContainerPort{
-		Protocol:      proto,
-		ContainerPort: env.AgentPort,
-	}
 	container := install.InitContainer(
 		env.AgentRegistry+"/"+env.AgentImage,
-		containerPort,
-		int(appPort.ContainerPort),
+		agentPorts,
+		egress,
 	)
 
 	if pod.Spec.InitContainers == nil {
@@ -201,7 +224,8 @@ func addAgentContainer(
 	pod *core.Pod,
 	svcPort *core.ServicePort,
 	appContainer *core.Container,
-	appPort *core.ContainerPort,
+	agentPorts []install.AgentPort,
+	egress []string,
 	podName, namespace string,
 	patches []patchOperation,
 ) ([]patchOperation, error) {
@@ -229,12 +253,23 @@ func addAgentContainer(
 	owners:
 		for _, owner := range pod.OwnerReferences {
 			switch owner.Kind {
-			case "StatefulSet":
-				// If the pod is owned by a statefulset, the workload's name is the same as the statefulset's
+			case "StatefulSet", "DaemonSet":
+				// If the pod is owned by a statefulset or a daemonset, the workload's name is the same as the owner's
+				agentName = owner.Name
+				break owners
+			case "Job":
+				// If it's owned by a job, then it's the same as the job, unless the job was created by a cronjob
 				agentName = owner.Name
+				if job, err := k8sapi.GetJob(ctx, owner.Name, namespace); err == nil {
+					for _, jobOwner := range job.GetOwnerReferences() {
+						if jobOwner.Kind == "CronJob" {
+							agentName = jobOwner.Name
+						}
+					}
+				}
 				break owners
 			case "ReplicaSet":
-				// If it's owned by a replicaset, then it's the same as the deployment e.g. "my-echo-697464c6c5" -> "my-echo"
+				// If it's owned by a replicaset, then it's the same as the deployment or rollout e.g. "my-echo-697464c6c5" -> "my-echo"
 				tokens := strings.Split(owner.Name, "-")
 				agentName = strings.Join(tokens[:len(tokens)-1], "-")
 				break owners
@@ -251,17 +286,6 @@ func addAgentContainer(
 		}
 	}
 
-	proto := svcPort.Protocol
-	if proto == "" {
-		proto = appPort.Protocol
-	}
-	containerPort := core.ContainerPort{
-		Protocol:      proto,
-		ContainerPort: env.AgentPort,
-	}
-	if svcPort.TargetPort.Type == intstr.String {
-		containerPort.Name = svcPort.TargetPort.StrVal
-	}
 	patches = append(patches, patchOperation{
 		Op:   "add",
 		Path: "/spec/containers/-",
@@ -269,8 +293,8 @@ func addAgentContainer(
 			agentName,
 			env.AgentRegistry+"/"+env.AgentImage,
 			appContainer,
-			containerPort,
-			int(appPort.ContainerPort),
+			agentPorts,
+			egress,
 			k8sapi.GetAppProto(ctx, env.AppProtocolStrategy, svcPort),
 			int(env.APIPort),
 			env.ManagerNamespace,
@@ -321,8 +345,9 @@ func addTPEnv(pod *core.Pod, cn *core.Container, env map[string]string, patches
 }
 
 // hidePorts  will replace the symbolic name of a container port with a generated name. It will perform
-// the same replacement on all references to that port from the probes of the container
-func hidePorts(pod *core.Pod, cn *core.Container, portName string, patches []patchOperation) []patchOperation {
+// the same replacement on all references to that port from the probes of the container. The ordinal
+// is the zero based order of the port among all ports that are hidden in the container.
+func hidePorts(pod *core.Pod, cn *core.Container, portName string, ordinal int, patches []patchOperation) []patchOperation {
 	cns := pod.Spec.Containers
 	var containerPath string
 	for i := range cns {
@@ -332,7 +357,7 @@ func hidePorts(pod *core.Pod, cn *core.Container, portName string, patches []pat
 		}
 	}
 
-	hiddenPortName := install.HiddenPortName(portName, 0)
+	hiddenPortName := install.HiddenPortName(portName, ordinal)
 	hidePort := func(path string) {
 		patches = append(patches, patchOperation{
 			Op:    "replace",
@@ -0,0 +1,27 @@
+package mutator
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	injections = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "telepresence_manager_agent_injections_total",
+		Help: "Number of pods that the traffic-agent was injected into.",
+	})
+
+	injectionFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "telepresence_manager_agent_injection_failures_total",
+		Help: "Number of pods that the traffic-agent could not be injected into.",
+	})
+)
+
+// RegisterMetrics registers the Prometheus metrics of the agent injector with the given Registerer.
+func RegisterMetrics(r prometheus.Registerer) error {
+	for _, c := range []prometheus.Collector{injections, injectionFailures} {
+		if err := r.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
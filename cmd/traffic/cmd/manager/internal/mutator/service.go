@@ -155,12 +155,16 @@ func serveMutatingFunc(ctx context.Context, r *http.Request, mf mutatorFunc) ([]
 	if err != nil {
 		// If the handler returned an error, still allow the object creation, and incorporate
 		// the error message into the response
+		injectionFailures.Inc()
 		dlog.Errorf(ctx, "mutating function error: %v", err)
 		response.Allowed = false
 		response.Result = &metav1.Status{
 			Message: err.Error(),
 		}
 	} else {
+		if len(patchOps) > 0 {
+			injections.Inc()
+		}
 		// Otherwise, encode the patch operations to JSON and return a positive response.
 		patchBytes, err := json.Marshal(patchOps)
 		if err != nil {
@@ -0,0 +1,116 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typed "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"github.com/TinderBackend/telepresence/v2/pkg/k8sapi"
+)
+
+// snapshotKey is the key of the snapshot in the data of a ConfigMap or Secret.
+const snapshotKey = "state.json"
+
+var storeLabels = map[string]string{
+	"app.kubernetes.io/created-by": "traffic-manager",
+}
+
+func unmarshalSnapshot(data []byte) (*Snapshot, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	snapshot := &Snapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// configMapStore stores the snapshot in a ConfigMap.
+type configMapStore struct {
+	client typed.ConfigMapInterface
+	name   string
+}
+
+func newConfigMapStore(ctx context.Context, namespace, name string) Store {
+	return &configMapStore{client: k8sapi.GetK8sInterface(ctx).CoreV1().ConfigMaps(namespace), name: name}
+}
+
+func (s *configMapStore) Load(ctx context.Context) (*Snapshot, error) {
+	cm, err := s.client.Get(ctx, s.name, meta.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			err = nil
+		}
+		return nil, err
+	}
+	return unmarshalSnapshot([]byte(cm.Data[snapshotKey]))
+}
+
+func (s *configMapStore) Save(ctx context.Context, snapshot *Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	cm, err := s.client.Get(ctx, s.name, meta.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		_, err = s.client.Create(ctx, &core.ConfigMap{
+			ObjectMeta: meta.ObjectMeta{Name: s.name, Labels: storeLabels},
+			Data:       map[string]string{snapshotKey: string(data)},
+		}, meta.CreateOptions{})
+		return err
+	}
+	cm.Data = map[string]string{snapshotKey: string(data)}
+	_, err = s.client.Update(ctx, cm, meta.UpdateOptions{})
+	return err
+}
+
+// secretStore stores the snapshot in a Secret. It's the better choice when the intercepts carry API keys.
+type secretStore struct {
+	client typed.SecretInterface
+	name   string
+}
+
+func newSecretStore(ctx context.Context, namespace, name string) Store {
+	return &secretStore{client: k8sapi.GetK8sInterface(ctx).CoreV1().Secrets(namespace), name: name}
+}
+
+func (s *secretStore) Load(ctx context.Context) (*Snapshot, error) {
+	secret, err := s.client.Get(ctx, s.name, meta.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			err = nil
+		}
+		return nil, err
+	}
+	return unmarshalSnapshot(secret.Data[snapshotKey])
+}
+
+func (s *secretStore) Save(ctx context.Context, snapshot *Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	secret, err := s.client.Get(ctx, s.name, meta.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		_, err = s.client.Create(ctx, &core.Secret{
+			ObjectMeta: meta.ObjectMeta{Name: s.name, Labels: storeLabels},
+			Type:       core.SecretTypeOpaque,
+			Data:       map[string][]byte{snapshotKey: data},
+		}, meta.CreateOptions{})
+		return err
+	}
+	secret.Data = map[string][]byte{snapshotKey: data}
+	_, err = s.client.Update(ctx, secret, meta.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,115 @@
+// Package persistence persists the parts of the traffic-manager's state that must survive a restart of the
+// traffic-manager, i.e. the client sessions and their intercepts. Agent sessions aren't persisted, because the
+// traffic-agents arrive again using new sessions when the traffic-manager restarts.
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+)
+
+// Version is the version of the snapshot format.
+const Version = 1
+
+// Snapshot is the persisted state of a traffic-manager.
+type Snapshot struct {
+	// Clients are the client sessions, keyed by session ID
+	Clients map[string]*rpc.ClientInfo
+
+	// Intercepts are the intercepts of the client sessions
+	Intercepts []*rpc.InterceptInfo
+
+	// ResumeTokenHashes are the hex encoded SHA-256 hashes of the tokens that the clients use to resume
+	// their sessions, keyed by session ID. The tokens themselves are never persisted.
+	ResumeTokenHashes map[string]string
+}
+
+// A Store loads and saves snapshots.
+type Store interface {
+	// Load returns the saved snapshot, or nil if no snapshot has been saved.
+	Load(ctx context.Context) (*Snapshot, error)
+
+	// Save saves the given snapshot, replacing the previously saved one.
+	Save(ctx context.Context, snapshot *Snapshot) error
+}
+
+// NewStore returns the Store of the given kind, which is either "configmap" or "secret". The snapshot is
+// stored in a ConfigMap or Secret with the given name and namespace. A nil Store is returned when the kind
+// is empty, meaning that the state shouldn't be persisted.
+func NewStore(ctx context.Context, kind, namespace, name string) (Store, error) {
+	switch strings.ToLower(kind) {
+	case "":
+		return nil, nil
+	case "configmap":
+		return newConfigMapStore(ctx, namespace, name), nil
+	case "secret":
+		return newSecretStore(ctx, namespace, name), nil
+	default:
+		return nil, fmt.Errorf("invalid state store %q, must be one of configmap or secret", kind)
+	}
+}
+
+type snapshotJSON struct {
+	Version           int                        `json:"version"`
+	Clients           map[string]json.RawMessage `json:"clients,omitempty"`
+	Intercepts        []json.RawMessage          `json:"intercepts,omitempty"`
+	ResumeTokenHashes map[string]string          `json:"resumeTokenHashes,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. The protobuf messages of the snapshot are encoded using protojson.
+func (s *Snapshot) MarshalJSON() ([]byte, error) {
+	sj := snapshotJSON{Version: Version, ResumeTokenHashes: s.ResumeTokenHashes}
+	if len(s.Clients) > 0 {
+		sj.Clients = make(map[string]json.RawMessage, len(s.Clients))
+		for id, client := range s.Clients {
+			data, err := protojson.Marshal(client)
+			if err != nil {
+				return nil, err
+			}
+			sj.Clients[id] = data
+		}
+	}
+	for _, ii := range s.Intercepts {
+		data, err := protojson.Marshal(ii)
+		if err != nil {
+			return nil, err
+		}
+		sj.Intercepts = append(sj.Intercepts, data)
+	}
+	return json.Marshal(&sj)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Snapshot) UnmarshalJSON(data []byte) error {
+	var sj snapshotJSON
+	if err := json.Unmarshal(data, &sj); err != nil {
+		return err
+	}
+	if sj.Version != Version {
+		return fmt.Errorf("unsupported snapshot version %d", sj.Version)
+	}
+	s.Clients = make(map[string]*rpc.ClientInfo, len(sj.Clients))
+	for id, data := range sj.Clients {
+		client := &rpc.ClientInfo{}
+		if err := protojson.Unmarshal(data, client); err != nil {
+			return fmt.Errorf("invalid client %s: %w", id, err)
+		}
+		s.Clients[id] = client
+	}
+	s.Intercepts = make([]*rpc.InterceptInfo, len(sj.Intercepts))
+	for i, data := range sj.Intercepts {
+		ii := &rpc.InterceptInfo{}
+		if err := protojson.Unmarshal(data, ii); err != nil {
+			return fmt.Errorf("invalid intercept: %w", err)
+		}
+		s.Intercepts[i] = ii
+	}
+	s.ResumeTokenHashes = sj.ResumeTokenHashes
+	return nil
+}
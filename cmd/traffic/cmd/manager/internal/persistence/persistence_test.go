@@ -0,0 +1,71 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/client-go/kubernetes/fake"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/persistence"
+	"github.com/TinderBackend/telepresence/v2/pkg/k8sapi"
+)
+
+func TestStore(t *testing.T) {
+	snapshot := &persistence.Snapshot{
+		Clients: map[string]*rpc.ClientInfo{
+			"session-1": {Name: "alice", InstallId: "install-1", Product: "telepresence", Version: "2.5.5"},
+		},
+		Intercepts: []*rpc.InterceptInfo{{
+			Id:            "session-1:hello",
+			Spec:          &rpc.InterceptSpec{Name: "hello", Agent: "hello", Namespace: "default", Mechanism: "tcp", TargetPort: 8080},
+			Disposition:   rpc.InterceptDispositionType_ACTIVE,
+			ClientSession: &rpc.SessionInfo{SessionId: "session-1"},
+			ApiKey:        "key",
+		}},
+		ResumeTokenHashes: map[string]string{"session-1": "hash-1"},
+	}
+
+	for _, kind := range []string{"configmap", "secret"} {
+		t.Run(kind, func(t *testing.T) {
+			ctx := k8sapi.WithK8sInterface(context.Background(), fake.NewSimpleClientset())
+			store, err := persistence.NewStore(ctx, kind, "ambassador", "traffic-manager-state")
+			require.NoError(t, err)
+
+			loaded, err := store.Load(ctx)
+			require.NoError(t, err)
+			assert.Nil(t, loaded, "nothing has been saved")
+
+			// The first save creates the ConfigMap or Secret, the second updates it
+			require.NoError(t, store.Save(ctx, &persistence.Snapshot{}))
+			require.NoError(t, store.Save(ctx, snapshot))
+
+			loaded, err = store.Load(ctx)
+			require.NoError(t, err)
+			require.NotNil(t, loaded)
+			require.Len(t, loaded.Clients, 1)
+			assert.True(t, proto.Equal(snapshot.Clients["session-1"], loaded.Clients["session-1"]))
+			require.Len(t, loaded.Intercepts, 1)
+			assert.True(t, proto.Equal(snapshot.Intercepts[0], loaded.Intercepts[0]))
+			assert.Equal(t, snapshot.ResumeTokenHashes, loaded.ResumeTokenHashes)
+		})
+	}
+}
+
+func TestNewStore(t *testing.T) {
+	store, err := persistence.NewStore(context.Background(), "", "ambassador", "traffic-manager-state")
+	require.NoError(t, err)
+	assert.Nil(t, store)
+
+	_, err = persistence.NewStore(context.Background(), "etcd", "ambassador", "traffic-manager-state")
+	assert.Error(t, err)
+}
+
+func TestSnapshot_UnmarshalJSON(t *testing.T) {
+	var snapshot persistence.Snapshot
+	assert.EqualError(t, snapshot.UnmarshalJSON([]byte(`{"version":2}`)), "unsupported snapshot version 2")
+	assert.Error(t, snapshot.UnmarshalJSON([]byte(`{"version":1,"intercepts":[{"bogus":true}]}`)))
+}
@@ -0,0 +1,25 @@
+package state
+
+import (
+	"context"
+	"net"
+
+	"github.com/TinderBackend/telepresence/v2/pkg/ipproto"
+	"github.com/TinderBackend/telepresence/v2/pkg/iputil"
+	"github.com/TinderBackend/telepresence/v2/pkg/tunnel"
+)
+
+// EgressAgent returns the session ID of the agent that originates the client's connections to the
+// given destination, or an empty string when the default route is used.
+func (s *State) EgressAgent(ctx context.Context, sessionID string, dest net.IP) string {
+	id := tunnel.NewConnID(ipproto.TCP, iputil.Parse("127.0.0.1"), dest, 1001, 8080)
+	agent := s.getEgressAgentSession(ctx, sessionID, id)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for agentID, ss := range s.sessions {
+		if agent != nil && ss == agent {
+			return agentID
+		}
+	}
+	return ""
+}
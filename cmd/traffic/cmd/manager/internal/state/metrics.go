@@ -0,0 +1,102 @@
+package state
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/pkg/tunnel"
+)
+
+var (
+	sessionsDesc = prometheus.NewDesc(
+		"telepresence_manager_sessions",
+		"Number of sessions, by type (client or agent).",
+		[]string{"type"}, nil)
+
+	interceptsDesc = prometheus.NewDesc(
+		"telepresence_manager_intercepts",
+		"Number of intercepts, by disposition.",
+		[]string{"disposition"}, nil)
+)
+
+// metrics are the Prometheus metrics that the State updates as things happen. Counts of things that
+// the State keeps track of, like sessions and intercepts, are collected when the metrics are scraped.
+type metrics struct {
+	tunnelStreams        prometheus.Gauge
+	tunnelBytes          *prometheus.CounterVec
+	agentsLookupDuration prometheus.Histogram
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		tunnelStreams: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "telepresence_manager_tunnel_streams",
+			Help: "Number of open tunnel streams.",
+		}),
+		tunnelBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "telepresence_manager_tunnel_bytes_total",
+			Help: "Bytes received on tunnel streams, by the type of session (client or agent) that sent them.",
+		}, []string{"type"}),
+		agentsLookupDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "telepresence_manager_agents_lookup_duration_seconds",
+			Help:    "Time taken to look up a host using the intercepted traffic-agents.",
+			Buckets: prometheus.ExponentialBuckets(0.005, 2, 9),
+		}),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (s *State) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sessionsDesc
+	ch <- interceptsDesc
+	s.metrics.tunnelStreams.Describe(ch)
+	s.metrics.tunnelBytes.Describe(ch)
+	s.metrics.agentsLookupDuration.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (s *State) Collect(ch chan<- prometheus.Metric) {
+	var clients, agents int
+	s.mu.Lock()
+	for _, ss := range s.sessions {
+		if _, ok := ss.(*agentSessionState); ok {
+			agents++
+		} else {
+			clients++
+		}
+	}
+	s.mu.Unlock()
+	ch <- prometheus.MustNewConstMetric(sessionsDesc, prometheus.GaugeValue, float64(clients), "client")
+	ch <- prometheus.MustNewConstMetric(sessionsDesc, prometheus.GaugeValue, float64(agents), "agent")
+
+	dispositions := make(map[rpc.InterceptDispositionType]int, len(rpc.InterceptDispositionType_name))
+	for d := range rpc.InterceptDispositionType_name {
+		dispositions[rpc.InterceptDispositionType(d)] = 0
+	}
+	for _, ii := range s.intercepts.LoadAll() {
+		dispositions[ii.Disposition]++
+	}
+	for d, n := range dispositions {
+		ch <- prometheus.MustNewConstMetric(interceptsDesc, prometheus.GaugeValue, float64(n), d.String())
+	}
+
+	s.metrics.tunnelStreams.Collect(ch)
+	s.metrics.tunnelBytes.Collect(ch)
+	s.metrics.agentsLookupDuration.Collect(ch)
+}
+
+// meteredStream is a tunnel.Stream that counts the bytes that it receives.
+type meteredStream struct {
+	tunnel.Stream
+	bytes prometheus.Counter
+}
+
+func (s *meteredStream) Receive(ctx context.Context) (tunnel.Message, error) {
+	m, err := s.Stream.Receive(ctx)
+	if err == nil && m.Code() == tunnel.Normal {
+		s.bytes.Add(float64(len(m.Payload())))
+	}
+	return m, err
+}
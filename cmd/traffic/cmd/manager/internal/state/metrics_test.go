@@ -0,0 +1,67 @@
+package state_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+	manager "github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/state"
+	testdata "github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/test"
+)
+
+func TestState_Collect(t *testing.T) {
+	ctx := context.Background()
+	testAgents := testdata.GetTestAgents(t)
+	testClients := testdata.GetTestClients(t)
+
+	now := time.Now()
+	state := manager.NewState(ctx)
+	clientID := state.AddClient(testClients["alice"], now)
+	state.AddAgent(testAgents["hello"], now)
+	state.AddAgent(testAgents["demo1"], now)
+
+	_, err := state.AddIntercept(clientID, "", &rpc.InterceptSpec{
+		Name:      "hello",
+		Client:    "alice",
+		Agent:     "hello",
+		Namespace: "default",
+		Mechanism: "tcp",
+	})
+	require.NoError(t, err)
+	_, err = state.AddIntercept(clientID, "", &rpc.InterceptSpec{
+		Name:      "missing",
+		Client:    "alice",
+		Agent:     "missing",
+		Namespace: "default",
+		Mechanism: "tcp",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, testutil.CollectAndCompare(state, strings.NewReader(`
+# HELP telepresence_manager_sessions Number of sessions, by type (client or agent).
+# TYPE telepresence_manager_sessions gauge
+telepresence_manager_sessions{type="agent"} 2
+telepresence_manager_sessions{type="client"} 1
+`), "telepresence_manager_sessions"))
+
+	require.NoError(t, testutil.CollectAndCompare(state, strings.NewReader(`
+# HELP telepresence_manager_intercepts Number of intercepts, by disposition.
+# TYPE telepresence_manager_intercepts gauge
+telepresence_manager_intercepts{disposition="ACTIVE"} 0
+telepresence_manager_intercepts{disposition="AGENT_ERROR"} 0
+telepresence_manager_intercepts{disposition="BAD_ARGS"} 0
+telepresence_manager_intercepts{disposition="FORBIDDEN"} 0
+telepresence_manager_intercepts{disposition="NO_AGENT"} 1
+telepresence_manager_intercepts{disposition="NO_CLIENT"} 0
+telepresence_manager_intercepts{disposition="NO_MECHANISM"} 0
+telepresence_manager_intercepts{disposition="NO_PORTS"} 0
+telepresence_manager_intercepts{disposition="SUSPENDED"} 0
+telepresence_manager_intercepts{disposition="UNSPECIFIED"} 0
+telepresence_manager_intercepts{disposition="WAITING"} 1
+`), "telepresence_manager_intercepts"))
+}
@@ -16,7 +16,7 @@ func (s *State) HasClient(sessionID string) bool {
 }
 
 func (s *State) Add(sessionID, clientName string, now time.Time) string {
-	return s.addClient(sessionID, &rpc.ClientInfo{Name: clientName}, now)
+	return s.addClient(sessionID, "resume-"+sessionID, &rpc.ClientInfo{Name: clientName}, now)
 }
 
 type ClientInfo rpc.ClientInfo
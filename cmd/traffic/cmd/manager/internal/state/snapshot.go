@@ -0,0 +1,64 @@
+package state
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/persistence"
+	"github.com/datawire/dlib/dlog"
+)
+
+// Snapshot returns a snapshot of the client sessions and their intercepts.
+func (s *State) Snapshot() *persistence.Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot := &persistence.Snapshot{Clients: s.clients.LoadAll(), ResumeTokenHashes: make(map[string]string)}
+	for sessionID, sess := range s.sessions {
+		if cs, ok := sess.(*clientSessionState); ok {
+			snapshot.ResumeTokenHashes[sessionID] = cs.resumeTokenHash
+		}
+	}
+	for _, ii := range s.intercepts.LoadAll() {
+		snapshot.Intercepts = append(snapshot.Intercepts, ii)
+	}
+	return snapshot
+}
+
+// Restore restores the client sessions and intercepts of the given snapshot, which must be restored before any
+// sessions arrive. A restored client session is considered present at the given time, so a client that doesn't
+// call Remain with its session ID before the session expires is lost together with its intercepts. Restored
+// intercepts are WAITING until a traffic-agent reviews them. Only the hash of the resume token of a restored
+// session is known, so its ResumeToken is empty, but the client can still resume it using its token.
+func (s *State) Restore(ctx context.Context, snapshot *persistence.Snapshot, now time.Time) {
+	for sessionID, client := range snapshot.Clients {
+		dlog.Infof(ctx, "Restoring session %s of client %s", sessionID, client.Name)
+		resumeTokenHash, ok := snapshot.ResumeTokenHashes[sessionID]
+		if !ok {
+			// Saved by a traffic-manager that didn't support resumable sessions
+			resumeTokenHash = hashResumeToken(uuid.New().String())
+		}
+		s.addClient(sessionID, "", client, now)
+		s.mu.Lock()
+		s.sessions[sessionID].(*clientSessionState).resumeTokenHash = resumeTokenHash
+		s.mu.Unlock()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ii := range snapshot.Intercepts {
+		if _, ok := snapshot.Clients[ii.ClientSession.GetSessionId()]; !ok {
+			dlog.Infof(ctx, "Intercept %s not restored, its client session is lost", ii.Id)
+			continue
+		}
+		ii = proto.Clone(ii).(*rpc.InterceptInfo)
+		ii.Disposition = rpc.InterceptDispositionType_WAITING
+		ii.Message = "Waiting for Agent approval"
+		dlog.Infof(ctx, "Restoring intercept %s", ii.Id)
+		s.intercepts.Store(ii.Id, ii)
+		s.interceptAPIKeys[ii.Id] = ii.ApiKey
+	}
+}
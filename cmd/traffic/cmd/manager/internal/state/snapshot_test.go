@@ -0,0 +1,72 @@
+package state_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+	manager "github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/state"
+	testdata "github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/test"
+)
+
+func TestState_SnapshotAndRestore(t *testing.T) {
+	ctx := context.Background()
+	testAgents := testdata.GetTestAgents(t)
+	testClients := testdata.GetTestClients(t)
+
+	now := time.Now()
+	state := manager.NewState(ctx)
+	clientID := state.AddClient(testClients["alice"], now)
+	state.AddAgent(testAgents["hello"], now)
+	cept, err := state.AddIntercept(clientID, "api-key", &rpc.InterceptSpec{
+		Name:      "hello",
+		Client:    "alice",
+		Agent:     "hello",
+		Namespace: "default",
+		Mechanism: "tcp",
+	})
+	require.NoError(t, err)
+	state.UpdateIntercept(cept.Id, func(ii *rpc.InterceptInfo) {
+		ii.Disposition = rpc.InterceptDispositionType_ACTIVE
+	})
+
+	snapshot := state.Snapshot()
+	require.Len(t, snapshot.Clients, 1, "agent sessions are not persisted")
+	require.Len(t, snapshot.Intercepts, 1)
+
+	// An intercept of a client session that isn't part of the snapshot is not restored
+	snapshot.Intercepts = append(snapshot.Intercepts, &rpc.InterceptInfo{
+		Id:            "lost:hello",
+		Spec:          &rpc.InterceptSpec{Name: "hello", Agent: "hello", Namespace: "default"},
+		ClientSession: &rpc.SessionInfo{SessionId: "lost"},
+	})
+
+	restored := manager.NewState(ctx)
+	restored.Restore(ctx, snapshot, now)
+
+	// The client re-attaches to its session using its old session ID
+	assert.Equal(t, testClients["alice"].Name, restored.GetClient(clientID).Name)
+	// Only the hash of the resume token is persisted, but the client can still resume its session using the token
+	require.NotEmpty(t, state.ResumeToken(clientID))
+	assert.NotEqual(t, state.ResumeToken(clientID), snapshot.ResumeTokenHashes[clientID])
+	assert.Empty(t, restored.ResumeToken(clientID))
+	assert.False(t, restored.ResumeClient(clientID, "wrong-token", testClients["alice"], now))
+	assert.True(t, restored.ResumeClient(clientID, state.ResumeToken(clientID), testClients["alice"], now))
+	assert.True(t, restored.MarkSession(&rpc.RemainRequest{Session: &rpc.SessionInfo{SessionId: clientID}}, now))
+
+	ii, ok := restored.GetIntercept(cept.Id)
+	require.True(t, ok)
+	assert.Equal(t, rpc.InterceptDispositionType_WAITING, ii.Disposition)
+	assert.Equal(t, "api-key", restored.GetInterceptAPIKey())
+	_, ok = restored.GetIntercept("lost:hello")
+	assert.False(t, ok)
+
+	// The restored intercept is lost when its client doesn't come back
+	restored.ExpireSessions(ctx, now.Add(time.Second), now.Add(time.Second))
+	_, ok = restored.GetIntercept(cept.Id)
+	assert.False(t, ok)
+}
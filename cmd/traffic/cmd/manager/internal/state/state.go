@@ -2,21 +2,30 @@ package state
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"fmt"
 	"os"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/egress"
 	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/watchable"
 	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/managerutil"
+	"github.com/TinderBackend/telepresence/v2/pkg/dnsproxy"
 	"github.com/TinderBackend/telepresence/v2/pkg/iputil"
+	"github.com/TinderBackend/telepresence/v2/pkg/k8sapi"
 	"github.com/TinderBackend/telepresence/v2/pkg/log"
 	"github.com/TinderBackend/telepresence/v2/pkg/tunnel"
 	"github.com/datawire/dlib/dlog"
@@ -134,8 +143,19 @@ func (ss *sessionState) SetLastMarked(lastMarked time.Time) {
 
 type clientSessionState struct {
 	sessionState
-	name string
-	pool *tunnel.Pool
+	name        string
+	pool        *tunnel.Pool
+	resumeToken string
+	suspended   bool
+
+	// resumeTokenHash is the hash of the resume token. Unlike the token, it's known for restored sessions.
+	resumeTokenHash string
+}
+
+// hashResumeToken returns the hex encoded SHA-256 hash of the given resume token.
+func hashResumeToken(resumeToken string) string {
+	sum := sha256.Sum256([]byte(resumeToken))
+	return hex.EncodeToString(sum[:])
 }
 
 type agentSessionState struct {
@@ -143,6 +163,8 @@ type agentSessionState struct {
 	agent           *rpc.AgentInfo
 	lookups         chan *rpc.LookupHostRequest
 	lookupResponses map[string]chan *rpc.LookupHostResponse
+	dnsRequests     chan *rpc.DNSRequest
+	dnsResponses    map[string]chan *rpc.DNSResponse
 }
 
 func (ss *agentSessionState) Cancel() {
@@ -150,6 +172,10 @@ func (ss *agentSessionState) Cancel() {
 	for _, lr := range ss.lookupResponses {
 		close(lr)
 	}
+	close(ss.dnsRequests)
+	for _, dr := range ss.dnsResponses {
+		close(dr)
+	}
 	ss.sessionState.Cancel()
 }
 
@@ -181,6 +207,11 @@ type State struct {
 	agentsByName     map[string]map[string]*rpc.AgentInfo // indexed copy of `agents`
 	timedLogLevel    log.TimedLevel
 	llSubs           *loglevelSubscribers
+	metrics          *metrics
+
+	// egressNamespaces tells if the destination of an outbound connection belongs to a namespace
+	// that an egress rule matches on. It's nil when there's no Kubernetes interface.
+	egressNamespaces egress.NamespaceMatcher
 }
 
 func NewState(ctx context.Context) *State {
@@ -192,9 +223,18 @@ func NewState(ctx context.Context) *State {
 		agentsByName:     make(map[string]map[string]*rpc.AgentInfo),
 		timedLogLevel:    log.NewTimedLevel(loglevel, log.SetLevel),
 		llSubs:           newLoglevelSubscribers(),
+		metrics:          newMetrics(),
+		egressNamespaces: newEgressNamespaces(ctx),
 	}
 }
 
+func newEgressNamespaces(ctx context.Context) egress.NamespaceMatcher {
+	if k8sapi.GetK8sInterface(ctx) == nil {
+		return nil
+	}
+	return egress.NewIPIndex(ctx)
+}
+
 // Internal ////////////////////////////////////////////////////////////////////////////////////////
 
 // unlockedCheckAgentsForIntercept (1) assumes that s.mu is already locked, and (2) checks the
@@ -227,6 +267,12 @@ func (s *State) unlockedCheckAgentsForIntercept(intercept *rpc.InterceptInfo) (e
 	case rpc.InterceptDispositionType_BAD_ARGS:
 		// Don't overwrite this error state.
 		return intercept.Disposition, intercept.Message
+	case rpc.InterceptDispositionType_FORBIDDEN:
+		// Don't overwrite this error state.
+		return intercept.Disposition, intercept.Message
+	case rpc.InterceptDispositionType_SUSPENDED:
+		// Don't overwrite this state; it's checked again when the client session resumes.
+		return intercept.Disposition, intercept.Message
 	}
 
 	// main ////////////////////////////////////////////////////////////////
@@ -261,8 +307,8 @@ func (s *State) unlockedCheckAgentsForIntercept(intercept *rpc.InterceptInfo) (e
 
 // Sessions: common ////////////////////////////////////////////////////////////////////////////////
 
-// MarkSession marks a session as being present at the indicated time.  Returns true if everything goes OK,
-// returns false if the given session ID does not exist.
+// MarkSession marks a session as being present at the indicated time, resuming it if it's suspended.  Returns
+// true if everything goes OK, returns false if the given session ID does not exist.
 func (s *State) MarkSession(req *rpc.RemainRequest, now time.Time) (ok bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -270,6 +316,9 @@ func (s *State) MarkSession(req *rpc.RemainRequest, now time.Time) (ok bool) {
 
 	if sess, ok := s.sessions[sessionID]; ok {
 		sess.SetLastMarked(now)
+		if cs, ok := sess.(*clientSessionState); ok && cs.suspended {
+			s.unlockedResumeSession(cs, sessionID)
+		}
 		if req.ApiKey != "" {
 			if client, ok := s.clients.Load(sessionID); ok {
 				client.ApiKey = req.ApiKey
@@ -336,19 +385,65 @@ func (s *State) unlockedRemoveSession(sessionID string) {
 }
 
 // ExpireSessions prunes any sessions that haven't had a MarkSession heartbeat since the given
-// 'moment'.
-func (s *State) ExpireSessions(ctx context.Context, moment time.Time) {
+// 'moment'. A client session that has had a heartbeat since 'suspendMoment' is suspended instead
+// of removed, so that the client can resume it. No sessions are suspended when 'suspendMoment'
+// isn't before 'moment'.
+func (s *State) ExpireSessions(ctx context.Context, moment, suspendMoment time.Time) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	for id, sess := range s.sessions {
-		if sess.LastMarked().Before(moment) {
-			dlog.Debugf(ctx, "Session %s removed. It has expired", id)
-			s.unlockedRemoveSession(id)
+		lastMarked := sess.LastMarked()
+		if !lastMarked.Before(moment) {
+			continue
+		}
+		if cs, ok := sess.(*clientSessionState); ok && !lastMarked.Before(suspendMoment) {
+			if !cs.suspended {
+				dlog.Infof(ctx, "Session %s suspended. It has expired, but can be resumed within the grace period", id)
+				s.unlockedSuspendSession(cs, id)
+			}
+			continue
+		}
+		dlog.Debugf(ctx, "Session %s removed. It has expired", id)
+		s.unlockedRemoveSession(id)
+	}
+}
+
+// unlockedSuspendSession (1) assumes that s.mu is already locked, and (2) suspends the given client
+// session and those of its intercepts that are ACTIVE or WAITING.
+func (s *State) unlockedSuspendSession(cs *clientSessionState, sessionID string) {
+	cs.suspended = true
+	for interceptID, intercept := range s.intercepts.LoadAll() {
+		if intercept.ClientSession.SessionId != sessionID {
+			continue
+		}
+		switch intercept.Disposition {
+		case rpc.InterceptDispositionType_ACTIVE, rpc.InterceptDispositionType_WAITING:
+			intercept.Disposition = rpc.InterceptDispositionType_SUSPENDED
+			intercept.Message = "Client session is suspended"
+			s.intercepts.Store(interceptID, intercept)
 		}
 	}
 }
 
+// unlockedResumeSession (1) assumes that s.mu is already locked, and (2) resumes the given suspended
+// client session. Its SUSPENDED intercepts are WAITING until a traffic-agent reviews them again.
+func (s *State) unlockedResumeSession(cs *clientSessionState, sessionID string) {
+	cs.suspended = false
+	for interceptID, intercept := range s.intercepts.LoadAll() {
+		if intercept.ClientSession.SessionId != sessionID || intercept.Disposition != rpc.InterceptDispositionType_SUSPENDED {
+			continue
+		}
+		intercept.Disposition = rpc.InterceptDispositionType_WAITING
+		intercept.Message = "Waiting for Agent approval"
+		if errCode, errMsg := s.unlockedCheckAgentsForIntercept(intercept); errCode != 0 {
+			intercept.Disposition = errCode
+			intercept.Message = errMsg
+		}
+		s.intercepts.Store(interceptID, intercept)
+	}
+}
+
 // SessionDone returns a channel that is closed when the session with the given ID terminates.  If
 // there is no such currently-live session, then an already-closed channel is returned.
 func (s *State) SessionDone(id string) (<-chan struct{}, error) {
@@ -370,11 +465,11 @@ func (s *State) AddClient(client *rpc.ClientInfo, now time.Time) string {
 	// (to both humans and computers) if the manager restarts and those existing session IDs
 	// suddenly refer to different sessions.
 	sessionID := uuid.New().String()
-	return s.addClient(sessionID, client, now)
+	return s.addClient(sessionID, uuid.New().String(), client, now)
 }
 
-// addClient is like AddClient, but takes a sessionID, for testing purposes
-func (s *State) addClient(sessionID string, client *rpc.ClientInfo, now time.Time) string {
+// addClient is like AddClient, but takes a sessionID and a resumeToken, for testing purposes
+func (s *State) addClient(sessionID, resumeToken string, client *rpc.ClientInfo, now time.Time) string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -389,12 +484,48 @@ func (s *State) addClient(sessionID string, client *rpc.ClientInfo, now time.Tim
 			lastMarked: now,
 			dials:      make(chan *rpc.DialRequest),
 		},
-		name: client.Name,
-		pool: tunnel.NewPool(),
+		name:            client.Name,
+		pool:            tunnel.NewPool(),
+		resumeToken:     resumeToken,
+		resumeTokenHash: hashResumeToken(resumeToken),
 	}
 	return sessionID
 }
 
+// ResumeToken returns the token that the client of the given session can use to resume it, or an empty
+// string if there's no such client session or if the session was restored.
+func (s *State) ResumeToken(sessionID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cs, ok := s.sessions[sessionID].(*clientSessionState); ok {
+		return cs.resumeToken
+	}
+	return ""
+}
+
+// ResumeClient resumes the client session with the given ID, provided that the given token is the session's
+// resume token and that the session belongs to a client with the same name. The session is marked as present
+// at the given time, and its ClientInfo is replaced with the given one. Returns false if the session can't be
+// resumed.
+func (s *State) ResumeClient(sessionID, resumeToken string, client *rpc.ClientInfo, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cs, ok := s.sessions[sessionID].(*clientSessionState)
+	if !ok || resumeToken == "" || cs.name != client.Name || subtle.ConstantTimeCompare([]byte(cs.resumeTokenHash), []byte(hashResumeToken(resumeToken))) != 1 {
+		return false
+	}
+	if old, ok := s.clients.Load(sessionID); ok && client.ApiKey == "" {
+		client.ApiKey = old.ApiKey
+	}
+	s.clients.Store(sessionID, client)
+	cs.SetLastMarked(now)
+	if cs.suspended {
+		s.unlockedResumeSession(cs, sessionID)
+	}
+	return true
+}
+
 func (s *State) GetClient(sessionID string) *rpc.ClientInfo {
 	ret, _ := s.clients.Load(sessionID)
 	return ret
@@ -441,6 +572,8 @@ func (s *State) AddAgent(agent *rpc.AgentInfo, now time.Time) string {
 		},
 		lookups:         make(chan *rpc.LookupHostRequest),
 		lookupResponses: make(map[string]chan *rpc.LookupHostResponse),
+		dnsRequests:     make(chan *rpc.DNSRequest),
+		dnsResponses:    make(map[string]chan *rpc.DNSResponse),
 		agent:           agent,
 	}
 
@@ -498,6 +631,17 @@ func (s *State) WatchAgents(
 // Intercepts //////////////////////////////////////////////////////////////////////////////////////
 
 func (s *State) AddIntercept(sessionID, apiKey string, spec *rpc.InterceptSpec) (*rpc.InterceptInfo, error) {
+	return s.addIntercept(sessionID, apiKey, spec, rpc.InterceptDispositionType_WAITING, "Waiting for Agent approval")
+}
+
+// AddRejectedIntercept adds an intercept that is rejected with the given disposition and message. Agents never
+// review such an intercept, but its client learns about the rejection just like it learns about other failures,
+// and is expected to remove the intercept.
+func (s *State) AddRejectedIntercept(sessionID, apiKey string, spec *rpc.InterceptSpec, disposition rpc.InterceptDispositionType, message string) (*rpc.InterceptInfo, error) {
+	return s.addIntercept(sessionID, apiKey, spec, disposition, message)
+}
+
+func (s *State) addIntercept(sessionID, apiKey string, spec *rpc.InterceptSpec, disposition rpc.InterceptDispositionType, message string) (*rpc.InterceptInfo, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -505,8 +649,8 @@ func (s *State) AddIntercept(sessionID, apiKey string, spec *rpc.InterceptSpec)
 	s.interceptAPIKeys[interceptID] = apiKey
 	cept := &rpc.InterceptInfo{
 		Spec:        spec,
-		Disposition: rpc.InterceptDispositionType_WAITING,
-		Message:     "Waiting for Agent approval",
+		Disposition: disposition,
+		Message:     message,
 		Id:          interceptID,
 		ClientSession: &rpc.SessionInfo{
 			SessionId: sessionID,
@@ -560,6 +704,8 @@ func (s *State) getAgentsInterceptedByClient(clientSessionID string) []string {
 		agentIDs[i] = id
 		i++
 	}
+	// Sorted, so that the choice of agent is predictable
+	sort.Strings(agentIDs)
 	return agentIDs
 }
 
@@ -589,6 +735,18 @@ func (s *State) UpdateIntercept(interceptID string, apply func(*rpc.InterceptInf
 	}
 }
 
+// ExpireIntercepts removes the intercepts that expire before the given 'moment'.
+func (s *State) ExpireIntercepts(ctx context.Context, moment time.Time) {
+	expired := s.intercepts.LoadAllMatching(func(_ string, ii *rpc.InterceptInfo) bool {
+		return ii.ExpiresAt != nil && ii.ExpiresAt.AsTime().Before(moment)
+	})
+	for id := range expired {
+		if s.RemoveIntercept(id) {
+			dlog.Infof(ctx, "Intercept %s removed. It has expired", id)
+		}
+	}
+}
+
 func (s *State) RemoveIntercept(interceptID string) bool {
 	_, didDelete := s.intercepts.LoadAndDelete(interceptID)
 	return didDelete
@@ -646,6 +804,14 @@ func (s *State) Tunnel(ctx context.Context, stream tunnel.Stream) error {
 		return status.Errorf(codes.NotFound, "Session %q not found", sessionID)
 	}
 
+	sessionType := "client"
+	if _, ok := ss.(*agentSessionState); ok {
+		sessionType = "agent"
+	}
+	stream = &meteredStream{Stream: stream, bytes: s.metrics.tunnelBytes.WithLabelValues(sessionType)}
+	s.metrics.tunnelStreams.Inc()
+	defer s.metrics.tunnelStreams.Dec()
+
 	bidiPipe, err := ss.OnConnect(ctx, stream)
 	if err != nil {
 		return err
@@ -659,11 +825,16 @@ func (s *State) Tunnel(ctx context.Context, stream tunnel.Stream) error {
 
 	// The session is either the telepresence client or a traffic-agent.
 	//
-	// A client will want to extend the tunnel to a dialer in an intercepted traffic-agent or, if no
-	// intercept is active, to a dialer here in the traffic-agent.
+	// A client will want to extend the tunnel to a dialer in the traffic-agent that its egress rules
+	// select for the destination. Without such a traffic-agent, the tunnel is extended to a dialer in
+	// an intercepted traffic-agent or, if no intercept is active, to a dialer here in the traffic-manager.
 	//
 	// A traffic-agent must always extend the tunnel to the client that it is currently intercepted
 	// by, and hence, start by sending the sessionID of that client on the tunnel.
+	//
+	// Intercepts that are declared using Intercept resources have no client session, so the tunnels
+	// of those intercepts are extended to a dialer here in the traffic-manager, which connects to the
+	// intercept's target.
 	var peerSession SessionState
 	if _, ok := ss.(*agentSessionState); ok {
 		// traffic-agent, so obtain the desired client session
@@ -679,7 +850,10 @@ func (s *State) Tunnel(ctx context.Context, stream tunnel.Stream) error {
 		peerSession = s.sessions[peerID]
 		s.mu.Unlock()
 	} else {
-		peerSession = s.getRandomAgentSession(sessionID)
+		peerSession = s.getEgressAgentSession(ctx, sessionID, stream.ID())
+		if peerSession == nil {
+			peerSession = s.getRandomAgentSession(sessionID)
+		}
 	}
 
 	var endPoint tunnel.Endpoint
@@ -696,6 +870,40 @@ func (s *State) Tunnel(ctx context.Context, stream tunnel.Stream) error {
 	return nil
 }
 
+// getEgressAgentSession returns the session of a traffic-agent of the workload that the client's egress rules
+// select for the destination of the given connection, or nil if no rule applies or if no traffic-agent of
+// the selected workload is available. The agent with the lowest session ID is used when there are several,
+// so that all connections that a rule applies to are routed through the same pod.
+func (s *State) getEgressAgentSession(ctx context.Context, clientSessionID string, id tunnel.ConnID) SessionState {
+	client, ok := s.clients.Load(clientSessionID)
+	if !ok || len(client.EgressRules) == 0 {
+		return nil
+	}
+	rule := egress.Match(client.EgressRules, id.Destination(), s.egressNamespaces)
+	if rule == nil {
+		return nil
+	}
+	agents := s.agents.LoadAllMatching(func(_ string, ai *rpc.AgentInfo) bool {
+		return ai.Name == rule.Workload && ai.Namespace == rule.Namespace
+	})
+	agentIDs := make([]string, 0, len(agents))
+	for agentID := range agents {
+		agentIDs = append(agentIDs, agentID)
+	}
+	sort.Strings(agentIDs)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, agentID := range agentIDs {
+		if agent, ok := s.sessions[agentID]; ok {
+			return agent
+		}
+	}
+	dlog.Warnf(ctx, "no traffic-agent of %s.%s is available for egress of %s, falling back to the default route",
+		rule.Workload, rule.Namespace, id)
+	return nil
+}
+
 func (s *State) getRandomAgentSession(clientSessionID string) (agent SessionState) {
 	if agentIDs := s.getAgentsInterceptedByClient(clientSessionID); len(agentIDs) > 0 {
 		s.mu.Lock()
@@ -725,6 +933,7 @@ func (s *State) AgentsLookup(ctx context.Context, clientSessionID string, reques
 	if iceptCount == 0 {
 		return ips, 0, nil
 	}
+	defer prometheus.NewTimer(s.metrics.agentsLookupDuration).ObserveDuration()
 
 	rsMu := sync.Mutex{} // prevent concurrent updates of the ips slice
 	agentTimeout, cancel := context.WithTimeout(ctx, time.Second)
@@ -831,6 +1040,146 @@ func (s *State) WatchLookupHost(agentSessionID string) <-chan *rpc.LookupHostReq
 	return ss.(*agentSessionState).lookups
 }
 
+// AgentsLookupDNS will send the given request to all agents currently intercepted by the client identified
+// with the clientSessionID, it will then wait for results to arrive, and return the answer and response code
+// of the best response together with a count of how many agents that replied. A response with records is
+// preferred over an empty response, which in turn is preferred over NXDOMAIN.
+func (s *State) AgentsLookupDNS(ctx context.Context, clientSessionID string, request *rpc.DNSRequest) (dnsproxy.RRs, int, int, error) {
+	iceptAgentIDs := s.getAgentsInterceptedByClient(clientSessionID)
+	iceptCount := len(iceptAgentIDs)
+	if iceptCount == 0 {
+		return nil, dns.RcodeNameError, 0, nil
+	}
+	defer prometheus.NewTimer(s.metrics.agentsLookupDuration).ObserveDuration()
+
+	rsMu := sync.Mutex{} // prevent concurrent updates of the responses slice
+	agentTimeout, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	var responses []*rpc.DNSResponse
+	wg := sync.WaitGroup{}
+	wg.Add(iceptCount)
+	for _, agentSessionID := range iceptAgentIDs {
+		go func(agentSessionID string) {
+			defer func() {
+				s.endDNSLookup(agentSessionID, request)
+				wg.Done()
+			}()
+
+			rsCh := s.startDNSLookup(agentTimeout, agentSessionID, request)
+			if rsCh == nil {
+				return
+			}
+			select {
+			case <-agentTimeout.Done():
+				return
+			case rs := <-rsCh:
+				if rs == nil {
+					// Channel closed
+					return
+				}
+				rsMu.Lock()
+				responses = append(responses, rs)
+				rsMu.Unlock()
+			}
+		}(agentSessionID)
+	}
+	wg.Wait() // wait for timeout or that all agents have responded
+
+	var rrs dnsproxy.RRs
+	rCode := dns.RcodeNameError
+	for _, r := range responses {
+		answer, rc, err := dnsproxy.FromRPC(r)
+		if err != nil {
+			dlog.Errorf(ctx, "unable to unpack DNS response from agent: %v", err)
+			continue
+		}
+		switch {
+		case len(answer) > 0:
+			return answer, rc, len(responses), nil
+		case rc == dns.RcodeSuccess:
+			rrs, rCode = answer, rc
+		case rCode != dns.RcodeSuccess:
+			rCode = rc
+		}
+	}
+	return rrs, rCode, len(responses), nil
+}
+
+// PostLookupDNSResponse receives DNS responses from an agent and places them in the channel
+// that corresponds to the DNS request
+func (s *State) PostLookupDNSResponse(response *rpc.DNSAgentResponse) {
+	responseID := dnsResponseID(response.Request)
+	var rch chan<- *rpc.DNSResponse
+	s.mu.Lock()
+	if as, ok := s.sessions[response.Session.SessionId].(*agentSessionState); ok {
+		rch = as.dnsResponses[responseID]
+	}
+	s.mu.Unlock()
+	if rch != nil {
+		rch <- response.Response
+	}
+}
+
+func dnsResponseID(request *rpc.DNSRequest) string {
+	return fmt.Sprintf("%s:%s:%d", request.Session.SessionId, request.Name, request.Type)
+}
+
+func (s *State) startDNSLookup(ctx context.Context, agentSessionID string, request *rpc.DNSRequest) <-chan *rpc.DNSResponse {
+	responseID := dnsResponseID(request)
+	var (
+		rch chan *rpc.DNSResponse
+		as  *agentSessionState
+		ok  bool
+	)
+	s.mu.Lock()
+	if as, ok = s.sessions[agentSessionID].(*agentSessionState); ok {
+		if rch, ok = as.dnsResponses[responseID]; !ok {
+			rch = make(chan *rpc.DNSResponse)
+			as.dnsResponses[responseID] = rch
+		}
+	}
+	s.mu.Unlock()
+	if as != nil {
+		// the as.dnsRequests channel may be closed at this point, so guard for panic. Agents that
+		// predate the DNS requests never receive from it, so the send must not block forever.
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					close(rch)
+				}
+			}()
+			select {
+			case <-ctx.Done():
+			case as.dnsRequests <- request:
+			}
+		}()
+	}
+	return rch
+}
+
+func (s *State) endDNSLookup(agentSessionID string, request *rpc.DNSRequest) {
+	responseID := dnsResponseID(request)
+	s.mu.Lock()
+	if as, ok := s.sessions[agentSessionID].(*agentSessionState); ok {
+		if rch, ok := as.dnsResponses[responseID]; ok {
+			delete(as.dnsResponses, responseID)
+			close(rch)
+		}
+	}
+	s.mu.Unlock()
+}
+
+func (s *State) WatchLookupDNS(agentSessionID string) <-chan *rpc.DNSRequest {
+	s.mu.Lock()
+	ss, ok := s.sessions[agentSessionID]
+	s.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return ss.(*agentSessionState).dnsRequests
+}
+
 // SetTempLogLevel sets the temporary log-level for the traffic-manager and all agents and,
 // if a duration is given, it also starts a timer that will reset the log-level once it
 // fires.
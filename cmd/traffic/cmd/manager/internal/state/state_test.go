@@ -2,11 +2,17 @@ package state_test
 
 import (
 	"context"
+	"net"
 	"testing"
 	"time"
 
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
 	manager "github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/state"
 	testdata "github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/test"
+	"github.com/TinderBackend/telepresence/v2/pkg/iputil"
 )
 
 type FakeClock struct {
@@ -94,7 +100,7 @@ func TestStateInternal(topT *testing.T) {
 		a.True(state.Mark(c2, clock.Now()))
 		a.False(state.Mark("asdf", clock.Now()))
 
-		state.ExpireSessions(ctx, epoch.Add(5*time.Second))
+		state.ExpireSessions(ctx, epoch.Add(5*time.Second), epoch.Add(5*time.Second))
 
 		a.True(state.HasClient(c1))
 		a.True(state.HasClient(c2))
@@ -106,7 +112,7 @@ func TestStateInternal(topT *testing.T) {
 		a.True(state.Mark(c2, clock.Now()))
 		a.False(state.Mark(c3, clock.Now()))
 
-		state.ExpireSessions(ctx, epoch.Add(5*time.Second))
+		state.ExpireSessions(ctx, epoch.Add(5*time.Second), epoch.Add(5*time.Second))
 
 		a.True(state.HasClient(c1))
 		a.True(state.HasClient(c2))
@@ -122,4 +128,127 @@ func TestStateInternal(topT *testing.T) {
 		a.False(state.Mark(c2, clock.Now()))
 		a.False(state.Mark(c3, clock.Now()))
 	})
+
+	topT.Run("intercept-expiry", func(t *testing.T) {
+		a := assertNew(t)
+
+		clock := &FakeClock{}
+		state := manager.NewState(ctx)
+
+		c1 := state.AddClient(testClients["alice"], clock.Now())
+		add := func(name string) string {
+			ii, err := state.AddIntercept(c1, "", &rpc.InterceptSpec{Name: name, Client: "alice", Agent: "hello", Namespace: "default"})
+			a.NoError(err)
+			return ii.Id
+		}
+		short := add("short")
+		long := add("long")
+		forever := add("forever")
+		setExpiry := func(id string, d time.Duration) {
+			a.NotNil(state.UpdateIntercept(id, func(ii *rpc.InterceptInfo) {
+				ii.ExpiresAt = timestamppb.New(clock.Now().Add(d))
+			}))
+		}
+		setExpiry(short, 10*time.Second)
+		setExpiry(long, 30*time.Second)
+
+		clock.When = 20
+		state.ExpireIntercepts(ctx, clock.Now())
+		_, ok := state.GetIntercept(short)
+		a.False(ok)
+		_, ok = state.GetIntercept(long)
+		a.True(ok)
+
+		clock.When = 40
+		state.ExpireIntercepts(ctx, clock.Now())
+		_, ok = state.GetIntercept(long)
+		a.False(ok)
+		_, ok = state.GetIntercept(forever)
+		a.True(ok)
+	})
+
+	topT.Run("egress", func(t *testing.T) {
+		a := assertNew(t)
+
+		clock := &FakeClock{}
+		state := manager.NewState(ctx)
+
+		d1 := state.AddAgent(testAgents["demo1"], clock.Now())
+		d2 := state.AddAgent(testAgents["demo2"], clock.Now())
+		_, sn, err := net.ParseCIDR("10.10.0.0/16")
+		a.NoError(err)
+		alice := proto.Clone(testClients["alice"]).(*rpc.ClientInfo)
+		alice.EgressRules = []*rpc.EgressRule{{Workload: "demo", Namespace: "default", Subnets: []*rpc.IPNet{iputil.IPNetToRPC(sn)}}}
+		c1 := state.AddClient(alice, clock.Now())
+		c2 := state.AddClient(testClients["bob"], clock.Now())
+
+		// The agent with the lowest session ID is chosen, so that the choice is predictable
+		first := d1
+		if d2 < d1 {
+			first = d2
+		}
+		a.Equal(first, state.EgressAgent(ctx, c1, iputil.Parse("10.10.1.2")))
+		a.Equal("", state.EgressAgent(ctx, c1, iputil.Parse("10.20.1.2")))
+		a.Equal("", state.EgressAgent(ctx, c2, iputil.Parse("10.10.1.2")))
+
+		// Without agents of the workload, the default route is used
+		state.RemoveSession(ctx, d1)
+		state.RemoveSession(ctx, d2)
+		a.Equal("", state.EgressAgent(ctx, c1, iputil.Parse("10.10.1.2")))
+	})
+
+	topT.Run("session-suspend", func(t *testing.T) {
+		a := assertNew(t)
+
+		clock := &FakeClock{}
+		state := manager.NewState(ctx)
+
+		c1 := state.AddClient(testClients["alice"], clock.Now())
+		h := state.AddAgent(testAgents["hello"], clock.Now())
+		ii, err := state.AddIntercept(c1, "", &rpc.InterceptSpec{Name: "hello", Client: "alice", Agent: "hello", Namespace: "default", Mechanism: "tcp"})
+		a.NoError(err)
+		state.UpdateIntercept(ii.Id, func(ii *rpc.InterceptInfo) {
+			ii.Disposition = rpc.InterceptDispositionType_ACTIVE
+		})
+		disposition := func() rpc.InterceptDispositionType {
+			ii, ok := state.GetIntercept(ii.Id)
+			if !ok {
+				return rpc.InterceptDispositionType_UNSPECIFIED
+			}
+			return ii.Disposition
+		}
+		expire := func() {
+			// Sessions expire after 15 seconds and have a grace period of one minute
+			a.True(state.Mark(h, clock.Now()))
+			state.ExpireSessions(ctx, clock.Now().Add(-15*time.Second), clock.Now().Add(-75*time.Second))
+		}
+
+		// An expired client session is suspended together with its intercepts
+		clock.When = 20
+		expire()
+		a.True(state.HasClient(c1))
+		a.Equal(rpc.InterceptDispositionType_SUSPENDED, disposition())
+
+		// A heartbeat resumes the session, and the agent must review the intercept again
+		a.True(state.Mark(c1, clock.Now()))
+		a.Equal(rpc.InterceptDispositionType_WAITING, disposition())
+
+		// A client that arrives again can resume the session using its resume token
+		clock.When = 40
+		expire()
+		a.Equal(rpc.InterceptDispositionType_SUSPENDED, disposition())
+		a.False(state.ResumeClient(c1, "bogus", testClients["alice"], clock.Now()))
+		a.False(state.ResumeClient(c1, state.ResumeToken(c1), testClients["bob"], clock.Now()))
+		a.True(state.ResumeClient(c1, state.ResumeToken(c1), testClients["alice"], clock.Now()))
+		a.Equal(rpc.InterceptDispositionType_WAITING, disposition())
+
+		// The session and its intercepts are removed when the grace period ends
+		clock.When = 100
+		expire()
+		a.True(state.HasClient(c1))
+		clock.When = 130
+		expire()
+		a.False(state.HasClient(c1))
+		a.Equal(rpc.InterceptDispositionType_UNSPECIFIED, disposition())
+	})
 }
@@ -0,0 +1,290 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/managerutil"
+	"github.com/TinderBackend/telepresence/v2/pkg/k8sapi"
+	"github.com/datawire/dlib/dlog"
+)
+
+// managerServicePrefix is the prefix of the full names of the methods of the Manager service. Calls to those
+// methods are forwarded to the leader when the traffic-manager runs with several replicas.
+const managerServicePrefix = "/telepresence.manager.Manager/"
+
+const (
+	leaseDuration = 15 * time.Second
+	renewDeadline = 10 * time.Second
+	retryPeriod   = 2 * time.Second
+)
+
+// leaderProxy keeps track of which replica of the traffic-manager that is the leader. The leader owns the
+// sessions and intercepts, so the Manager API calls that arrive at the other replicas are forwarded to it,
+// and the tunnel streams of clients and agents therefore always meet in the leader. Replicas hence make the
+// traffic-manager highly available, but don't add capacity.
+type leaderProxy struct {
+	sync.RWMutex
+
+	// local is true when this replica is the leader and ready to serve
+	local bool
+
+	// conn is the connection to the leader, or nil when this replica is the leader or no leader is known
+	conn *grpc.ClientConn
+
+	// leader is the identity of the current leader
+	leader string
+}
+
+// newLeaderProxy returns a leaderProxy for a replica that serves all calls itself.
+func newLeaderProxy() *leaderProxy {
+	return &leaderProxy{local: true}
+}
+
+// isLeader returns true if this replica serves the Manager API calls itself.
+func (p *leaderProxy) isLeader() bool {
+	p.RLock()
+	defer p.RUnlock()
+	return p.local
+}
+
+// setLocal makes this replica serve all calls itself.
+func (p *leaderProxy) setLocal() {
+	p.Lock()
+	p.local = true
+	p.closeConn()
+	p.Unlock()
+}
+
+// setLeader makes this replica forward all calls to the given leader. Calls fail with codes.Unavailable while
+// the identity of the leader is empty.
+func (p *leaderProxy) setLeader(ctx context.Context, identity string) {
+	p.Lock()
+	defer p.Unlock()
+	if !p.local && p.leader == identity && (identity == "" || p.conn != nil) {
+		return
+	}
+	p.local = false
+	p.leader = identity
+	p.closeConn()
+	if identity == "" {
+		return
+	}
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if mz, ok := managerutil.GetEnv(ctx).MaxReceiveSize.AsInt64(); ok {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(int(mz))))
+	}
+	conn, err := grpc.DialContext(ctx, identity, opts...)
+	if err != nil {
+		dlog.Errorf(ctx, "unable to dial leader %s: %v", identity, err)
+		return
+	}
+	p.conn = conn
+}
+
+// closeConn closes the connection to the leader. The mutex must be locked when this method is called.
+func (p *leaderProxy) closeConn() {
+	if p.conn != nil {
+		_ = p.conn.Close()
+		p.conn = nil
+	}
+}
+
+// target returns the connection that a call to the given method must be forwarded to, or nil if the call is
+// served by this replica.
+func (p *leaderProxy) target(fullMethod string) (*grpc.ClientConn, error) {
+	if !strings.HasPrefix(fullMethod, managerServicePrefix) {
+		return nil, nil
+	}
+	p.RLock()
+	defer p.RUnlock()
+	if p.local {
+		return nil, nil
+	}
+	if p.conn == nil {
+		return nil, status.Error(codes.Unavailable, "no traffic-manager leader has been elected")
+	}
+	return p.conn, nil
+}
+
+// unaryInterceptor is a grpc.UnaryServerInterceptor that forwards calls to the leader.
+func (p *leaderProxy) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	conn, err := p.target(info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	if conn == nil {
+		return handler(ctx, req)
+	}
+	_, out, err := methodTypes(info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	reply := out.New().Interface()
+	if err = conn.Invoke(forwardedContext(ctx), info.FullMethod, req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// streamInterceptor is a grpc.StreamServerInterceptor that forwards calls to the leader.
+func (p *leaderProxy) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	conn, err := p.target(info.FullMethod)
+	if err != nil {
+		return err
+	}
+	if conn == nil {
+		return handler(srv, ss)
+	}
+	in, out, err := methodTypes(info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ss.Context())
+	defer cancel()
+	desc := &grpc.StreamDesc{StreamName: info.FullMethod, ServerStreams: info.IsServerStream, ClientStreams: info.IsClientStream}
+	cs, err := conn.NewStream(forwardedContext(ctx), desc, info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	// Forward the messages of the client to the leader
+	go func() {
+		for {
+			msg := in.New().Interface()
+			if err := ss.RecvMsg(msg); err != nil {
+				if errors.Is(err, io.EOF) {
+					_ = cs.CloseSend()
+				} else {
+					cancel()
+				}
+				return
+			}
+			if err := cs.SendMsg(msg); err != nil {
+				return
+			}
+		}
+	}()
+
+	// Forward the messages of the leader to the client
+	for {
+		msg := out.New().Interface()
+		if err := cs.RecvMsg(msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err := ss.SendMsg(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// forwardedContext returns a context that passes the metadata of the incoming call on to the leader.
+func forwardedContext(ctx context.Context) context.Context {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = metadata.NewOutgoingContext(ctx, md.Copy())
+	}
+	return ctx
+}
+
+// methodTypes returns the types of the input and output messages of the method with the given full name.
+func methodTypes(fullMethod string) (in, out protoreflect.MessageType, err error) {
+	name := protoreflect.FullName(strings.Replace(strings.TrimPrefix(fullMethod, "/"), "/", ".", 1))
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Unimplemented, "unknown method %s: %v", fullMethod, err)
+	}
+	md, ok := d.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, nil, status.Errorf(codes.Unimplemented, "%s is not a method", fullMethod)
+	}
+	if in, err = protoregistry.GlobalTypes.FindMessageByName(md.Input().FullName()); err != nil {
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+	if out, err = protoregistry.GlobalTypes.FindMessageByName(md.Output().FullName()); err != nil {
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+	return in, out, nil
+}
+
+// runLeaderElection takes part in the election of the leader among the replicas of the traffic-manager. The
+// given function is called when this replica becomes the leader, and the calls to the Manager API are forwarded
+// to the leader while some other replica is leading. An error is returned when this replica loses its
+// leadership, so that it restarts with a clean state.
+func (m *Manager) runLeaderElection(ctx context.Context, lead func(context.Context) error) error {
+	env := managerutil.GetEnv(ctx)
+	if env.PodIP == "" {
+		return errors.New("leader election requires TELEPRESENCE_MANAGER_POD_IP")
+	}
+	identity := net.JoinHostPort(env.PodIP, env.ServerPort)
+
+	// A failure to lead makes this replica give up its leadership
+	leadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	leadErr := make(chan error, 1)
+	le, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock: &resourcelock.LeaseLock{
+			LeaseMeta: meta.ObjectMeta{
+				Name:      env.LeaderElectionLease,
+				Namespace: env.ManagerNamespace,
+			},
+			Client:     k8sapi.GetK8sInterface(ctx).CoordinationV1(),
+			LockConfig: resourcelock.ResourceLockConfig{Identity: identity},
+		},
+		ReleaseOnCancel: true,
+		LeaseDuration:   leaseDuration,
+		RenewDeadline:   renewDeadline,
+		RetryPeriod:     retryPeriod,
+		Name:            env.LeaderElectionLease,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				dlog.Infof(ctx, "%s is the leader", identity)
+				if err := lead(ctx); err != nil && ctx.Err() == nil {
+					leadErr <- err
+					cancel()
+				}
+			},
+			OnStoppedLeading: func() {
+				dlog.Infof(ctx, "%s is no longer the leader", identity)
+			},
+			OnNewLeader: func(leader string) {
+				if leader != identity {
+					dlog.Infof(ctx, "forwarding calls to the leader %s", leader)
+					m.leaderProxy.setLeader(ctx, leader)
+				}
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create leader elector: %w", err)
+	}
+	le.Run(leadCtx)
+	select {
+	case err = <-leadErr:
+		return err
+	default:
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return fmt.Errorf("%s lost its leadership", identity)
+}
@@ -0,0 +1,120 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/managerutil"
+	"github.com/datawire/dlib/dlog"
+)
+
+// replica is a ManagerServer that serves Version and Tunnel.
+type replica struct {
+	name string
+	rpc.UnimplementedManagerServer
+}
+
+// Version returns the name of the replica, followed by the value of the "x-test" metadata of the call.
+func (r *replica) Version(ctx context.Context, _ *empty.Empty) (*rpc.VersionInfo2, error) {
+	v := r.name
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, x := range md.Get("x-test") {
+			v += " " + x
+		}
+	}
+	return &rpc.VersionInfo2{Version: v}, nil
+}
+
+// Tunnel echoes all messages, prefixed with the name of the replica.
+func (r *replica) Tunnel(s rpc.Manager_TunnelServer) error {
+	for {
+		msg, err := s.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err = s.Send(&rpc.TunnelMessage{Payload: append([]byte(r.name+":"), msg.Payload...)}); err != nil {
+			return err
+		}
+	}
+}
+
+func serveReplica(t *testing.T, name string, opts ...grpc.ServerOption) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := grpc.NewServer(opts...)
+	rpc.RegisterManagerServer(s, &replica{name: name})
+	go func() {
+		_ = s.Serve(l)
+	}()
+	t.Cleanup(s.Stop)
+	return l.Addr().String()
+}
+
+func TestLeaderProxy(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+	ctx = managerutil.WithEnv(ctx, &managerutil.Env{MaxReceiveSize: resource.MustParse("4Mi")})
+
+	p := newLeaderProxy()
+	leaderAddr := serveReplica(t, "leader")
+	followerAddr := serveReplica(t, "follower",
+		grpc.UnaryInterceptor(p.unaryInterceptor),
+		grpc.StreamInterceptor(p.streamInterceptor))
+
+	conn, err := grpc.DialContext(ctx, followerAddr, grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	client := rpc.NewManagerClient(conn)
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-test", "metadata")
+
+	// A replica that serves all calls itself
+	v, err := client.Version(ctx, &empty.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, "follower metadata", v.Version)
+
+	// A replica that waits for a leader
+	p.setLeader(ctx, "")
+	assert.False(t, p.isLeader())
+	_, err = client.Version(ctx, &empty.Empty{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	// A replica that forwards all calls to the leader
+	p.setLeader(ctx, leaderAddr)
+	v, err = client.Version(ctx, &empty.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, "leader metadata", v.Version)
+
+	tc, err := client.Tunnel(ctx)
+	require.NoError(t, err)
+	for _, s := range []string{"one", "two", "three"} {
+		require.NoError(t, tc.Send(&rpc.TunnelMessage{Payload: []byte(s)}))
+		msg, err := tc.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, "leader:"+s, string(msg.Payload))
+	}
+	require.NoError(t, tc.CloseSend())
+	_, err = tc.Recv()
+	assert.ErrorIs(t, err, io.EOF)
+
+	// A replica that became the leader
+	p.setLocal()
+	assert.True(t, p.isLeader())
+	v, err = client.Version(ctx, &empty.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, "follower metadata", v.Version)
+}
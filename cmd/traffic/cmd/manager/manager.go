@@ -10,12 +10,16 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
 	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
 	"github.com/TinderBackend/telepresence/rpc/v2/systema"
+	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/audit"
+	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/crd"
 	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/mutator"
+	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/persistence"
 	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/watchable"
 	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/managerutil"
 	"github.com/TinderBackend/telepresence/v2/pkg/k8sapi"
@@ -43,26 +47,96 @@ func Main(ctx context.Context, args ...string) error {
 		return fmt.Errorf("unable to create the Kubernetes Interface from InClusterConfig: %w", err)
 	}
 	ctx = k8sapi.WithK8sInterface(ctx, ki)
+	dc, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("unable to create the Kubernetes dynamic Interface from InClusterConfig: %w", err)
+	}
+	ctx = k8sapi.WithDynamicInterface(ctx, dc)
 
 	g := dgroup.NewGroup(ctx, dgroup.GroupConfig{
 		EnableSignalHandling: true,
 	})
 	mgr := NewManager(ctx)
 
+	env := managerutil.GetEnv(ctx)
+	store, err := persistence.NewStore(ctx, env.StateStore, env.ManagerNamespace, env.StateStoreName)
+	if err != nil {
+		return err
+	}
+
+	var reconciler *crd.Reconciler
+	if env.InterceptResources {
+		reconciler = crd.NewReconciler(dc, mgr.state, mgr.addResourceIntercept, strings.Fields(env.InterceptResourcesNamespaces))
+	}
+
+	auditLog, err := audit.OpenLog(env.AuditLog)
+	if err != nil {
+		return err
+	}
+	auditor := audit.NewAuditor(auditLog, env.AuditEvents)
+
+	// Calls to the Manager API must wait until the state has been restored, and a leader has been elected
+	// when there are several replicas
+	mgr.leaderProxy.setLeader(ctx, "")
+
 	// Serve HTTP (including gRPC)
 	g.Go("httpd", mgr.serveHTTP)
 
 	g.Go("agent-injector", mutator.ServeMutator)
 
-	g.Go("intercept-gc", mgr.runInterceptGCLoop)
+	if env.LeaderElection {
+		if store == nil {
+			dlog.Warn(ctx, "leader election is enabled without a state store, a new leader will lose all sessions and intercepts")
+		}
+		g.Go("leader-election", func(ctx context.Context) error {
+			return mgr.runLeaderElection(ctx, func(ctx context.Context) error {
+				return mgr.lead(ctx, store, reconciler, auditor)
+			})
+		})
+	} else {
+		g.Go("leader", func(ctx context.Context) error {
+			return mgr.lead(ctx, store, reconciler, auditor)
+		})
+	}
+
+	// Wait for exit
+	return g.Wait()
+}
+
+// lead restores the state from the given store and then does the work that only one replica of the
+// traffic-manager may do, i.e. persisting the state, reconciling Intercept resources, auditing intercepts,
+// and garbage collecting sessions and intercepts. The store and the reconciler are optional.
+func (m *Manager) lead(ctx context.Context, store persistence.Store, reconciler *crd.Reconciler, auditor *audit.Auditor) error {
+	g := dgroup.NewGroup(ctx, dgroup.GroupConfig{})
+	if store != nil {
+		// The state must be restored before the sessions arrive
+		if err := m.restoreState(ctx, store); err != nil {
+			dlog.Errorf(ctx, "unable to restore state: %v", err)
+		}
+		g.Go("state-persister", func(ctx context.Context) error {
+			return m.persistState(ctx, store)
+		})
+	}
+	// Subscribe before any intercepts can be created, so that the auditor sees them all
+	intercepts := m.state.WatchIntercepts(ctx, nil)
+	m.leaderProxy.setLocal()
+
+	if reconciler != nil {
+		g.Go("intercept-resources", reconciler.Run)
+	}
+
+	g.Go("audit", func(ctx context.Context) error {
+		return auditor.Run(ctx, intercepts)
+	})
+
+	g.Go("intercept-gc", m.runInterceptGCLoop)
 
 	// This goroutine is responsible for informing System A of intercepts (and
 	// relevant metadata like domains) that have been garbage collected. This
 	// ensures System A doesn't list preview URLs + intercepts that no longer
 	// exist.
-	g.Go("systema-gc", mgr.runSystemAGCLoop)
+	g.Go("systema-gc", m.runSystemAGCLoop)
 
-	// Wait for exit
 	return g.Wait()
 }
 
@@ -70,15 +144,25 @@ func (m *Manager) serveHTTP(ctx context.Context) error {
 	env := managerutil.GetEnv(ctx)
 	host := env.ServerHost
 	port := env.ServerPort
-	opts := []grpc.ServerOption{}
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(m.leaderProxy.unaryInterceptor),
+		grpc.StreamInterceptor(m.leaderProxy.streamInterceptor),
+	}
 	if mz, ok := env.MaxReceiveSize.AsInt64(); ok {
 		opts = append(opts, grpc.MaxRecvMsgSize(int(mz)))
 	}
 
+	metricsHandler, err := m.metricsHandler()
+	if err != nil {
+		return fmt.Errorf("unable to register metrics: %w", err)
+	}
+
 	grpcHandler := grpc.NewServer(opts...)
-	httpHandler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	httpHandler := http.NewServeMux()
+	httpHandler.Handle("/metrics", metricsHandler)
+	httpHandler.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintf(w, "Hello World from: %s\n", r.URL.Path)
-	}))
+	})
 	sc := &dhttp.ServerConfig{
 		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
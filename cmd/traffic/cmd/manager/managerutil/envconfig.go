@@ -2,7 +2,9 @@ package managerutil
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/sethvargo/go-envconfig"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -29,6 +31,25 @@ type Env struct {
 	PodCIDRStrategy string `env:"POD_CIDR_STRATEGY,default=auto"`
 	PodCIDRs        string `env:"POD_CIDRS,default="`
 	PodIP           string `env:"TELEPRESENCE_MANAGER_POD_IP,default="`
+
+	StateStore     string `env:"TELEPRESENCE_STATE_STORE,default="`
+	StateStoreName string `env:"TELEPRESENCE_STATE_STORE_NAME,default=traffic-manager-state"`
+
+	LeaderElection      bool   `env:"TELEPRESENCE_LEADER_ELECTION,default=false"`
+	LeaderElectionLease string `env:"TELEPRESENCE_LEADER_ELECTION_LEASE,default=traffic-manager-leader"`
+
+	InterceptResources           bool   `env:"TELEPRESENCE_INTERCEPT_RESOURCES,default=false"`
+	InterceptResourcesNamespaces string `env:"TELEPRESENCE_INTERCEPT_RESOURCES_NAMESPACES,default="`
+
+	InterceptPolicy string `env:"TELEPRESENCE_INTERCEPT_POLICY,default="`
+
+	InterceptMaxTTL time.Duration `env:"TELEPRESENCE_INTERCEPT_MAX_TTL,default=0"`
+
+	AuditLog    string `env:"TELEPRESENCE_AUDIT_LOG,default=-"`
+	AuditEvents bool   `env:"TELEPRESENCE_AUDIT_EVENTS,default=true"`
+
+	SessionGracePeriod  time.Duration `env:"TELEPRESENCE_SESSION_GRACE_PERIOD,default=0"`
+	SuspendedIntercepts string        `env:"TELEPRESENCE_SUSPENDED_INTERCEPTS,default=fallback"`
 }
 
 type envKey struct{}
@@ -38,6 +59,11 @@ func LoadEnv(ctx context.Context) (context.Context, error) {
 	if err := envconfig.Process(ctx, &env); err != nil {
 		return ctx, err
 	}
+	switch env.SuspendedIntercepts {
+	case "fallback", "unavailable":
+	default:
+		return ctx, fmt.Errorf("invalid TELEPRESENCE_SUSPENDED_INTERCEPTS %q, must be one of fallback or unavailable", env.SuspendedIntercepts)
+	}
 	if env.AgentImage == "" {
 		env.AgentImage = "tel2:" + strings.TrimPrefix(version.Version, "v")
 	}
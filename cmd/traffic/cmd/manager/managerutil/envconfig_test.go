@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -39,6 +40,14 @@ func TestEnvconfig(t *testing.T) {
 		AgentPort:       9900,
 		MaxReceiveSize:  resource.MustParse("4Mi"),
 		PodCIDRStrategy: "auto",
+		StateStoreName:  "traffic-manager-state",
+
+		LeaderElectionLease: "traffic-manager-leader",
+
+		AuditLog:    "-",
+		AuditEvents: true,
+
+		SuspendedIntercepts: "fallback",
 	}
 
 	testcases := map[string]struct {
@@ -57,6 +66,24 @@ func TestEnvconfig(t *testing.T) {
 				e.SystemAHost = "app.getambassador.io"
 			},
 		},
+		"intercept-max-ttl": {
+			Input: map[string]string{
+				"TELEPRESENCE_INTERCEPT_MAX_TTL": "8h",
+			},
+			Output: func(e *managerutil.Env) {
+				e.InterceptMaxTTL = 8 * time.Hour
+			},
+		},
+		"session-grace-period": {
+			Input: map[string]string{
+				"TELEPRESENCE_SESSION_GRACE_PERIOD": "10m",
+				"TELEPRESENCE_SUSPENDED_INTERCEPTS": "unavailable",
+			},
+			Output: func(e *managerutil.Env) {
+				e.SessionGracePeriod = 10 * time.Minute
+				e.SuspendedIntercepts = "unavailable"
+			},
+		},
 	}
 
 	for tcName, tc := range testcases {
@@ -0,0 +1,37 @@
+package manager
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/mutator"
+)
+
+// metricsHandler returns a handler that serves the Prometheus metrics of the traffic-manager.
+func (m *Manager) metricsHandler() (http.Handler, error) {
+	reg := prometheus.NewRegistry()
+	for _, c := range []prometheus.Collector{
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		m.state,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "telepresence_manager_leader",
+			Help: "1 if this replica of the traffic-manager is the leader, 0 otherwise.",
+		}, func() float64 {
+			if m.leaderProxy.isLeader() {
+				return 1
+			}
+			return 0
+		}),
+	} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	if err := mutator.RegisterMetrics(reg); err != nil {
+		return nil, err
+	}
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{}), nil
+}
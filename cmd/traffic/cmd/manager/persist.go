@@ -0,0 +1,75 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/persistence"
+	"github.com/datawire/dlib/dlog"
+)
+
+// restoreGracePeriod is the extra time that clients get to reconnect to a traffic-manager that restored their
+// sessions, on top of the time it normally takes for a session to expire.
+const restoreGracePeriod = time.Minute
+
+// persistInterval is the minimum time between two saves of the state.
+const persistInterval = time.Second
+
+// restoreState restores the state that was saved in the given store by a previous traffic-manager.
+func (m *Manager) restoreState(ctx context.Context, store persistence.Store) error {
+	snapshot, err := store.Load(ctx)
+	if err != nil || snapshot == nil {
+		return err
+	}
+	m.state.Restore(ctx, snapshot, m.clock.Now().Add(restoreGracePeriod))
+	return nil
+}
+
+// persistState saves the state in the given store whenever the client sessions or intercepts change.
+func (m *Manager) persistState(ctx context.Context, store persistence.Store) error {
+	clients := m.state.WatchClients(ctx, nil)
+	intercepts := m.state.WatchIntercepts(ctx, nil)
+	ticker := time.NewTicker(persistInterval)
+	defer ticker.Stop()
+
+	var saved []byte
+	dirty := false
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-clients:
+			if !ok {
+				return nil
+			}
+			dirty = true
+		case _, ok := <-intercepts:
+			if !ok {
+				return nil
+			}
+			dirty = true
+		case <-ticker.C:
+			if !dirty {
+				continue
+			}
+			snapshot := m.state.Snapshot()
+			data, err := json.Marshal(snapshot)
+			if err != nil {
+				dlog.Errorf(ctx, "unable to marshal state: %v", err)
+				continue
+			}
+			if bytes.Equal(data, saved) {
+				dirty = false
+				continue
+			}
+			if err = store.Save(ctx, snapshot); err != nil {
+				dlog.Errorf(ctx, "unable to save state: %v", err)
+				continue
+			}
+			saved = data
+			dirty = false
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/authz"
+	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/managerutil"
+	"github.com/TinderBackend/telepresence/v2/pkg/k8sapi"
+)
+
+// verifyClient replaces the Kubernetes identity of the given client with the identity that its token is
+// verified as. The token is verified only when an intercept policy is in effect, and it's never stored.
+func verifyClient(ctx context.Context, client *rpc.ClientInfo) error {
+	token := client.KubeToken
+	client.KubeToken = ""
+	client.KubeIdentity = nil
+	if token == "" || managerutil.GetEnv(ctx).InterceptPolicy == "" {
+		return nil
+	}
+	id, err := authz.VerifyToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	client.KubeIdentity = id
+	return nil
+}
+
+// checkPolicy returns an explanation if the intercept policy doesn't allow the given client to create an
+// intercept with the given spec, or an empty string if it does. The intercept is forbidden when the policy
+// can't be loaded.
+func checkPolicy(ctx context.Context, client *rpc.ClientInfo, spec *rpc.InterceptSpec) string {
+	env := managerutil.GetEnv(ctx)
+	if env.InterceptPolicy == "" {
+		return ""
+	}
+	policy, err := authz.LoadPolicy(ctx, env.ManagerNamespace, env.InterceptPolicy)
+	if err != nil {
+		return err.Error()
+	}
+	req := &authz.Request{Client: client, Spec: spec}
+	if policy.NeedsWorkloadLabels() {
+		wl, err := k8sapi.GetWorkload(ctx, spec.Agent, spec.Namespace, spec.WorkloadKind)
+		if err != nil {
+			return fmt.Sprintf("unable to get labels of workload %s.%s: %v", spec.Agent, spec.Namespace, err)
+		}
+		req.WorkloadLabels = wl.GetLabels()
+	}
+	if policy.Allows(req) {
+		return ""
+	}
+	who := client.Name + ", whose Kubernetes identity isn't verified,"
+	if id := client.KubeIdentity; id != nil {
+		who = id.Username
+	}
+	return fmt.Sprintf("%s is not allowed to intercept %s.%s using the %s mechanism", who, spec.Agent, spec.Namespace, spec.Mechanism)
+}
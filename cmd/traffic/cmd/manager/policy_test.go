@@ -0,0 +1,64 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/authz"
+	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/crd"
+	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/state"
+	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/managerutil"
+	"github.com/TinderBackend/telepresence/v2/pkg/k8sapi"
+	"github.com/datawire/dlib/dlog"
+)
+
+func TestAddResourceIntercept(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+	ctx = k8sapi.WithK8sInterface(ctx, fake.NewSimpleClientset(&core.ConfigMap{
+		ObjectMeta: meta.ObjectMeta{Name: "policy", Namespace: "ambassador"},
+		Data:       map[string]string{authz.PolicyKey: "rules: [{clients: [" + crd.SessionID + "], namespaces: [sandbox]}]"},
+	}))
+	ctx = managerutil.WithEnv(ctx, &managerutil.Env{
+		ManagerNamespace: "ambassador",
+		InterceptPolicy:  "policy",
+		InterceptMaxTTL:  time.Hour,
+	})
+	m := &Manager{ctx: ctx, clock: wall{}, state: state.NewState(ctx)}
+
+	spec := func(name, namespace string) *rpc.InterceptSpec {
+		return &rpc.InterceptSpec{
+			Name:       name,
+			Client:     crd.SessionID,
+			Agent:      "hello",
+			Namespace:  namespace,
+			Mechanism:  "tcp",
+			TargetHost: "10.0.0.5",
+			TargetPort: 8080,
+		}
+	}
+
+	// The policy applies to resource intercepts, and so does the maximum time-to-live
+	ii, err := m.addResourceIntercept(ctx, spec("allowed.sandbox", "sandbox"))
+	require.NoError(t, err)
+	assert.NotEqual(t, rpc.InterceptDispositionType_FORBIDDEN, ii.Disposition)
+	require.NotNil(t, ii.ExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), ii.ExpiresAt.AsTime(), time.Minute)
+
+	ii, err = m.addResourceIntercept(ctx, spec("denied.default", "default"))
+	require.NoError(t, err)
+	assert.Equal(t, rpc.InterceptDispositionType_FORBIDDEN, ii.Disposition)
+	assert.Contains(t, ii.Message, "is not allowed to intercept hello.default")
+
+	// An invalid spec is rejected
+	invalid := spec("invalid.sandbox", "sandbox")
+	invalid.Agent = ""
+	_, err = m.addResourceIntercept(ctx, invalid)
+	assert.Error(t, err)
+}
@@ -9,16 +9,20 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/miekg/dns"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	empty "google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
 	"github.com/TinderBackend/telepresence/rpc/v2/systema"
 	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/cluster"
+	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/crd"
 	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/internal/state"
 	"github.com/TinderBackend/telepresence/v2/cmd/traffic/cmd/manager/managerutil"
+	"github.com/TinderBackend/telepresence/v2/pkg/dnsproxy"
 	"github.com/TinderBackend/telepresence/v2/pkg/iputil"
 	"github.com/TinderBackend/telepresence/v2/pkg/tunnel"
 	"github.com/TinderBackend/telepresence/v2/pkg/version"
@@ -37,6 +41,7 @@ type Manager struct {
 	state       *state.State
 	systema     *systemaPool
 	clusterInfo cluster.Info
+	leaderProxy *leaderProxy
 
 	rpc.UnsafeManagerServer
 }
@@ -56,14 +61,18 @@ func NewManager(ctx context.Context) *Manager {
 		ID:          uuid.New().String(),
 		state:       state.NewState(ctx),
 		clusterInfo: cluster.NewInfo(ctx),
+		leaderProxy: newLeaderProxy(),
 	}
 	ret.systema = NewSystemAPool(ret)
 	return ret
 }
 
 // Version returns the version information of the Manager.
-func (*Manager) Version(context.Context, *empty.Empty) (*rpc.VersionInfo2, error) {
-	return &rpc.VersionInfo2{Version: version.Version}, nil
+func (*Manager) Version(ctx context.Context, _ *empty.Empty) (*rpc.VersionInfo2, error) {
+	return &rpc.VersionInfo2{
+		Version:           version.Version,
+		KubeTokenRequired: managerutil.GetEnv(ctx).InterceptPolicy != "",
+	}, nil
 }
 
 // GetLicense returns the license for the cluster. This directory is mounted
@@ -133,10 +142,38 @@ func (m *Manager) ArriveAsClient(ctx context.Context, client *rpc.ClientInfo) (*
 		return nil, status.Errorf(codes.InvalidArgument, val)
 	}
 
-	sessionID := m.state.AddClient(client, m.clock.Now())
+	if err := verifyClient(ctx, client); err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	// Routing outbound connections through a workload's traffic-agent is subject to the intercept policy,
+	// as an intercept of that workload using the "egress" mechanism.
+	for _, rule := range client.EgressRules {
+		spec := &rpc.InterceptSpec{Agent: rule.Workload, Namespace: rule.Namespace, Mechanism: "egress"}
+		if msg := checkPolicy(ctx, client, spec); msg != "" {
+			return nil, status.Error(codes.PermissionDenied, msg)
+		}
+	}
+
+	now := m.clock.Now()
+	resume := client.ResumeSession
+	client.ResumeSession = nil
+	if resume != nil {
+		if m.state.ResumeClient(resume.SessionId, resume.ResumeToken, client, now) {
+			dlog.Infof(ctx, "Session %s resumed", resume.SessionId)
+			return &rpc.SessionInfo{
+				SessionId:   resume.SessionId,
+				ResumeToken: resume.ResumeToken,
+			}, nil
+		}
+		dlog.Infof(ctx, "Session %s can't be resumed, creating a new session", resume.SessionId)
+	}
+
+	sessionID := m.state.AddClient(client, now)
 
 	return &rpc.SessionInfo{
-		SessionId: sessionID,
+		SessionId:   sessionID,
+		ResumeToken: m.state.ResumeToken(sessionID),
 	}, nil
 }
 
@@ -305,15 +342,62 @@ func (m *Manager) CreateIntercept(ctx context.Context, ciReq *rpc.CreateIntercep
 	apiKey := ciReq.GetApiKey()
 	dlog.Debug(ctx, "CreateIntercept called")
 
-	if m.state.GetClient(sessionID) == nil {
+	client := m.state.GetClient(sessionID)
+	if client == nil {
 		return nil, status.Errorf(codes.NotFound, "Client session %q not found", sessionID)
 	}
 
 	if val := validateIntercept(spec); val != "" {
 		return nil, status.Errorf(codes.InvalidArgument, val)
 	}
+	ttl := ciReq.GetTtl().AsDuration()
+	if ttl < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "ttl must not be negative")
+	}
+	return m.addIntercept(ctx, client, sessionID, apiKey, spec, ttl)
+}
+
+// addIntercept adds an intercept with the given spec for the given client unless the intercept policy
+// forbids it, in which case a rejected intercept is added. The given time-to-live is clamped to the
+// maximum time-to-live, and zero means that the intercept lives until it's removed.
+func (m *Manager) addIntercept(ctx context.Context, client *rpc.ClientInfo, sessionID, apiKey string, spec *rpc.InterceptSpec, ttl time.Duration) (*rpc.InterceptInfo, error) {
+	if msg := checkPolicy(ctx, client, spec); msg != "" {
+		dlog.Infof(ctx, "CreateIntercept forbidden: %s", msg)
+		return m.state.AddRejectedIntercept(sessionID, apiKey, spec, rpc.InterceptDispositionType_FORBIDDEN, msg)
+	}
 
-	return m.state.AddIntercept(sessionID, apiKey, spec)
+	ii, err := m.state.AddIntercept(sessionID, apiKey, spec)
+	if err != nil {
+		return nil, err
+	}
+	env := managerutil.GetEnv(ctx)
+	if maxTTL := env.InterceptMaxTTL; maxTTL > 0 && (ttl == 0 || ttl > maxTTL) {
+		ttl = maxTTL
+	}
+	unavailable := env.SuspendedIntercepts == "unavailable"
+	if ttl > 0 || unavailable {
+		var expiresAt *timestamppb.Timestamp
+		if ttl > 0 {
+			expiresAt = timestamppb.New(m.clock.Now().Add(ttl))
+		}
+		if ui := m.state.UpdateIntercept(ii.Id, func(ii *rpc.InterceptInfo) {
+			ii.ExpiresAt = expiresAt
+			ii.UnavailableWhenSuspended = unavailable
+		}); ui != nil {
+			ii = ui
+		}
+	}
+	return ii, nil
+}
+
+// addResourceIntercept adds the intercept that an Intercept resource declares. It's subject to the same
+// validation, intercept policy, and maximum time-to-live as the intercepts that clients create. The
+// policy sees it as created by a client named after the session of the resource intercepts.
+func (m *Manager) addResourceIntercept(ctx context.Context, spec *rpc.InterceptSpec) (*rpc.InterceptInfo, error) {
+	if val := validateIntercept(spec); val != "" {
+		return nil, errors.New(val)
+	}
+	return m.addIntercept(ctx, &rpc.ClientInfo{Name: crd.SessionID}, crd.SessionID, "", spec, 0)
 }
 
 func (m *Manager) makeinterceptID(ctx context.Context, sessionID string, name string) (string, error) {
@@ -611,6 +695,57 @@ func (m *Manager) WatchLookupHost(session *rpc.SessionInfo, stream rpc.Manager_W
 	}
 }
 
+func (m *Manager) LookupDNS(ctx context.Context, request *rpc.DNSRequest) (*rpc.DNSResponse, error) {
+	ctx = managerutil.WithSessionInfo(ctx, request.GetSession())
+	qType := uint16(request.Type)
+	qtn := dns.TypeToString[qType]
+	dlog.Debugf(ctx, "LookupDNS called %s %s", qtn, request.Name)
+	sessionID := request.GetSession().GetSessionId()
+
+	rrs, rCode, count, err := m.state.AgentsLookupDNS(ctx, sessionID, request)
+	if err != nil {
+		dlog.Errorf(ctx, "AgentsLookupDNS: %v", err)
+	} else if count > 0 {
+		dlog.Debugf(ctx, "LookupDNS on agents: %s %s -> %s %s", qtn, request.Name, dns.RcodeToString[rCode], rrs)
+	}
+
+	if count == 0 {
+		if rrs, rCode, err = dnsproxy.Lookup(ctx, qType, request.Name); err != nil {
+			dlog.Errorf(ctx, "LookupDNS on traffic-manager: %v", err)
+		} else {
+			dlog.Debugf(ctx, "LookupDNS on traffic-manager: %s %s -> %s %s", qtn, request.Name, dns.RcodeToString[rCode], rrs)
+		}
+	}
+	return dnsproxy.ToRPC(rrs, rCode)
+}
+
+func (m *Manager) AgentLookupDNSResponse(ctx context.Context, response *rpc.DNSAgentResponse) (*empty.Empty, error) {
+	ctx = managerutil.WithSessionInfo(ctx, response.GetSession())
+	dlog.Debugf(ctx, "AgentLookupDNSResponse called %s %s", dns.TypeToString[uint16(response.Request.Type)], response.Request.Name)
+	m.state.PostLookupDNSResponse(response)
+	return &empty.Empty{}, nil
+}
+
+func (m *Manager) WatchLookupDNS(session *rpc.SessionInfo, stream rpc.Manager_WatchLookupDNSServer) error {
+	ctx := managerutil.WithSessionInfo(stream.Context(), session)
+	dlog.Debugf(ctx, "WatchLookupDNS called")
+	drCh := m.state.WatchLookupDNS(session.SessionId)
+	for {
+		select {
+		case <-m.ctx.Done():
+			return nil
+		case dr := <-drCh:
+			if dr == nil {
+				return nil
+			}
+			if err := stream.Send(dr); err != nil {
+				dlog.Errorf(ctx, "WatchLookupDNS.Send() failed: %v", err)
+				return nil
+			}
+		}
+	}
+}
+
 // GetLogs acquires the logs for the traffic-manager and/or traffic-agents specified by the
 // GetLogsRequest and returns them to the caller
 // Deprecated: Clients should use the user daemon's GatherLogs method
@@ -638,7 +773,10 @@ func (m *Manager) WatchClusterInfo(session *rpc.SessionInfo, stream rpc.Manager_
 	return m.clusterInfo.Watch(ctx, stream)
 }
 
-// expire removes stale sessions.
+// expire suspends or removes stale sessions, and removes intercepts that have outlived their time-to-live.
 func (m *Manager) expire(ctx context.Context) {
-	m.state.ExpireSessions(ctx, m.clock.Now().Add(-15*time.Second))
+	now := m.clock.Now()
+	moment := now.Add(-15 * time.Second)
+	m.state.ExpireSessions(ctx, moment, moment.Add(-managerutil.GetEnv(ctx).SessionGracePeriod))
+	m.state.ExpireIntercepts(ctx, now)
 }
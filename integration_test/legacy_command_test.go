@@ -30,7 +30,7 @@ func (s *singleServiceSuite) Test_LegacySwapDeploymentDoesIntercept() {
 		if err != nil || stderr != "" {
 			return false
 		}
-		return strings.Contains(stdout, "No Workloads (Deployments, StatefulSets, or ReplicaSets)")
+		return strings.Contains(stdout, "No Workloads (Deployments, StatefulSets, ReplicaSets, DaemonSets, Jobs, CronJobs, or Rollouts)")
 	},
 		10*time.Second,
 		1*time.Second,
@@ -94,13 +94,13 @@ func (s *multipleServicesSuite) Test_ListOnlyMapped() {
 	require.Empty(stdout)
 
 	stdout = itest.TelepresenceOk(ctx, "list", "--namespace", s.AppNamespace())
-	require.Contains(stdout, "No Workloads (Deployments, StatefulSets, or ReplicaSets)")
+	require.Contains(stdout, "No Workloads (Deployments, StatefulSets, ReplicaSets, DaemonSets, Jobs, CronJobs, or Rollouts)")
 
 	stdout = itest.TelepresenceOk(ctx, "connect", "--mapped-namespaces", "all")
 	require.Empty(stdout)
 
 	stdout = itest.TelepresenceOk(ctx, "list", "--namespace", s.AppNamespace())
-	require.NotContains(stdout, "No Workloads (Deployments, StatefulSets, or ReplicaSets)")
+	require.NotContains(stdout, "No Workloads (Deployments, StatefulSets, ReplicaSets, DaemonSets, Jobs, CronJobs, or Rollouts)")
 }
 
 func (s *multipleServicesSuite) Test_RepeatedConnect() {
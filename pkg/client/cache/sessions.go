@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"context"
+	"os"
+
+	"github.com/TinderBackend/telepresence/rpc/v2/manager"
+)
+
+const sessionsFile = "sessions.json"
+
+// SaveSessionToUserCache saves the given session so that it can be resumed by a later connect to the
+// traffic-manager with the given key. The session must contain the token that is needed to resume it.
+func SaveSessionToUserCache(ctx context.Context, key string, session *manager.SessionInfo) error {
+	sessions, err := loadSessionsFromUserCache(ctx)
+	if err != nil {
+		return err
+	}
+	sessions[key] = session
+	return SaveToUserCache(ctx, sessions, sessionsFile)
+}
+
+// LoadSessionFromUserCache returns the session that was saved for the traffic-manager with the given key,
+// or nil if no such session was saved.
+func LoadSessionFromUserCache(ctx context.Context, key string) (*manager.SessionInfo, error) {
+	sessions, err := loadSessionsFromUserCache(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return sessions[key], nil
+}
+
+// DeleteSessionFromUserCache removes the session that was saved for the traffic-manager with the given
+// key. An attempt to remove a non-existing session is a no-op and the function returns nil.
+func DeleteSessionFromUserCache(ctx context.Context, key string) error {
+	sessions, err := loadSessionsFromUserCache(ctx)
+	if err != nil {
+		return err
+	}
+	if _, ok := sessions[key]; !ok {
+		return nil
+	}
+	delete(sessions, key)
+	if len(sessions) == 0 {
+		return DeleteFromUserCache(ctx, sessionsFile)
+	}
+	return SaveToUserCache(ctx, sessions, sessionsFile)
+}
+
+func loadSessionsFromUserCache(ctx context.Context) (map[string]*manager.SessionInfo, error) {
+	var sessions map[string]*manager.SessionInfo
+	if err := LoadFromUserCache(ctx, &sessions, sessionsFile); err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	if sessions == nil {
+		sessions = make(map[string]*manager.SessionInfo)
+	}
+	return sessions, nil
+}
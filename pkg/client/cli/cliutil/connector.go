@@ -60,7 +60,9 @@ func replaceConnectorConn(ctx context.Context, conn *grpc.ClientConn) {
 	}
 }
 
-func withConnectorConn(ctx context.Context, conn *grpc.ClientConn) context.Context {
+// WithConnectorConn returns a context that makes WithConnector, and the functions that use it, use the
+// given connection to the connector instead of dialing its socket.
+func WithConnectorConn(ctx context.Context, conn *grpc.ClientConn) context.Context {
 	var up unsafe.Pointer
 	atomic.StorePointer(&up, unsafe.Pointer(conn))
 	return context.WithValue(ctx, connectorConnPtrKey{}, &up)
@@ -110,7 +112,7 @@ func withConnector(ctx context.Context, maybeStart bool, withNotify bool, fn fun
 	if err != nil {
 		return err
 	}
-	ctx = withConnectorConn(ctx, conn)
+	ctx = WithConnectorConn(ctx, conn)
 	defer func() {
 		if conn := getConnectorConn(ctx); conn != nil {
 			conn.Close()
@@ -67,8 +67,15 @@ func WithStartedNetwork(ctx context.Context, fn func(context.Context, daemon.Dae
 	return withNetwork(ctx, false, fn)
 }
 
+type daemonConnCtxKey struct{}
+
+// WithDaemonConn returns a context that makes WithNetwork, and the functions that use it, use the given
+// connection to the daemon instead of dialing its socket.
+func WithDaemonConn(ctx context.Context, conn *grpc.ClientConn) context.Context {
+	return context.WithValue(ctx, daemonConnCtxKey{}, conn)
+}
+
 func withNetwork(ctx context.Context, maybeStart bool, fn func(context.Context, daemon.DaemonClient) error) error {
-	type daemonConnCtxKey struct{}
 	if untyped := ctx.Value(daemonConnCtxKey{}); untyped != nil {
 		conn := untyped.(*grpc.ClientConn)
 		daemonClient := daemon.NewDaemonClient(conn)
@@ -102,7 +109,7 @@ func withNetwork(ctx context.Context, maybeStart bool, fn func(context.Context,
 		return err
 	}
 	defer conn.Close()
-	ctx = context.WithValue(ctx, daemonConnCtxKey{}, conn)
+	ctx = WithDaemonConn(ctx, conn)
 
 	daemonClient := daemon.NewDaemonClient(conn)
 	if !started {
@@ -119,8 +119,8 @@ func Command(ctx context.Context) *cobra.Command {
   rootCmd.InitDefaultHelpCmd()
   static := cliutil.CommandGroups{
     "Session Commands": []*cobra.Command{connectCommand(), LoginCommand(), LogoutCommand(), LicenseCommand(), statusCommand(), quitCommand()},
-    "Traffic Commands": []*cobra.Command{listCommand(), interceptCommand(ctx), leaveCommand(), previewCommand()},
-    "Debug Commands":   []*cobra.Command{loglevelCommand(), gatherLogsCommand()},
+    "Traffic Commands": []*cobra.Command{listCommand(), interceptCommand(ctx), leaveCommand(), previewCommand(), replayCommand()},
+    "Debug Commands":   []*cobra.Command{loglevelCommand(), gatherLogsCommand(), dnsCommand()},
     "Other Commands":   []*cobra.Command{versionCommand(), uninstallCommand(), dashboardCommand(), ClusterIdCommand(), genYAMLCommand(), vpnDiagCommand()},
   }
   for name, cmds := range static {
@@ -139,6 +139,7 @@ func Command(ctx context.Context) *cobra.Command {
 	command.Args = argsCheck(ac)
       }
       initDeprecatedPersistentFlags(command)
+      initOutputFormat(command)
     }
   }
   for _, group := range globalFlagGroups {
@@ -158,6 +159,26 @@ func argsCheck(f cobra.PositionalArgs) cobra.PositionalArgs {
   }
 }
 
+// initOutputFormat makes the command, and its subcommands, print their output, and the errors that
+// they return before they run, in the format given by the global --output flag.
+func initOutputFormat(cmd *cobra.Command) {
+  if cmd.RunE != nil {
+    cmd.RunE = withOutputFormat(cmd.RunE)
+  }
+  if cmd.Args != nil {
+    cmd.Args = withReportedErrors(cmd.Args)
+  }
+  if cmd.PersistentPreRunE != nil {
+    cmd.PersistentPreRunE = withReportedErrors(cmd.PersistentPreRunE)
+  }
+  if cmd.PreRunE != nil {
+    cmd.PreRunE = withReportedErrors(cmd.PreRunE)
+  }
+  for _, sub := range cmd.Commands() {
+    initOutputFormat(sub)
+  }
+}
+
 func initDeprecatedPersistentFlags(cmd *cobra.Command) {
   cmd.Flags().AddFlagSet(deprecatedGlobalFlags)
   opf := cmd.PostRun
@@ -200,6 +221,10 @@ func initGlobalFlagGroups() {
 	"no-report", false,
 	"turn off anonymous crash reports and log submission on failure",
       )
+      flags.String(
+	"output", outputText,
+	"set the output format, supported values are 'text', 'json', and 'yaml'",
+      )
       return flags
     }(),
   }}
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/TinderBackend/telepresence/rpc/v2/daemon"
+	"github.com/TinderBackend/telepresence/v2/pkg/client/cli/cliutil"
+	"github.com/TinderBackend/telepresence/v2/pkg/client/errcat"
+)
+
+func dnsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "dns",
+		Args: OnlySubcommands,
+
+		Short: "Inspect the local DNS resolver, or flush its cache",
+		RunE:  RunSubcommands,
+	}
+
+	flushCmd := &cobra.Command{
+		Use:  "flush",
+		Args: cobra.NoArgs,
+
+		Short: "Remove all entries from the cache of the local DNS resolver",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withDNSResolver(cmd.Context(), func(ctx context.Context, daemonClient daemon.DaemonClient) error {
+				if _, err := daemonClient.FlushDNS(ctx, &empty.Empty{}); err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), "DNS cache flushed")
+				return nil
+			})
+		},
+	}
+
+	statsCmd := &cobra.Command{
+		Use:  "stats",
+		Args: cobra.NoArgs,
+
+		Short: "Show the request and cache statistics of the local DNS resolver",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withDNSResolver(cmd.Context(), func(ctx context.Context, daemonClient daemon.DaemonClient) error {
+				stats, err := daemonClient.GetDNSStats(ctx, &empty.Empty{})
+				if err != nil {
+					return err
+				}
+				if !setResult(cmd.OutOrStdout(), stats) {
+					printDNSStats(cmd, stats)
+				}
+				return nil
+			})
+		},
+	}
+	var tail int
+	traceCmd := &cobra.Command{
+		Use:  "trace",
+		Args: cobra.NoArgs,
+
+		Short: "Show the queries that the local DNS resolver answers as they happen",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// The queries are printed as they happen, so they can't be captured in a single document
+			if err := requireTextOutput(cmd, "with dns trace"); err != nil {
+				return err
+			}
+			return withDNSResolver(cmd.Context(), func(ctx context.Context, daemonClient daemon.DaemonClient) error {
+				stream, err := daemonClient.TraceDNS(ctx, &daemon.TraceDNSRequest{Tail: int32(tail)})
+				if err != nil {
+					return err
+				}
+				for {
+					q, err := stream.Recv()
+					if err != nil {
+						if ctx.Err() != nil || errors.Is(err, io.EOF) {
+							err = nil
+						}
+						return err
+					}
+					printDNSQuery(cmd, q)
+				}
+			})
+		},
+	}
+	traceCmd.Flags().IntVar(&tail, "tail", -1, "Number of recent queries to show before new ones. A negative number shows all recent queries")
+	cmd.AddCommand(flushCmd, statsCmd, traceCmd)
+	return cmd
+}
+
+// withDNSResolver calls the given function with a client of the root daemon, and returns a user error
+// when the root daemon isn't running or isn't connected.
+func withDNSResolver(ctx context.Context, f func(context.Context, daemon.DaemonClient) error) error {
+	err := cliutil.WithStartedNetwork(ctx, f)
+	if errors.Is(err, cliutil.ErrNoNetwork) || status.Code(err) == codes.Unavailable {
+		err = errcat.User.New("not connected")
+	}
+	return err
+}
+
+func printDNSStats(cmd *cobra.Command, stats *daemon.DNSStats) {
+	out := cmd.OutOrStdout()
+	hitRate := 0.0
+	if lookups := stats.CacheHits + stats.CacheMisses; lookups > 0 {
+		hitRate = 100 * float64(stats.CacheHits) / float64(lookups)
+	}
+	fmt.Fprintf(out, "Requests              : %d\n", stats.RequestCount)
+	fmt.Fprintf(out, "Cache hits            : %d (%.1f%%)\n", stats.CacheHits, hitRate)
+	fmt.Fprintf(out, "Cache misses          : %d\n", stats.CacheMisses)
+	fmt.Fprintf(out, "Cache entries         : %d\n", stats.CacheEntries)
+	fmt.Fprintf(out, "Negative cache entries: %d\n", stats.NegativeCacheEntries)
+}
+
+func printDNSQuery(cmd *cobra.Command, q *daemon.DNSQuery) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %-6s %s -> %s (%s, %s)",
+		q.Time.AsTime().Local().Format("15:04:05.000"), q.Type, q.Name, q.RCode, q.Source, q.Latency.AsDuration().Round(time.Microsecond))
+	if len(q.ClusterLookups) > 0 && !(len(q.ClusterLookups) == 1 && q.ClusterLookups[0] == q.Name) {
+		fmt.Fprintf(&sb, " lookups: %s", strings.Join(q.ClusterLookups, ", "))
+	}
+	if q.Error != "" {
+		fmt.Fprintf(&sb, " error: %s", q.Error)
+	}
+	if len(q.Answers) > 0 {
+		fmt.Fprintf(&sb, "\n\t%s", strings.Join(q.Answers, "\n\t"))
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), sb.String())
+}
@@ -31,6 +31,12 @@ type gatherLogsArgs struct {
 	podYaml        bool
 }
 
+// gatherLogsResult is the result of the gather-logs command.
+type gatherLogsResult struct {
+	OutputFile string   `json:"output_file"`
+	Files      []string `json:"files"`
+}
+
 func gatherLogsCommand() *cobra.Command {
 	gl := &gatherLogsArgs{}
 	cmd := &cobra.Command{
@@ -242,7 +248,13 @@ func (gl *gatherLogsArgs) gatherLogs(ctx context.Context, cmd *cobra.Command) er
 		return errcat.User.New(err)
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "Logs have been exported to %s\n", gl.outputFile)
+	gr := &gatherLogsResult{OutputFile: gl.outputFile, Files: make([]string, len(files))}
+	for i, file := range files {
+		gr.Files[i] = filepath.Base(file)
+	}
+	if out := cmd.OutOrStdout(); !setResult(out, gr) {
+		fmt.Fprintf(out, "Logs have been exported to %s\n", gl.outputFile)
+	}
 	return nil
 }
 
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/TinderBackend/telepresence/rpc/v2/connector"
 	"github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/pkg/tunnel"
 )
 
 type listInfo struct {
@@ -64,11 +66,14 @@ func (s *listInfo) list(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 	stdout := cmd.OutOrStdout()
+	if setResult(stdout, r.Workloads) {
+		return nil
+	}
 	if len(r.Workloads) == 0 {
 		if s.json {
 			fmt.Fprintln(stdout, "[]")
 		} else {
-			fmt.Fprintln(stdout, "No Workloads (Deployments, StatefulSets, or ReplicaSets)")
+			fmt.Fprintln(stdout, "No Workloads (Deployments, StatefulSets, ReplicaSets, DaemonSets, Jobs, CronJobs, or Rollouts)")
 		}
 		return nil
 	}
@@ -158,7 +163,7 @@ func DescribeIntercept(ii *manager.InterceptInfo, volumeMountsPrevented error, d
 	fields = append(fields, kv{"Intercept name", ii.Spec.Name})
 	fields = append(fields, kv{"State", func() string {
 		msg := ""
-		if ii.Disposition > manager.InterceptDispositionType_WAITING {
+		if ii.Disposition > manager.InterceptDispositionType_WAITING && ii.Disposition != manager.InterceptDispositionType_SUSPENDED {
 			msg += "error: "
 		}
 		msg += ii.Disposition.String()
@@ -176,9 +181,25 @@ func DescribeIntercept(ii *manager.InterceptInfo, volumeMountsPrevented error, d
 	fields = append(fields, kv{"Destination",
 		net.JoinHostPort(ii.Spec.TargetHost, fmt.Sprintf("%d", ii.Spec.TargetPort))})
 
+	if ii.Spec.EgressDestination != "" {
+		fields = append(fields, kv{"Egress Destination", ii.Spec.EgressDestination})
+	}
+	if ii.Spec.Mirror {
+		fields = append(fields, kv{"Mirror", "copies of the traffic are sent, responses are discarded"})
+	}
+	if faults := tunnel.FormatFaultSpec(ii.Spec.Faults); faults != "" {
+		fields = append(fields, kv{"Faults", faults})
+	}
+	if ii.ExpiresAt != nil {
+		fields = append(fields, kv{"Expires", ii.ExpiresAt.AsTime().Local().Format(time.RFC1123)})
+	}
 	if ii.Spec.ServicePortIdentifier != "" {
 		fields = append(fields, kv{"Service Port Identifier", ii.Spec.ServicePortIdentifier})
 	}
+	for _, sp := range ii.Spec.ServicePorts {
+		fields = append(fields, kv{"Additional Port",
+			fmt.Sprintf("%s -> %s", sp.ServicePortIdentifier, net.JoinHostPort(ii.Spec.TargetHost, fmt.Sprintf("%d", sp.TargetPort)))})
+	}
 	if debug {
 		fields = append(fields, kv{"Mechanism", ii.Spec.Mechanism})
 		fields = append(fields, kv{"Mechanism Args", fmt.Sprintf("%q", ii.Spec.MechanismArgs)})
@@ -202,13 +223,7 @@ func DescribeIntercept(ii *manager.InterceptInfo, volumeMountsPrevented error, d
 	}()})
 
 	if ii.PreviewDomain != "" {
-		previewURL := ii.PreviewDomain
-		// Right now SystemA gives back domains with the leading "https://", but
-		// let's not rely on that.
-		if !strings.HasPrefix(previewURL, "https://") && !strings.HasPrefix(previewURL, "http://") {
-			previewURL = "https://" + previewURL
-		}
-		fields = append(fields, kv{"Preview URL", previewURL})
+		fields = append(fields, kv{"Preview URL", previewURL(ii)})
 	}
 	if l5Hostname := ii.GetPreviewSpec().GetIngress().GetL5Host(); l5Hostname != "" {
 		fields = append(fields, kv{"Layer 5 Hostname", l5Hostname})
@@ -229,3 +244,75 @@ func DescribeIntercept(ii *manager.InterceptInfo, volumeMountsPrevented error, d
 	}
 	return msg
 }
+
+// previewURL returns the URL of the preview domain of the given intercept.
+func previewURL(ii *manager.InterceptInfo) string {
+	if ii.PreviewDomain == "" {
+		return ""
+	}
+	url := ii.PreviewDomain
+	// Right now SystemA gives back domains with the leading "https://", but
+	// let's not rely on that.
+	if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+		url = "https://" + url
+	}
+	return url
+}
+
+// interceptResult is the result of the intercept command. It contains the same information as the
+// description of the intercept that DescribeIntercept returns.
+type interceptResult struct {
+	ID                    string     `json:"id,omitempty"`
+	Name                  string     `json:"name"`
+	LocalOnly             bool       `json:"local_only,omitempty"`
+	Disposition           string     `json:"disposition,omitempty"`
+	Message               string     `json:"message,omitempty"`
+	WorkloadKind          string     `json:"workload_kind,omitempty"`
+	Workload              string     `json:"workload,omitempty"`
+	Namespace             string     `json:"namespace,omitempty"`
+	TargetHost            string     `json:"target_host,omitempty"`
+	TargetPort            int32      `json:"target_port,omitempty"`
+	ServicePortIdentifier string     `json:"service_port_identifier,omitempty"`
+	EgressDestination     string     `json:"egress_destination,omitempty"`
+	Mirror                bool       `json:"mirror,omitempty"`
+	Faults                string     `json:"faults,omitempty"`
+	ExpiresAt             *time.Time `json:"expires_at,omitempty"`
+	Mechanism             string     `json:"mechanism,omitempty"`
+	MechanismArgs         []string   `json:"mechanism_args,omitempty"`
+	MountPoint            string     `json:"mount_point,omitempty"`
+	MountError            string     `json:"mount_error,omitempty"`
+	PreviewURL            string     `json:"preview_url,omitempty"`
+	L5Hostname            string     `json:"l5_hostname,omitempty"`
+}
+
+func newInterceptResult(ii *manager.InterceptInfo, volumeMountsPrevented error) *interceptResult {
+	spec := ii.Spec
+	ir := &interceptResult{
+		ID:                    ii.Id,
+		Name:                  spec.Name,
+		Disposition:           ii.Disposition.String(),
+		Message:               ii.Message,
+		WorkloadKind:          spec.WorkloadKind,
+		Workload:              spec.Agent,
+		Namespace:             spec.Namespace,
+		TargetHost:            spec.TargetHost,
+		TargetPort:            spec.TargetPort,
+		ServicePortIdentifier: spec.ServicePortIdentifier,
+		EgressDestination:     spec.EgressDestination,
+		Mirror:                spec.Mirror,
+		Faults:                tunnel.FormatFaultSpec(spec.Faults),
+		Mechanism:             spec.Mechanism,
+		MechanismArgs:         spec.MechanismArgs,
+		MountPoint:            spec.MountPoint,
+		PreviewURL:            previewURL(ii),
+		L5Hostname:            ii.GetPreviewSpec().GetIngress().GetL5Host(),
+	}
+	if ii.ExpiresAt != nil {
+		t := ii.ExpiresAt.AsTime()
+		ir.ExpiresAt = &t
+	}
+	if spec.MountPoint == "" && volumeMountsPrevented != nil {
+		ir.MountError = volumeMountsPrevented.Error()
+	}
+	return ir
+}
@@ -19,6 +19,14 @@ import (
 
 const defaultDuration = 30 * time.Minute
 
+// logLevelResult is the result of the loglevel command.
+type logLevelResult struct {
+	LogLevel string `json:"log_level"`
+	Duration string `json:"duration"`
+	Local    bool   `json:"local"`
+	Remote   bool   `json:"remote"`
+}
+
 type logLevelSetter struct {
 	duration   time.Duration
 	localOnly  bool
@@ -88,8 +96,16 @@ func (lls *logLevelSetter) setTempLogLevel(cmd *cobra.Command, args []string) er
 				_, err := managerClient.SetLogLevel(ctx, rq)
 				return err
 			})
-			return err
+			if err != nil {
+				return err
+			}
 		}
+		setResult(cmd.OutOrStdout(), &logLevelResult{
+			LogLevel: args[0],
+			Duration: lls.duration.String(),
+			Local:    !lls.remoteOnly,
+			Remote:   !lls.localOnly,
+		})
 		return nil
 	})
 }
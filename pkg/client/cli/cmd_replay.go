@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/TinderBackend/telepresence/v2/pkg/client/errcat"
+	"github.com/TinderBackend/telepresence/v2/pkg/recording"
+)
+
+func replayCommand() *cobra.Command {
+	var flags struct {
+		to             string
+		timeout        time.Duration
+		printResponses bool
+	}
+	cmd := &cobra.Command{
+		Use:  "replay <file> --to <host:port>",
+		Args: cobra.ExactArgs(1),
+
+		Short: "Replay a recorded intercept against a local process",
+		Long: `Replay the connections recorded by 'telepresence intercept --record <file>' against a local process.
+
+The connections are replayed one at a time, in the order that they were opened. The requests of a connection are
+sent in the order that they were recorded, and the responses recorded ahead of a request are awaited before it's sent.
+The replay doesn't require a connection to the cluster.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if flags.to == "" {
+				return errcat.User.New("the --to flag is required")
+			}
+			hdr, conns, err := recording.ReadFile(args[0])
+			if err != nil {
+				return errcat.User.Newf("unable to read recording %q: %w", args[0], err)
+			}
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "Replaying %d connections of intercept %s recorded %s to %s\n",
+				len(conns), hdr.Intercept, hdr.Started.Format(time.RFC3339), flags.to)
+
+			var responses io.Writer
+			if flags.printResponses {
+				responses = out
+			}
+			for _, c := range conns {
+				result, err := recording.Replay(cmd.Context(), c, flags.to, flags.timeout, responses)
+				if err != nil {
+					return errcat.User.Newf("replay of %s failed: %w", c.ID, err)
+				}
+				if flags.printResponses {
+					fmt.Fprintln(out)
+				}
+				fmt.Fprintf(out, "%s: sent %d bytes, received %d bytes (recorded %d bytes)\n",
+					c.ID, result.Sent, result.Received, result.Recorded)
+			}
+			return nil
+		},
+	}
+	flagSet := cmd.Flags()
+	flagSet.StringVar(&flags.to, "to", "", "The <host:port> of the local process to replay the connections against")
+	flagSet.DurationVar(&flags.timeout, "timeout", 5*time.Second, "How long to wait for a response before sending the next request")
+	flagSet.BoolVar(&flags.printResponses, "print-responses", false, "Print the responses of the local process")
+	return cmd
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"sort"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -39,11 +40,12 @@ type daemonStatus struct {
 }
 
 type daemonStatusDNS struct {
-	LocalIP         net.IP        `json:"local_ip,omitempty"`
-	RemoteIP        net.IP        `json:"remote_ip,omitempty"`
-	ExcludeSuffixes []string      `json:"exclude_suffixes,omitempty"`
-	IncludeSuffixes []string      `json:"include_suffixes,omitempty"`
-	LookupTimeout   time.Duration `json:"lookup_timeout_in_nanos,omitempty"`
+	LocalIP         net.IP            `json:"local_ip,omitempty"`
+	RemoteIP        net.IP            `json:"remote_ip,omitempty"`
+	ExcludeSuffixes []string          `json:"exclude_suffixes,omitempty"`
+	IncludeSuffixes []string          `json:"include_suffixes,omitempty"`
+	LookupTimeout   time.Duration     `json:"lookup_timeout_in_nanos,omitempty"`
+	Overrides       map[string]string `json:"overrides,omitempty"`
 }
 
 type connectorStatus struct {
@@ -101,6 +103,9 @@ func (s *statusInfo) status(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
+	if setResult(s.out, &statusOutput{DaemonStatus: *ds, UserDaemon: *cs}) {
+		return nil
+	}
 	if s.json {
 		return s.printJSON(ds, cs)
 	}
@@ -136,6 +141,7 @@ func (s *statusInfo) daemonStatus(ctx context.Context) (*daemonStatus, error) {
 			ds.DNS.ExcludeSuffixes = dns.ExcludeSuffixes
 			ds.DNS.IncludeSuffixes = dns.IncludeSuffixes
 			ds.DNS.LookupTimeout = dns.LookupTimeout.AsDuration()
+			ds.DNS.Overrides = dns.Overrides
 			for _, subnet := range obc.AlsoProxySubnets {
 				ds.AlsoProxySubnets = append(ds.AlsoProxySubnets, iputil.IPNetFromRPC(subnet).String())
 			}
@@ -254,6 +260,17 @@ func (s *statusInfo) printDaemonText(ds *daemonStatus) {
 			s.printf("    Exclude suffixes: %v\n", ds.DNS.ExcludeSuffixes)
 			s.printf("    Include suffixes: %v\n", ds.DNS.IncludeSuffixes)
 			s.printf("    Timeout         : %v\n", ds.DNS.LookupTimeout)
+			if len(ds.DNS.Overrides) > 0 {
+				s.printf("    Overrides       :\n")
+				names := make([]string, 0, len(ds.DNS.Overrides))
+				for name := range ds.DNS.Overrides {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				for _, name := range names {
+					s.printf("      %s -> %s\n", name, ds.DNS.Overrides[name])
+				}
+			}
 			s.printf("  Also Proxy : (%d subnets)\n", len(ds.AlsoProxySubnets))
 			for _, subnet := range ds.AlsoProxySubnets {
 				s.printf("    - %s\n", subnet)
@@ -93,9 +93,29 @@ func (u *uninstallInfo) run(cmd *cobra.Command, args []string) error {
 	if err == nil && doQuit {
 		err = cliutil.Disconnect(cmd.Context(), true, true)
 	}
+	if err == nil {
+		res := &uninstallResult{Namespace: u.namespace}
+		switch {
+		case u.agent:
+			res.Agents = args
+		case u.allAgents:
+			res.AllAgents = true
+		default:
+			res.Everything = true
+		}
+		setResult(cmd.OutOrStdout(), res)
+	}
 	return err
 }
 
+// uninstallResult is the result of the uninstall command.
+type uninstallResult struct {
+	Agents     []string `json:"agents,omitempty"`
+	AllAgents  bool     `json:"all_agents,omitempty"`
+	Everything bool     `json:"everything,omitempty"`
+	Namespace  string   `json:"namespace,omitempty"`
+}
+
 func removeClusterFromUserCache(ctx context.Context, connInfo *connector.ConnectInfo) (err error) {
 	// Login token is affined to the traffic-manager that just got removed. The user-info
 	// in turn, is info obtained using that token so both are removed here as a
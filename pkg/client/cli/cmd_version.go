@@ -25,37 +25,64 @@ func versionCommand() *cobra.Command {
 	}
 }
 
+// versionResult is the result of the version command.
+type versionResult struct {
+	Client     string               `json:"client"`
+	RootDaemon *daemonVersionResult `json:"root_daemon"`
+	UserDaemon *daemonVersionResult `json:"user_daemon"`
+}
+
+type daemonVersionResult struct {
+	Running    bool   `json:"running"`
+	Version    string `json:"version,omitempty"`
+	APIVersion int32  `json:"api_version,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+func (v *daemonVersionResult) String() string {
+	switch {
+	case v.Error != "":
+		return "error: " + v.Error
+	case !v.Running:
+		return "not running"
+	default:
+		return fmt.Sprintf("%s (api v%d)", v.Version, v.APIVersion)
+	}
+}
+
 // printVersion requests version info from the daemon and prints both client and daemon version.
 func printVersion(cmd *cobra.Command, _ []string) error {
-	fmt.Fprintf(cmd.OutOrStdout(), "Client: %s\n",
-		client.DisplayVersion())
+	vr := versionResult{Client: client.DisplayVersion()}
 
 	var retErr error
 
 	version, err := daemonVersion(cmd.Context())
 	switch {
 	case err == nil:
-		fmt.Fprintf(cmd.OutOrStdout(), "Root Daemon: %s (api v%d)\n",
-			version.Version, version.ApiVersion)
+		vr.RootDaemon = &daemonVersionResult{Running: true, Version: version.Version, APIVersion: version.ApiVersion}
 	case err == cliutil.ErrNoNetwork:
-		fmt.Fprintf(cmd.OutOrStdout(), "Root Daemon: not running\n")
+		vr.RootDaemon = &daemonVersionResult{}
 	default:
-		fmt.Fprintf(cmd.OutOrStdout(), "Root Daemon: error: %v\n", err)
+		vr.RootDaemon = &daemonVersionResult{Error: err.Error()}
 		retErr = err
 	}
 
 	version, err = connectorVersion(cmd.Context())
 	switch {
 	case err == nil:
-		fmt.Fprintf(cmd.OutOrStdout(), "User Daemon: %s (api v%d)\n",
-			version.Version, version.ApiVersion)
+		vr.UserDaemon = &daemonVersionResult{Running: true, Version: version.Version, APIVersion: version.ApiVersion}
 	case err == cliutil.ErrNoUserDaemon:
-		fmt.Fprintf(cmd.OutOrStdout(), "User Daemon: not running\n")
+		vr.UserDaemon = &daemonVersionResult{}
 	default:
-		fmt.Fprintf(cmd.OutOrStdout(), "User Daemon: error: %v\n", err)
+		vr.UserDaemon = &daemonVersionResult{Error: err.Error()}
 		retErr = err
 	}
 
+	if out := cmd.OutOrStdout(); !setResult(out, &vr) {
+		fmt.Fprintf(out, "Client: %s\n", vr.Client)
+		fmt.Fprintf(out, "Root Daemon: %s\n", vr.RootDaemon)
+		fmt.Fprintf(out, "User Daemon: %s\n", vr.UserDaemon)
+	}
 	return retErr
 }
 
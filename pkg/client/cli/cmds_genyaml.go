@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/pflag"
 	"gopkg.in/yaml.v3"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -116,7 +117,7 @@ type genContainerInfo struct {
 	*genYAMLInfo
 	containerName string
 	serviceName   string
-	port          int
+	ports         []int
 	proto         string
 	agentPort     int
 	appProto      string
@@ -137,8 +138,9 @@ func genContainerSubCommand(yamlInfo *genYAMLInfo) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&info.containerName, "container-name", "",
 		"The name of the container hosting the application you wish to intercept.")
-	flags.IntVar(&info.port, "port", 0,
-		"The port number you wish to intercept")
+	flags.IntSliceVar(&info.ports, "port", nil,
+		`The port number you wish to intercept. Repeat the flag or use a comma separated list to intercept
+several ports. The agent will then listen on consecutive port numbers, starting with the --agent-port.`)
 	flags.StringVar(&info.proto, "protocol", string(corev1.ProtocolTCP),
 		`The transport protocol the port speaks, i.e. "tcp" or "udp"`)
 	flags.StringVar(&info.appProto, "app-protocol", "",
@@ -173,7 +175,9 @@ func (i *genContainerInfo) run(cmd *cobra.Command, kubeFlags map[string]string)
 	}
 
 	scheme := runtime.NewScheme()
-	scheme.AddKnownTypes(schema.GroupVersion{Group: appsv1.GroupName, Version: "v1"}, &appsv1.StatefulSet{}, &appsv1.Deployment{}, &appsv1.ReplicaSet{})
+	scheme.AddKnownTypes(schema.GroupVersion{Group: appsv1.GroupName, Version: "v1"},
+		&appsv1.StatefulSet{}, &appsv1.Deployment{}, &appsv1.ReplicaSet{}, &appsv1.DaemonSet{})
+	scheme.AddKnownTypes(schema.GroupVersion{Group: batchv1.GroupName, Version: "v1"}, &batchv1.Job{}, &batchv1.CronJob{})
 	codecFactory := serializer.NewCodecFactory(scheme)
 	deserializer := codecFactory.UniversalDeserializer()
 
@@ -183,7 +187,7 @@ func (i *genContainerInfo) run(cmd *cobra.Command, kubeFlags map[string]string)
 	}
 	wl, err := k8sapi.WrapWorkload(obj)
 	if err != nil {
-		return fmt.Errorf("unexpected object of kind %s; please pass in a Deployment, ReplicaSet, or StatefulSet", kind)
+		return fmt.Errorf("unexpected object of kind %s; please pass in a Deployment, ReplicaSet, StatefulSet, DaemonSet, Job, or CronJob", kind)
 	}
 	containers := wl.GetPodTemplate().Spec.Containers
 	containerIdx := -1
@@ -217,15 +221,26 @@ func (i *genContainerInfo) run(cmd *cobra.Command, kubeFlags map[string]string)
 	if agentImage == "" {
 		agentImage = "tel2:" + strings.TrimPrefix(version.Version, "v")
 	}
+	ports := make([]install.AgentPort, len(i.ports))
+	for pi, port := range i.ports {
+		ports[pi] = install.AgentPort{
+			Port: corev1.ContainerPort{
+				Protocol:      corev1.Protocol(i.proto),
+				ContainerPort: int32(i.agentPort + pi),
+			},
+			AppPort: port,
+		}
+	}
+	egress, err := install.EgressDestinations(wl.GetPodTemplate().Annotations)
+	if err != nil {
+		return err
+	}
 	agentContainer := install.AgentContainer(
 		i.serviceName,
 		fmt.Sprintf("%s/%s", registry, agentImage),
 		container,
-		corev1.ContainerPort{
-			Protocol:      corev1.Protocol(i.proto),
-			ContainerPort: int32(i.agentPort),
-		},
-		i.port,
+		ports,
+		egress,
 		i.appProto,
 		cfg.TelepresenceAPI.Port,
 		k8sConfig.GetManagerNamespace(),
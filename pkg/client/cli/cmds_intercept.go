@@ -8,15 +8,19 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/TinderBackend/telepresence/rpc/v2/connector"
@@ -27,18 +31,26 @@ import (
 	"github.com/TinderBackend/telepresence/v2/pkg/client/cli/extensions"
 	"github.com/TinderBackend/telepresence/v2/pkg/client/errcat"
 	"github.com/TinderBackend/telepresence/v2/pkg/client/scout"
+	"github.com/TinderBackend/telepresence/v2/pkg/install"
 	"github.com/TinderBackend/telepresence/v2/pkg/proc"
+	"github.com/TinderBackend/telepresence/v2/pkg/tunnel"
 	"github.com/datawire/dlib/dcontext"
 	"github.com/datawire/dlib/dexec"
 )
 
 type interceptArgs struct {
-	name        string // Args[0] || `${Args[0]}-${--namespace}` // which depends on a combinationof --workload and --namespace
-	agentName   string // --workload || Args[0] // only valid if !localOnly
-	namespace   string // --namespace
-	port        string // --port // only valid if !localOnly
-	serviceName string // --service // only valid if !localOnly
-	localOnly   bool   // --local-only
+	name        string   // Args[0] || `${Args[0]}-${--namespace}` // which depends on a combinationof --workload and --namespace
+	agentName   string   // --workload || Args[0] // only valid if !localOnly
+	namespace   string   // --namespace
+	ports       []string // --port // only valid if !localOnly
+	serviceName string   // --service // only valid if !localOnly
+	egress      string   // --egress // only valid if !localOnly
+	record      string   // --record // only valid if !localOnly
+	mirror      bool     // --mirror // only valid if !localOnly
+	fault       string   // --fault // only valid if !localOnly
+	localOnly   bool     // --local-only
+
+	ttl time.Duration // --ttl // only valid if !localOnly
 
 	previewEnabled bool                 // --preview-url // only valid if !localOnly
 	previewSpec    *manager.PreviewSpec // --preview-url-* // only valid if !localOnly
@@ -100,6 +112,9 @@ type interceptState struct {
 	localPort  uint16 // the parsed <local port>
 
 	dockerPort uint16
+
+	// "<local port>:<container port>" of all but the first --port when using --docker-run
+	additionalDockerPorts []string
 }
 
 func interceptCommand(ctx context.Context) *cobra.Command {
@@ -115,14 +130,38 @@ func interceptCommand(ctx context.Context) *cobra.Command {
 	flags := cmd.Flags()
 
 	flags.StringVarP(&args.agentName, "workload", "w", "", "Name of workload (Deployment, ReplicaSet) to intercept, if different from <name>")
-	flags.StringVarP(&args.port, "port", "p", strconv.Itoa(client.GetConfig(ctx).Intercept.DefaultPort), ``+
+	flags.StringSliceVarP(&args.ports, "port", "p", []string{strconv.Itoa(client.GetConfig(ctx).Intercept.DefaultPort)}, ``+
 		`Local port to forward to. If intercepting a service with multiple ports, `+
 		`use <local port>:<svcPortIdentifier>, where the identifier is the port name or port number. `+
-		`With --docker-run, use <local port>:<container port> or <local port>:<container port>:<svcPortIdentifier>.`,
+		`With --docker-run, use <local port>:<container port> or <local port>:<container port>:<svcPortIdentifier>. `+
+		`Repeat the flag to intercept several service ports at once; all but the first must then name a service port.`,
 	)
 
 	flags.StringVar(&args.serviceName, "service", "", "Name of service to intercept. If not provided, we will try to auto-detect one")
 
+	flags.StringVar(&args.egress, "egress", "", ``+
+		`Intercept the outbound connections that the workload makes to <host>:<port> instead of its inbound connections. `+
+		`The destination must be declared in the workload's "`+install.EgressAnnotation+`" pod annotation. `+
+		`A host name in the annotation is resolved once, when the pod starts, so connections are only intercepted `+
+		`while the host keeps the addresses that it had then. Use an IP address for a stable destination.`)
+
+	flags.StringVar(&args.record, "record", "", ``+
+		`Record the connections that the intercept delivers to the local process in the given file. `+
+		`The recording can be replayed using 'telepresence replay'.`)
+
+	flags.BoolVar(&args.mirror, "mirror", false, ``+
+		`Keep serving all traffic using the intercepted workload and send a copy of each intercepted request or `+
+		`connection to the local process. The responses of the local process are discarded.`)
+
+	flags.StringVar(&args.fault, "fault", "", ``+
+		`Inject faults into the connections that the intercept delivers to the local process, `+
+		`e.g. "latency=100ms,bandwidth=64Ki,reset=5,drop=10". The latency is added to each message, the bandwidth `+
+		`is in bytes per second, and reset and drop are percentages.`)
+
+	flags.DurationVar(&args.ttl, "ttl", 0, ``+
+		`Remove the intercept after the given time, e.g. "2h". The traffic-manager may enforce a shorter maximum. `+
+		`A warning is sent a few minutes before the intercept expires.`)
+
 	flags.BoolVarP(&args.localOnly, "local-only", "l", false, ``+
 		`Declare a local-only intercept for the purpose of getting direct outbound access to the intercept's namespace`)
 
@@ -198,6 +237,21 @@ func interceptCommand(ctx context.Context) *cobra.Command {
 			if cmd.Flag("preview-url").Changed && args.previewEnabled {
 				return errcat.User.New("a local-only intercept cannot be previewed")
 			}
+			if args.egress != "" {
+				return errcat.User.New("a local-only intercept cannot have an egress destination")
+			}
+			if args.record != "" {
+				return errcat.User.New("a local-only intercept cannot be recorded")
+			}
+			if args.mirror {
+				return errcat.User.New("a local-only intercept cannot mirror traffic")
+			}
+			if args.fault != "" {
+				return errcat.User.New("a local-only intercept cannot inject faults")
+			}
+			if args.ttl != 0 {
+				return errcat.User.New("a local-only intercept cannot have a time-to-live")
+			}
 		case false:
 			// Actually intercepting something
 			if args.agentName == "" {
@@ -206,8 +260,25 @@ func interceptCommand(ctx context.Context) *cobra.Command {
 					args.name += "-" + args.namespace
 				}
 			}
+			if args.egress != "" {
+				if cmd.Flag("preview-url").Changed && args.previewEnabled {
+					return errcat.User.New("an egress intercept cannot be previewed")
+				}
+				args.previewEnabled = false
+			}
+			if args.mirror {
+				if cmd.Flag("preview-url").Changed && args.previewEnabled {
+					return errcat.User.New("a mirror intercept cannot be previewed")
+				}
+				args.previewEnabled = false
+			}
 		}
 		args.mountSet = cmd.Flag("mount").Changed
+		if len(args.cmdline) > 0 || args.dockerRun {
+			if err := requireTextOutput(cmd, "when running a command"); err != nil {
+				return err
+			}
+		}
 		if args.dockerRun {
 			if err := validateDockerArgs(args.cmdline); err != nil {
 				return err
@@ -227,11 +298,21 @@ func leaveCommand() *cobra.Command {
 
 		Short: "Remove existing intercept",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return removeIntercept(cmd.Context(), strings.TrimSpace(args[0]))
+			name := strings.TrimSpace(args[0])
+			if err := removeIntercept(cmd.Context(), name); err != nil {
+				return err
+			}
+			setResult(cmd.OutOrStdout(), &leaveResult{Name: name})
+			return nil
 		},
 	}
 }
 
+// leaveResult is the result of the leave command.
+type leaveResult struct {
+	Name string `json:"name"`
+}
+
 func intercept(cmd *cobra.Command, args interceptArgs) error {
 	if len(args.cmdline) == 0 && !args.dockerRun {
 		// start and retain the intercept
@@ -386,8 +467,7 @@ func (is *interceptState) createRequest(ctx context.Context) (*connector.CreateI
 	spec.Agent = is.args.agentName
 	spec.TargetHost = "127.0.0.1"
 
-	// Parse port into spec based on how it's formatted
-	portMapping := strings.Split(is.args.port, ":")
+	// Parse ports into spec based on how they're formatted
 	portError := func() error {
 		if is.args.dockerRun {
 			return errcat.User.New("ports must be of the format --ports <local-port>:<container-port>[:<svcPortIdentifier>]")
@@ -398,41 +478,95 @@ func (is *interceptState) createRequest(ctx context.Context) (*connector.CreateI
 	parsePort := func(portStr string) (uint16, error) {
 		port, err := strconv.ParseUint(portStr, 10, 16)
 		if err != nil {
-			return 0, errcat.User.Newf("port numbers must be a valid, positive int, you gave: %q", is.args.port)
+			return 0, errcat.User.Newf("port numbers must be a valid, positive int, you gave: %q", portStr)
 		}
 		return uint16(port), nil
 	}
 
-	port, err := parsePort(portMapping[0])
-	if err != nil {
-		return nil, err
+	// parsePortMapping parses <local port>[:<container port>][:<svcPortIdentifier>]
+	parsePortMapping := func(mapping string) (localPort, dockerPort uint16, svcPortIdentifier string, err error) {
+		portMapping := strings.Split(mapping, ":")
+		if localPort, err = parsePort(portMapping[0]); err != nil {
+			return 0, 0, "", err
+		}
+		switch len(portMapping) {
+		case 1:
+		case 2:
+			if port, err := parsePort(portMapping[1]); err == nil && is.args.dockerRun {
+				dockerPort = port
+			} else {
+				svcPortIdentifier = portMapping[1]
+			}
+		case 3:
+			if !is.args.dockerRun {
+				return 0, 0, "", portError()
+			}
+			if dockerPort, err = parsePort(portMapping[1]); err != nil {
+				return 0, 0, "", err
+			}
+			svcPortIdentifier = portMapping[2]
+		default:
+			return 0, 0, "", portError()
+		}
+		if is.args.dockerRun && dockerPort == 0 {
+			dockerPort = localPort
+		}
+		return localPort, dockerPort, svcPortIdentifier, nil
 	}
-	is.localPort = port
-	spec.TargetPort = int32(port)
 
-	switch len(portMapping) {
-	case 1:
-	case 2:
-		if port, err = parsePort(portMapping[1]); err == nil && is.args.dockerRun {
-			is.dockerPort = port
-		} else {
-			spec.ServicePortIdentifier = portMapping[1]
+	var err error
+	for i, mapping := range is.args.ports {
+		localPort, dockerPort, svcPortIdentifier, err := parsePortMapping(mapping)
+		if err != nil {
+			return nil, err
 		}
-	case 3:
-		if !is.args.dockerRun {
-			return nil, portError()
+		if i == 0 {
+			is.localPort = localPort
+			is.dockerPort = dockerPort
+			spec.TargetPort = int32(localPort)
+			spec.ServicePortIdentifier = svcPortIdentifier
+			continue
 		}
-		if port, err = parsePort(portMapping[1]); err != nil {
-			return nil, err
+		if svcPortIdentifier == "" {
+			return nil, errcat.User.Newf("the additional port %q must name the service port that it intercepts", mapping)
 		}
-		is.dockerPort = port
-		spec.ServicePortIdentifier = portMapping[2]
-	default:
-		return nil, portError()
+		if dockerPort != 0 {
+			is.additionalDockerPorts = append(is.additionalDockerPorts, fmt.Sprintf("%d:%d", localPort, dockerPort))
+		}
+		spec.ServicePorts = append(spec.ServicePorts, &manager.InterceptPort{
+			ServicePortIdentifier: svcPortIdentifier,
+			TargetPort:            int32(localPort),
+		})
 	}
-
-	if is.args.dockerRun && is.dockerPort == 0 {
-		is.dockerPort = is.localPort
+	if len(spec.ServicePorts) > 0 && spec.ServicePortIdentifier == "" {
+		return nil, errcat.User.New("when intercepting several ports, all ports must name the service port that they intercept")
+	}
+	if is.args.egress != "" {
+		if len(spec.ServicePorts) > 0 {
+			return nil, errcat.User.New("an egress intercept cannot intercept several ports")
+		}
+		if _, _, err := net.SplitHostPort(is.args.egress); err != nil {
+			return nil, errcat.User.Newf("the egress destination must be of the format <host>:<port>, you gave: %q", is.args.egress)
+		}
+		spec.EgressDestination = is.args.egress
+	}
+	spec.Mirror = is.args.mirror
+	if is.args.fault != "" {
+		if spec.Faults, err = tunnel.ParseFaultSpec(is.args.fault); err != nil {
+			return nil, errcat.User.Newf("invalid --fault: %w", err)
+		}
+	}
+	if is.args.ttl < 0 {
+		return nil, errcat.User.Newf("the --ttl must not be negative, you gave: %s", is.args.ttl)
+	}
+	if is.args.ttl > 0 {
+		ir.Ttl = durationpb.New(is.args.ttl)
+	}
+	if is.args.record != "" {
+		// The recording is made by the user daemon, which doesn't share our working directory
+		if ir.RecordFile, err = filepath.Abs(is.args.record); err != nil {
+			return nil, err
+		}
 	}
 
 	doMount := false
@@ -636,6 +770,7 @@ func (is *interceptState) EnsureState(ctx context.Context) (acquired bool, err e
 
 	if args.agentName == "" {
 		// local-only
+		setResult(is.cmd.OutOrStdout(), &interceptResult{Name: args.name, LocalOnly: true})
 		return true, nil
 	}
 	fmt.Fprintf(is.cmd.OutOrStdout(), "Using %s %s\n", r.WorkloadKind, args.agentName)
@@ -701,7 +836,9 @@ func (is *interceptState) EnsureState(ctx context.Context) (acquired bool, err e
 	if doMount || err != nil {
 		volumeMountProblem = checkMountCapability(ctx)
 	}
-	fmt.Fprintln(is.cmd.OutOrStdout(), DescribeIntercept(intercept, volumeMountProblem, false))
+	if out := is.cmd.OutOrStdout(); !setResult(out, newInterceptResult(intercept, volumeMountProblem)) {
+		fmt.Fprintln(out, DescribeIntercept(intercept, volumeMountProblem, false))
+	}
 	return true, nil
 }
 
@@ -768,6 +905,9 @@ func (is *interceptState) runInDocker(ctx context.Context, cmd safeCobraCommand,
 	if is.dockerPort != 0 {
 		ourArgs = append(ourArgs, "-p", fmt.Sprintf("%d:%d", is.localPort, is.dockerPort))
 	}
+	for _, dp := range is.additionalDockerPorts {
+		ourArgs = append(ourArgs, "-p", dp)
+	}
 
 	dockerMount := ""
 	if is.mountPoint != "" { // do we have a mount point at all?
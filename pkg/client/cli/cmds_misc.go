@@ -3,19 +3,26 @@ package cli
 import (
 	"context"
 	"fmt"
+	"net"
 	"runtime"
+	"strings"
 
 	"github.com/pkg/browser"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/TinderBackend/telepresence/rpc/v2/connector"
+	"github.com/TinderBackend/telepresence/rpc/v2/manager"
 	"github.com/TinderBackend/telepresence/v2/pkg/client"
 	"github.com/TinderBackend/telepresence/v2/pkg/client/cli/cliutil"
+	"github.com/TinderBackend/telepresence/v2/pkg/client/errcat"
+	"github.com/TinderBackend/telepresence/v2/pkg/iputil"
 	"github.com/TinderBackend/telepresence/v2/pkg/k8sapi"
 	"github.com/TinderBackend/telepresence/v2/pkg/proc"
+	"github.com/TinderBackend/telepresence/v2/pkg/tunnel"
 )
 
 // ClusterIdCommand is a simple command that makes it easier for users to
@@ -55,6 +62,10 @@ func ClusterIdCommand() *cobra.Command {
 func connectCommand() *cobra.Command {
 	var dnsIP string
 	var mappedNamespaces []string
+	var outboundFaults []string
+	var egressFrom string
+	var egressRules []string
+	var dnsMap map[string]string
 
 	kubeFlags := pflag.NewFlagSet("Kubernetes flags", 0)
 	cmd := &cobra.Command{
@@ -66,14 +77,43 @@ func connectCommand() *cobra.Command {
 				KubeFlags:        kubeFlagMap(kubeFlags),
 				MappedNamespaces: mappedNamespaces,
 			}
+			for _, of := range outboundFaults {
+				f, err := parseOutboundFault(of)
+				if err != nil {
+					return err
+				}
+				request.OutboundFaults = append(request.OutboundFaults, f)
+			}
+			for _, er := range egressRules {
+				r, err := parseEgressRule(er)
+				if err != nil {
+					return err
+				}
+				request.EgressRules = append(request.EgressRules, r)
+			}
+			if egressFrom != "" {
+				// Applies to all destinations, so it must come after the other rules
+				workload, namespace := splitWorkloadName(egressFrom)
+				request.EgressRules = append(request.EgressRules, &manager.EgressRule{Workload: workload, Namespace: namespace})
+			}
+			for name, target := range dnsMap {
+				if err := client.ValidateDNSOverride(name, target); err != nil {
+					return errcat.User.Newf("invalid --dns-map: %w", err)
+				}
+			}
+			request.DnsOverrides = dnsMap
 
 			if len(args) == 0 {
-				return withConnector(cmd, true, request, func(_ context.Context, _ *connectorState) error {
+				return withConnector(cmd, true, request, func(_ context.Context, cs *connectorState) error {
+					setResult(cmd.OutOrStdout(), newConnectResult(cs.ConnectInfo))
 					return nil
 				})
 			}
 
-			return withConnector(cmd, false, request, func(ctx context.Context, _ *connectorState) error {
+			if err := requireTextOutput(cmd, "when running a command"); err != nil {
+				return err
+			}
+			return withConnector(cmd, false, request, func(ctx context.Context, cs *connectorState) error {
 				return proc.Run(ctx, nil, args[0], args[1:]...)
 			})
 		},
@@ -94,6 +134,27 @@ func connectCommand() *cobra.Command {
 		"mapped-namespaces", nil, ``+
 			`Comma separated list of namespaces considered by DNS resolver and NAT for outbound connections. `+
 			`Defaults to all namespaces`)
+	nwFlags.StringArrayVar(&outboundFaults,
+		"outbound-fault", nil, ``+
+			`Inject faults into outbound connections to a subnet, e.g. "10.96.0.0/12,latency=100ms,drop=10". `+
+			`The subnet is followed by faults in the format of the intercept command's --fault flag. `+
+			`Repeat the flag to inject faults into several subnets`)
+	nwFlags.StringVar(&egressFrom,
+		"egress-from", "", ``+
+			`Originate outbound connections to the cluster from the pod of a workload, given as <workload>[.<namespace>], `+
+			`so that they leave with that pod's network identity. The workload must have a traffic-agent. `+
+			`Connections are routed as usual when no traffic-agent of the workload is available`)
+	nwFlags.StringArrayVar(&egressRules,
+		"egress-rule", nil, ``+
+			`Like --egress-from, but only for some destinations, e.g. "10.0.0.0/8,payments=gateway.egress". The `+
+			`workload is preceded by a comma separated list of subnets and namespaces of destination pods and services. `+
+			`Repeat the flag to add several rules. The first matching rule is used, and --egress-from applies to `+
+			`destinations that no rule matches`)
+	nwFlags.StringToStringVar(&dnsMap,
+		"dns-map", nil, ``+
+			`Answer DNS queries for a name with a local IP, or with the records of another name in the cluster, `+
+			`e.g. "payments.default=127.0.0.1". Overrides the dns.overrides of the config. Repeat the flag or `+
+			`use a comma separated list to map several names`)
 	flags.AddFlagSet(nwFlags)
 
 	kubeConfig := genericclioptions.NewConfigFlags(false)
@@ -103,6 +164,75 @@ func connectCommand() *cobra.Command {
 	return cmd
 }
 
+// connectResult is the result of the connect command.
+type connectResult struct {
+	ClusterContext string `json:"cluster_context,omitempty"`
+	ClusterServer  string `json:"cluster_server,omitempty"`
+	ClusterID      string `json:"cluster_id,omitempty"`
+	SessionID      string `json:"session_id,omitempty"`
+}
+
+func newConnectResult(ci *connector.ConnectInfo) *connectResult {
+	return &connectResult{
+		ClusterContext: ci.ClusterContext,
+		ClusterServer:  ci.ClusterServer,
+		ClusterID:      ci.ClusterId,
+		SessionID:      ci.GetSessionInfo().GetSessionId(),
+	}
+}
+
+// parseOutboundFault parses the argument of the --outbound-fault flag, i.e. "<CIDR>,<faults>"
+func parseOutboundFault(s string) (*manager.OutboundFault, error) {
+	cidr, faults := s, ""
+	if comma := strings.IndexByte(s, ','); comma >= 0 {
+		cidr, faults = s[:comma], s[comma+1:]
+	}
+	_, subnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, errcat.User.Newf("invalid --outbound-fault %q: %w", s, err)
+	}
+	spec, err := tunnel.ParseFaultSpec(faults)
+	if err != nil {
+		return nil, errcat.User.Newf("invalid --outbound-fault %q: %w", s, err)
+	}
+	return &manager.OutboundFault{Subnet: iputil.IPNetToRPC(subnet), Faults: spec}, nil
+}
+
+// parseEgressRule parses the argument of the --egress-rule flag, i.e. "<CIDR or namespace>[,...]=<workload>[.<namespace>]"
+func parseEgressRule(s string) (*manager.EgressRule, error) {
+	eq := strings.LastIndexByte(s, '=')
+	if eq <= 0 || eq == len(s)-1 {
+		return nil, errcat.User.Newf("invalid --egress-rule %q: must be <CIDR or namespace>[,...]=<workload>[.<namespace>]", s)
+	}
+	workload, namespace := splitWorkloadName(s[eq+1:])
+	r := &manager.EgressRule{Workload: workload, Namespace: namespace}
+	for _, sel := range strings.Split(s[:eq], ",") {
+		if strings.ContainsRune(sel, '/') {
+			_, subnet, err := net.ParseCIDR(sel)
+			if err != nil {
+				return nil, errcat.User.Newf("invalid --egress-rule %q: %w", s, err)
+			}
+			r.Subnets = append(r.Subnets, iputil.IPNetToRPC(subnet))
+		} else {
+			if errs := validation.IsDNS1123Label(sel); len(errs) > 0 {
+				return nil, errcat.User.Newf("invalid --egress-rule %q: namespace %q: %s", s, sel, strings.Join(errs, ", "))
+			}
+			r.Namespaces = append(r.Namespaces, sel)
+		}
+	}
+	return r, nil
+}
+
+// splitWorkloadName splits "<workload>[.<namespace>]" into its workload and namespace. The namespace is
+// empty when it's omitted. A namespace never contains dots, but a workload name may, so the split is made at
+// the last dot.
+func splitWorkloadName(s string) (string, string) {
+	if dot := strings.LastIndexByte(s, '.'); dot >= 0 {
+		return s[:dot], s[dot+1:]
+	}
+	return s, ""
+}
+
 func dashboardCommand() *cobra.Command {
 	return &cobra.Command{
 		Use:  "dashboard",
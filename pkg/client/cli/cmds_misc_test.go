@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TinderBackend/telepresence/v2/pkg/client/errcat"
+)
+
+func TestSplitWorkloadName(t *testing.T) {
+	for s, want := range map[string][2]string{
+		"hello":                {"hello", ""},
+		"hello.default":        {"hello", "default"},
+		"hello.v1.default":     {"hello.v1", "default"},
+		"api.example.com.prod": {"api.example.com", "prod"},
+	} {
+		workload, namespace := splitWorkloadName(s)
+		assert.Equal(t, want, [2]string{workload, namespace}, s)
+	}
+}
+
+func TestParseEgressRule(t *testing.T) {
+	r, err := parseEgressRule("10.0.0.0/8,payments=egress.v2.infra")
+	require.NoError(t, err)
+	assert.Equal(t, "egress.v2", r.Workload)
+	assert.Equal(t, "infra", r.Namespace)
+	assert.Equal(t, []string{"payments"}, r.Namespaces)
+	require.Len(t, r.Subnets, 1)
+
+	for _, bad := range []string{"egress", "=egress", "10.0.0.0/8=", "10.0.0.0/33=egress", "Bad_NS=egress"} {
+		_, err = parseEgressRule(bad)
+		require.Error(t, err, bad)
+		assert.Equal(t, errcat.User, errcat.GetCategory(err), bad)
+	}
+}
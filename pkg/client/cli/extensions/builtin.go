@@ -26,6 +26,37 @@ func builtinExtensions(ctx context.Context) map[string]ExtensionInfo {
 			Image: image,
 			Mechanisms: map[string]MechanismInfo{
 				"tcp": {},
+				// The "route" mechanism is served by the open source traffic-agent. It intercepts the
+				// HTTP requests that match its flags and sends all other requests to the application.
+				"route": {
+					Flags: map[string]FlagInfo{
+						"header": {
+							Type: "stringArray",
+							Usage: `` +
+								`Only intercept traffic that matches this "HTTP2_HEADER=REGEXP" specifier. ` +
+								`Instead of a "--route-header=HTTP2_HEADER=REGEXP" pair, you may say "--route-header=auto", which will only intercept requests that carry the ` +
+								`"x-telepresence-intercept-id" header of the intercept. ` +
+								`If this flag is given multiple times, then it will only intercept traffic that matches *all* of the specifiers`,
+						},
+						"path-equal": {
+							Type:  "string",
+							Usage: `Only intercept traffic with paths that are exactly equal to this path once the query string is removed`,
+						},
+						"path-prefix": {
+							Type:  "string",
+							Usage: `Only intercept traffic with paths beginning with this prefix`,
+						},
+						"path-regex": {
+							Type:  "string",
+							Usage: `Only intercept traffic with paths that are entirely matched by this regular expression once the query string is removed`,
+						},
+						"meta": {
+							Type: "stringArray",
+							Usage: `` +
+								`Associates key=value pairs with the intercept that can later be retrieved using the Telepresence API service`,
+						},
+					},
+				},
 			},
 		},
 		// FIXME(lukeshu): We shouldn't compile in the info about the Ambassador Smart Agent
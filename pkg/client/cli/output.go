@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/TinderBackend/telepresence/v2/pkg/client/errcat"
+)
+
+// The formats of the global --output flag. The default is text.
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputYAML = "yaml"
+)
+
+// commandOutput is what a command prints when the global --output flag is json or yaml.
+type commandOutput struct {
+	Cmd    string        `json:"cmd"`
+	Stdout string        `json:"stdout,omitempty"`
+	Result interface{}   `json:"result,omitempty"`
+	Error  *commandError `json:"error,omitempty"`
+}
+
+type commandError struct {
+	Message  string `json:"message"`
+	Category string `json:"category"`
+}
+
+// structuredOutput is the writer that a command is given as its stdout when the global --output flag
+// is json or yaml. It captures what the command prints, and the result that the command sets using
+// setResult.
+type structuredOutput struct {
+	bytes.Buffer
+	result interface{}
+}
+
+// setResult sets the typed result of a command when its output is json or yaml. The given writer must
+// be the command's OutOrStdout(). It returns false if the output is text, in which case the command
+// must print the result itself.
+func setResult(out io.Writer, result interface{}) bool {
+	if so, ok := out.(*structuredOutput); ok {
+		so.result = result
+		return true
+	}
+	return false
+}
+
+// reportedError is an error that has been printed as part of the json or yaml output of a command.
+type reportedError struct {
+	error
+}
+
+func (e *reportedError) Unwrap() error {
+	return e.error
+}
+
+// ErrorReported returns true if the given error has been printed as part of the json or yaml output
+// of a command, and hence shouldn't be printed again.
+func ErrorReported(err error) bool {
+	var re *reportedError
+	return errors.As(err, &re)
+}
+
+func outputFormat(cmd *cobra.Command) (string, error) {
+	flag := cmd.Flags().Lookup("output")
+	if flag == nil || flag != cmd.Root().PersistentFlags().Lookup("output") {
+		// The command doesn't have the global flag, or has an --output flag of its own (e.g. genyaml)
+		return outputText, nil
+	}
+	switch format := flag.Value.String(); format {
+	case outputText, outputJSON, outputYAML:
+		return format, nil
+	default:
+		return "", errcat.User.Newf("invalid --output %q: must be one of %s, %s, or %s", format, outputText, outputJSON, outputYAML)
+	}
+}
+
+// requireTextOutput returns a user error when the global --output flag is json or yaml. It's used by
+// commands that, in the given mode, print output that can't be captured in a single document, e.g.
+// because they run a subprocess that writes directly to the terminal.
+func requireTextOutput(cmd *cobra.Command, mode string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if format != outputText {
+		return errcat.User.Newf("--output %s cannot be used %s", format, mode)
+	}
+	return nil
+}
+
+// ReportError prints the given error as the json or yaml output of the command when the global --output
+// flag says so, and returns an error for which ErrorReported is true. The error is returned unchanged
+// when the output is text. It's intended for errors that are raised before the command runs, such as
+// flag errors.
+func ReportError(cmd *cobra.Command, err error) error {
+	if err == nil || ErrorReported(err) {
+		return err
+	}
+	format, fmtErr := outputFormat(cmd)
+	if fmtErr != nil || format == outputText {
+		return err
+	}
+	if printErr := printOutput(cmd, cmd.OutOrStdout(), format, &commandOutput{}, err); printErr != nil {
+		return printErr
+	}
+	return &reportedError{err}
+}
+
+// printOutput prints the given output, along with the error of the command if it isn't nil, in the
+// given format.
+func printOutput(cmd *cobra.Command, out io.Writer, format string, co *commandOutput, cmdErr error) error {
+	co.Cmd = strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	if cmdErr != nil {
+		co.Error = &commandError{Message: cmdErr.Error(), Category: errcat.GetCategory(cmdErr).String()}
+	}
+	var data []byte
+	var err error
+	if format == outputJSON {
+		if data, err = json.Marshal(co); err == nil {
+			data = append(data, '\n')
+		}
+	} else {
+		data, err = yaml.Marshal(co)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	return err
+}
+
+// withOutputFormat wraps the RunE of a command so that the command's output, its result, and its error,
+// are printed in the format given by the global --output flag.
+func withOutputFormat(runE func(*cobra.Command, []string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+		if format == outputText {
+			return runE(cmd, args)
+		}
+
+		stdout := cmd.OutOrStdout()
+		so := &structuredOutput{}
+		cmd.SetOut(so)
+		runErr := runE(cmd, args)
+		cmd.SetOut(stdout)
+
+		if err = printOutput(cmd, stdout, format, &commandOutput{Stdout: so.String(), Result: so.result}, runErr); err != nil {
+			return err
+		}
+		if runErr != nil {
+			return &reportedError{runErr}
+		}
+		return nil
+	}
+}
+
+// withReportedErrors wraps a function that a command calls before it runs, such as its args validator,
+// so that a returned error is printed in the format given by the global --output flag.
+func withReportedErrors(f func(*cobra.Command, []string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		return ReportError(cmd, f(cmd, args))
+	}
+}
@@ -0,0 +1,386 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+	"sigs.k8s.io/yaml"
+
+	"github.com/TinderBackend/telepresence/rpc/v2/common"
+	"github.com/TinderBackend/telepresence/rpc/v2/connector"
+	"github.com/TinderBackend/telepresence/rpc/v2/daemon"
+	"github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/pkg/client"
+	"github.com/TinderBackend/telepresence/v2/pkg/client/cli/cliutil"
+	"github.com/TinderBackend/telepresence/v2/pkg/client/errcat"
+	"github.com/TinderBackend/telepresence/v2/pkg/filelocation"
+	"github.com/TinderBackend/telepresence/v2/pkg/version"
+	"github.com/datawire/dlib/dlog"
+)
+
+// outputTestCommand returns a root command with the global --output flag, and a "test" subcommand
+// that runs the given function.
+func outputTestCommand(runE func(*cobra.Command, []string) error) (*cobra.Command, *bytes.Buffer) {
+	return outputRootCommand(&cobra.Command{Use: "test", RunE: runE})
+}
+
+// outputRootCommand returns a root command with the global --output flag, and the given subcommands,
+// set up the same way as the subcommands of the telepresence root command.
+func outputRootCommand(cmds ...*cobra.Command) (*cobra.Command, *bytes.Buffer) {
+	root := &cobra.Command{Use: "telepresence", SilenceErrors: true, SilenceUsage: true}
+	root.PersistentFlags().String("output", outputText, "")
+	for _, cmd := range cmds {
+		if ac := cmd.Args; ac != nil {
+			cmd.Args = argsCheck(ac)
+		}
+		root.AddCommand(cmd)
+	}
+	initOutputFormat(root)
+	out := &bytes.Buffer{}
+	root.SetOut(out)
+	root.SetErr(&bytes.Buffer{})
+	return root, out
+}
+
+func TestOutputFormat(t *testing.T) {
+	type result struct {
+		Name string `json:"name"`
+	}
+	runE := func(cmd *cobra.Command, _ []string) error {
+		out := cmd.OutOrStdout()
+		fmt.Fprintln(out, "some output")
+		if !setResult(out, &result{Name: "hello"}) {
+			fmt.Fprintln(out, "hello")
+		}
+		return nil
+	}
+
+	t.Run("text", func(t *testing.T) {
+		root, out := outputTestCommand(runE)
+		root.SetArgs([]string{"test"})
+		require.NoError(t, root.Execute())
+		assert.Equal(t, "some output\nhello\n", out.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		root, out := outputTestCommand(runE)
+		root.SetArgs([]string{"test", "--output", "json"})
+		require.NoError(t, root.Execute())
+		assert.Equal(t, `{"cmd":"test","stdout":"some output\n","result":{"name":"hello"}}`+"\n", out.String())
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		root, out := outputTestCommand(runE)
+		root.SetArgs([]string{"test", "--output", "yaml"})
+		require.NoError(t, root.Execute())
+		var co map[string]interface{}
+		require.NoError(t, yaml.Unmarshal(out.Bytes(), &co))
+		assert.Equal(t, "test", co["cmd"])
+		assert.Equal(t, map[string]interface{}{"name": "hello"}, co["result"])
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		root, out := outputTestCommand(runE)
+		root.SetArgs([]string{"test", "--output", "xml"})
+		err := root.Execute()
+		require.Error(t, err)
+		assert.Equal(t, errcat.User, errcat.GetCategory(err))
+		assert.Empty(t, out.String())
+	})
+}
+
+func TestOutputFormatError(t *testing.T) {
+	runE := func(cmd *cobra.Command, _ []string) error {
+		return errcat.User.New("not connected")
+	}
+
+	root, out := outputTestCommand(runE)
+	root.SetArgs([]string{"test", "--output", "json"})
+	err := root.Execute()
+	require.Error(t, err)
+	assert.True(t, ErrorReported(err))
+	assert.Equal(t, errcat.User, errcat.GetCategory(err))
+
+	var co commandOutput
+	require.NoError(t, json.Unmarshal(out.Bytes(), &co))
+	require.NotNil(t, co.Error)
+	assert.Equal(t, "not connected", co.Error.Message)
+	assert.Equal(t, "user", co.Error.Category)
+	assert.Nil(t, co.Result)
+
+	// Errors aren't reported when the output is text
+	root, _ = outputTestCommand(runE)
+	root.SetArgs([]string{"test"})
+	err = root.Execute()
+	require.Error(t, err)
+	assert.False(t, ErrorReported(err))
+	assert.False(t, ErrorReported(errors.New("boom")))
+}
+
+// fakeDaemon is a root daemon that only answers Version and SetLogLevel.
+type fakeDaemon struct {
+	daemon.UnimplementedDaemonServer
+	versionErr error
+	logLevel   *manager.LogLevelRequest
+}
+
+func (d *fakeDaemon) Version(context.Context, *empty.Empty) (*common.VersionInfo, error) {
+	if d.versionErr != nil {
+		return nil, d.versionErr
+	}
+	return &common.VersionInfo{ApiVersion: 3, Version: version.Version}, nil
+}
+
+func (d *fakeDaemon) SetLogLevel(_ context.Context, rq *manager.LogLevelRequest) (*empty.Empty, error) {
+	d.logLevel = rq
+	return &empty.Empty{}, nil
+}
+
+// fakeConnector is a user daemon that is connected to a cluster, and that only answers Version,
+// Status, Uninstall, and SetLogLevel.
+type fakeConnector struct {
+	connector.UnimplementedConnectorServer
+	uninstall *connector.UninstallRequest
+	logLevel  *manager.LogLevelRequest
+}
+
+func (c *fakeConnector) Version(context.Context, *empty.Empty) (*common.VersionInfo, error) {
+	return &common.VersionInfo{ApiVersion: 3, Version: version.Version}, nil
+}
+
+func (c *fakeConnector) Status(context.Context, *empty.Empty) (*connector.ConnectInfo, error) {
+	return &connector.ConnectInfo{Error: connector.ConnectInfo_ALREADY_CONNECTED}, nil
+}
+
+func (c *fakeConnector) Uninstall(_ context.Context, rq *connector.UninstallRequest) (*connector.UninstallResult, error) {
+	c.uninstall = rq
+	return &connector.UninstallResult{}, nil
+}
+
+func (c *fakeConnector) SetLogLevel(_ context.Context, rq *manager.LogLevelRequest) (*empty.Empty, error) {
+	c.logLevel = rq
+	return &empty.Empty{}, nil
+}
+
+// fakeManager is a traffic-manager that only answers SetLogLevel. It's reached through the connection
+// to the user daemon.
+type fakeManager struct {
+	manager.UnimplementedManagerServer
+	logLevel *manager.LogLevelRequest
+}
+
+func (m *fakeManager) SetLogLevel(_ context.Context, rq *manager.LogLevelRequest) (*empty.Empty, error) {
+	m.logLevel = rq
+	return &empty.Empty{}, nil
+}
+
+// serveFake serves the gRPC services that the given function registers, and returns a connection to them.
+func serveFake(ctx context.Context, t *testing.T, register func(*grpc.Server)) *grpc.ClientConn {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	register(s)
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// outputTestContext returns a context for running the real commands. The update check of the version
+// command fails without delay, and the daemons are the given fakes unless they are nil.
+func outputTestContext(t *testing.T, d *fakeDaemon, c *fakeConnector, m *fakeManager) context.Context {
+	ctx := dlog.NewTestContext(t, false)
+	ctx = filelocation.WithUserHomeDir(ctx, t.TempDir())
+	cfg := client.GetDefaultConfig()
+	cfg.Cloud.SystemaHost = "127.0.0.1:1"
+	ctx = client.WithConfig(ctx, &cfg)
+	if d != nil {
+		ctx = cliutil.WithDaemonConn(ctx, serveFake(ctx, t, func(s *grpc.Server) {
+			daemon.RegisterDaemonServer(s, d)
+		}))
+	}
+	if c != nil {
+		ctx = cliutil.WithConnectorConn(ctx, serveFake(ctx, t, func(s *grpc.Server) {
+			connector.RegisterConnectorServer(s, c)
+			if m != nil {
+				manager.RegisterManagerServer(s, m)
+			}
+		}))
+	}
+	return ctx
+}
+
+// executeJSON executes the given command line with "--output json" after the command name, and decodes
+// its json output.
+func executeJSON(ctx context.Context, t *testing.T, root *cobra.Command, args ...string) (map[string]interface{}, error) {
+	root.SetArgs(append([]string{args[0], "--output", "json"}, args[1:]...))
+	err := root.ExecuteContext(ctx)
+	out := root.OutOrStdout().(*bytes.Buffer)
+	var co map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &co), "output is not a single json document: %s", out)
+	return co, err
+}
+
+func TestOutputVersion(t *testing.T) {
+	running := map[string]interface{}{"running": true, "version": version.Version, "api_version": 3.0}
+
+	t.Run("running", func(t *testing.T) {
+		ctx := outputTestContext(t, &fakeDaemon{}, &fakeConnector{}, nil)
+		root, _ := outputRootCommand(versionCommand())
+		co, err := executeJSON(ctx, t, root, "version")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"cmd": "version",
+			"result": map[string]interface{}{
+				"client":      client.DisplayVersion(),
+				"root_daemon": running,
+				"user_daemon": running,
+			},
+		}, co)
+	})
+
+	t.Run("not running", func(t *testing.T) {
+		for _, socket := range []string{client.DaemonSocketName, client.ConnectorSocketName} {
+			if _, err := os.Stat(socket); err == nil {
+				t.Skipf("%s exists", socket)
+			}
+		}
+		ctx := outputTestContext(t, nil, nil, nil)
+		root, _ := outputRootCommand(versionCommand())
+		co, err := executeJSON(ctx, t, root, "version")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"cmd": "version",
+			"result": map[string]interface{}{
+				"client":      client.DisplayVersion(),
+				"root_daemon": map[string]interface{}{"running": false},
+				"user_daemon": map[string]interface{}{"running": false},
+			},
+		}, co)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ctx := outputTestContext(t, &fakeDaemon{versionErr: errors.New("boom")}, &fakeConnector{}, nil)
+		root, _ := outputRootCommand(versionCommand())
+		co, err := executeJSON(ctx, t, root, "version")
+		require.Error(t, err)
+		assert.True(t, ErrorReported(err))
+		result := co["result"].(map[string]interface{})
+		assert.Contains(t, result["root_daemon"].(map[string]interface{})["error"], "boom")
+		assert.Equal(t, false, result["root_daemon"].(map[string]interface{})["running"])
+		assert.Equal(t, running, result["user_daemon"])
+		e := co["error"].(map[string]interface{})
+		assert.Contains(t, e["message"], "boom")
+		assert.Equal(t, "unknown", e["category"])
+	})
+}
+
+func TestOutputUninstall(t *testing.T) {
+	t.Run("agents", func(t *testing.T) {
+		c := &fakeConnector{}
+		ctx := outputTestContext(t, &fakeDaemon{}, c, nil)
+		root, _ := outputRootCommand(uninstallCommand())
+		co, err := executeJSON(ctx, t, root, "uninstall", "--agent", "echo", "hello", "--namespace", "ns")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"agents": []interface{}{"echo", "hello"}, "namespace": "ns"}, co["result"])
+		require.NotNil(t, c.uninstall)
+		assert.Equal(t, connector.UninstallRequest_NAMED_AGENTS, c.uninstall.UninstallType)
+		assert.Equal(t, []string{"echo", "hello"}, c.uninstall.Agents)
+	})
+
+	t.Run("all agents", func(t *testing.T) {
+		c := &fakeConnector{}
+		ctx := outputTestContext(t, &fakeDaemon{}, c, nil)
+		root, _ := outputRootCommand(uninstallCommand())
+		co, err := executeJSON(ctx, t, root, "uninstall", "--all-agents")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"all_agents": true}, co["result"])
+		require.NotNil(t, c.uninstall)
+		assert.Equal(t, connector.UninstallRequest_ALL_AGENTS, c.uninstall.UninstallType)
+	})
+
+	t.Run("args error", func(t *testing.T) {
+		c := &fakeConnector{}
+		ctx := outputTestContext(t, &fakeDaemon{}, c, nil)
+		root, _ := outputRootCommand(uninstallCommand())
+		co, err := executeJSON(ctx, t, root, "uninstall")
+		require.Error(t, err)
+		assert.True(t, ErrorReported(err))
+		assert.Equal(t, map[string]interface{}{
+			"cmd":   "uninstall",
+			"error": map[string]interface{}{"message": "please specify --agent, --all-agents, or --everything", "category": "user"},
+		}, co)
+		assert.Nil(t, c.uninstall)
+	})
+}
+
+func TestOutputLoglevel(t *testing.T) {
+	d, c, m := &fakeDaemon{}, &fakeConnector{}, &fakeManager{}
+	ctx := outputTestContext(t, d, c, m)
+	root, _ := outputRootCommand(loglevelCommand())
+	co, err := executeJSON(ctx, t, root, "loglevel", "debug", "--duration", "10m")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"log_level": "debug",
+		"duration":  "10m0s",
+		"local":     true,
+		"remote":    true,
+	}, co["result"])
+	for _, rq := range []*manager.LogLevelRequest{d.logLevel, c.logLevel, m.logLevel} {
+		require.NotNil(t, rq)
+		assert.Equal(t, "debug", rq.LogLevel)
+	}
+
+	d, c, m = &fakeDaemon{}, &fakeConnector{}, &fakeManager{}
+	ctx = outputTestContext(t, d, c, m)
+	root, _ = outputRootCommand(loglevelCommand())
+	co, err = executeJSON(ctx, t, root, "loglevel", "info", "--local-only")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"log_level": "info",
+		"duration":  "30m0s",
+		"local":     true,
+		"remote":    false,
+	}, co["result"])
+	assert.Nil(t, m.logLevel)
+}
+
+func TestOutputArgsError(t *testing.T) {
+	root, _ := outputRootCommand(leaveCommand())
+	co, err := executeJSON(outputTestContext(t, nil, nil, nil), t, root, "leave")
+	require.Error(t, err)
+	assert.True(t, ErrorReported(err))
+	assert.Equal(t, "leave", co["cmd"])
+	assert.Equal(t, "user", co["error"].(map[string]interface{})["category"])
+}
+
+func TestOutputRunCommand(t *testing.T) {
+	// The output of a subprocess can't be part of the json output, so that combination is rejected
+	// before anything is started.
+	root, _ := outputRootCommand(connectCommand())
+	co, err := executeJSON(outputTestContext(t, nil, nil, nil), t, root, "connect", "--", "true")
+	require.Error(t, err)
+	assert.True(t, ErrorReported(err))
+	assert.Equal(t, map[string]interface{}{
+		"cmd":   "connect",
+		"error": map[string]interface{}{"message": "--output json cannot be used when running a command", "category": "user"},
+	}, co)
+}
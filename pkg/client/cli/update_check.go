@@ -70,7 +70,12 @@ func updateCheck(cmd *cobra.Command, forceCheck bool) error {
 		return uc.StoreNextCheck(cmd.Context(), time.Hour)
 	}
 	if update != nil {
-		fmt.Fprintf(cmd.OutOrStdout(),
+		out := cmd.OutOrStdout()
+		if format, _ := outputFormat(cmd); format != outputText {
+			// The check runs before the command, so the message can't be part of its json or yaml output
+			out = cmd.ErrOrStderr()
+		}
+		fmt.Fprintf(out,
 			"An update of %s from version %s to %s is available. Please visit https://www.getambassador.io/docs/telepresence/latest/install/upgrade/ for more info.\n",
 			binaryName, &ourVersion, update)
 	}
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"sync/atomic"
 	"time"
 	"unsafe"
@@ -16,6 +18,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/TinderBackend/telepresence/v2/pkg/client/errcat"
 	"github.com/TinderBackend/telepresence/v2/pkg/filelocation"
@@ -35,6 +38,7 @@ type Config struct {
 	TelepresenceAPI TelepresenceAPI `json:"telepresenceAPI,omitempty" yaml:"telepresenceAPI,omitempty"`
 	Daemons         Daemons         `json:"daemons,omitempty" yaml:"daemons,omitempty"`
 	Intercept       Intercept       `json:"intercept,omitempty" yaml:"intercept,omitempty"`
+	DNS             DNS             `json:"dns,omitempty" yaml:"dns,omitempty"`
 }
 
 // Merge merges this instance with the non-zero values of the given argument. The argument values take priority.
@@ -47,6 +51,7 @@ func (c *Config) Merge(o *Config) {
 	c.TelepresenceAPI.merge(&o.TelepresenceAPI)
 	c.Daemons.merge(&o.Daemons)
 	c.Intercept.merge(&o.Intercept)
+	c.DNS.merge(&o.DNS)
 }
 
 // Watch uses a file system watcher that receives events when the configuration changes
@@ -129,6 +134,8 @@ func (c *Config) UnmarshalYAML(node *yaml.Node) error {
 			err = ms[i+1].Decode(&c.Daemons)
 		case kv == "intercept":
 			err = ms[i+1].Decode(&c.Intercept)
+		case kv == "dns":
+			err = ms[i+1].Decode(&c.DNS)
 		case parseContext != nil:
 			dlog.Warn(parseContext, withLoc(fmt.Sprintf("unknown key %q", kv), ms[i]))
 		}
@@ -808,6 +815,112 @@ func (ic Intercept) MarshalYAML() (interface{}, error) {
 	return im, nil
 }
 
+type DNS struct {
+	// Overrides maps DNS names to IPs, or to other names that are then resolved in the cluster. The
+	// overrides are used by the root daemon's resolver before it asks the cluster.
+	Overrides map[string]string `json:"overrides,omitempty" yaml:"overrides,omitempty"`
+
+	// CacheTTL is the maximum time that the root daemon's resolver caches an answer from the cluster.
+	CacheTTL time.Duration `json:"cacheTTL,omitempty" yaml:"cacheTTL,omitempty"`
+
+	// NegativeCacheTTL is the time that the root daemon's resolver caches that a name wasn't found.
+	NegativeCacheTTL time.Duration `json:"negativeCacheTTL,omitempty" yaml:"negativeCacheTTL,omitempty"`
+}
+
+func (d *DNS) merge(o *DNS) {
+	for name, target := range o.Overrides {
+		if d.Overrides == nil {
+			d.Overrides = make(map[string]string, len(o.Overrides))
+		}
+		d.Overrides[name] = target
+	}
+	if o.CacheTTL != 0 {
+		d.CacheTTL = o.CacheTTL
+	}
+	if o.NegativeCacheTTL != 0 {
+		d.NegativeCacheTTL = o.NegativeCacheTTL
+	}
+}
+
+// MarshalYAML is not using pointer receiver here, because DNS is not pointer in the Config struct
+func (d DNS) MarshalYAML() (interface{}, error) {
+	dm := make(map[string]interface{})
+	if len(d.Overrides) > 0 {
+		dm["overrides"] = d.Overrides
+	}
+	if d.CacheTTL != 0 {
+		dm["cacheTTL"] = d.CacheTTL.String()
+	}
+	if d.NegativeCacheTTL != 0 {
+		dm["negativeCacheTTL"] = d.NegativeCacheTTL.String()
+	}
+	return dm, nil
+}
+
+// UnmarshalYAML parses the dns YAML
+func (d *DNS) UnmarshalYAML(node *yaml.Node) (err error) {
+	if node.Kind != yaml.MappingNode {
+		return errors.New(withLoc("dns must be an object", node))
+	}
+
+	ms := node.Content
+	top := len(ms)
+	for i := 0; i < top; i += 2 {
+		kv, err := stringKey(ms[i])
+		if err != nil {
+			return err
+		}
+		v := ms[i+1]
+		switch kv {
+		case "overrides":
+			var overrides map[string]string
+			if err := v.Decode(&overrides); err != nil {
+				dlog.Warn(parseContext, withLoc(fmt.Sprintf("map of strings expected for key %q", kv), ms[i]))
+				continue
+			}
+			for name, target := range overrides {
+				if err := ValidateDNSOverride(name, target); err != nil {
+					dlog.Warn(parseContext, withLoc(err.Error(), v))
+					continue
+				}
+				if d.Overrides == nil {
+					d.Overrides = make(map[string]string, len(overrides))
+				}
+				d.Overrides[name] = target
+			}
+		case "cacheTTL", "negativeCacheTTL":
+			duration, err := time.ParseDuration(v.Value)
+			if err != nil || duration <= 0 {
+				dlog.Warn(parseContext, withLoc(fmt.Sprintf("positive duration expected for key %q", kv), ms[i]))
+			} else if kv == "cacheTTL" {
+				d.CacheTTL = duration
+			} else {
+				d.NegativeCacheTTL = duration
+			}
+		default:
+			if parseContext != nil {
+				dlog.Warn(parseContext, withLoc(fmt.Sprintf("unknown key %q", kv), ms[i]))
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateDNSOverride returns an error unless the given name is a DNS name and the given target is
+// either an IP or a DNS name.
+func ValidateDNSOverride(name, target string) error {
+	if errs := validation.IsDNS1123Subdomain(strings.ToLower(strings.TrimSuffix(name, "."))); len(errs) > 0 {
+		return fmt.Errorf("invalid DNS override name %q: %s", name, strings.Join(errs, ", "))
+	}
+	if net.ParseIP(target) != nil {
+		return nil
+	}
+	if errs := validation.IsDNS1123Subdomain(strings.ToLower(strings.TrimSuffix(target, "."))); len(errs) > 0 {
+		return fmt.Errorf("invalid DNS override %q for %q: must be an IP or a DNS name", target, name)
+	}
+	return nil
+}
+
 var parseContext context.Context
 
 type parsedFile struct{}
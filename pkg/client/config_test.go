@@ -47,6 +47,12 @@ telepresenceAPI:
 intercept:
   appProtocolStrategy: portName
   defaultPort: 9080
+dns:
+  overrides:
+    payments.default: 127.0.0.1
+    orders.default: orders-v2.default
+    bad_name: 127.0.0.1
+  negativeCacheTTL: 10s
 `,
 	}
 
@@ -86,6 +92,12 @@ intercept:
 	assert.Equal(t, 1234, cfg.TelepresenceAPI.Port)                                                     // from user
 	assert.Equal(t, k8sapi.PortName, cfg.Intercept.AppProtocolStrategy)                                 // from user
 	assert.Equal(t, 9080, cfg.Intercept.DefaultPort)                                                    // from user
+	assert.Equal(t, map[string]string{
+		"payments.default": "127.0.0.1",
+		"orders.default":   "orders-v2.default",
+	}, cfg.DNS.Overrides) // from user, without the invalid name
+	assert.Equal(t, time.Duration(0), cfg.DNS.CacheTTL)       // default
+	assert.Equal(t, 10*time.Second, cfg.DNS.NegativeCacheTTL) // from user
 }
 
 func Test_ConfigMarshalYAML(t *testing.T) {
@@ -102,6 +114,8 @@ func Test_ConfigMarshalYAML(t *testing.T) {
 	cfg.TelepresenceAPI.Port = 4567
 	cfg.Intercept.AppProtocolStrategy = k8sapi.PortName
 	cfg.Intercept.DefaultPort = 9080
+	cfg.DNS.Overrides = map[string]string{"payments.default": "127.0.0.1"}
+	cfg.DNS.CacheTTL = 30 * time.Second
 	cfgBytes, err := yaml.Marshal(cfg)
 	require.NoError(t, err)
 
@@ -0,0 +1,67 @@
+package rootd
+
+import (
+	"context"
+	"net"
+
+	"github.com/miekg/dns"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/pkg/dnsproxy"
+	"github.com/datawire/dlib/dlog"
+)
+
+// clusterLookup sends a LookupDNS request to the traffic-manager and returns the result
+func (s *session) clusterLookup(ctx context.Context, qType uint16, name string) (dnsproxy.RRs, int, error) {
+	dlog.Debugf(ctx, "LookupDNS %q %s", name, dns.TypeToString[qType])
+	s.dnsLookups++
+	answer, rCode, err := s.lookupDNS(ctx, qType, name)
+	if err != nil || len(answer) == 0 {
+		s.dnsFailures++
+	}
+	return answer, rCode, err
+}
+
+func (s *session) lookupDNS(ctx context.Context, qType uint16, name string) (dnsproxy.RRs, int, error) {
+	r, err := s.managerClient.LookupDNS(ctx, &manager.DNSRequest{
+		Session: s.session,
+		Name:    name,
+		Type:    int32(qType),
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			// The traffic-manager predates LookupDNS
+			return s.lookupHost(ctx, qType, name)
+		}
+		return nil, dns.RcodeServerFailure, err
+	}
+	return dnsproxy.FromRPC(r)
+}
+
+// lookupHost uses the LookupHost request of older traffic-managers, which can only resolve A and
+// AAAA records.
+func (s *session) lookupHost(ctx context.Context, qType uint16, name string) (dnsproxy.RRs, int, error) {
+	r, err := s.managerClient.LookupHost(ctx, &manager.LookupHostRequest{
+		Session: s.session,
+		Host:    name,
+	})
+	if err != nil {
+		return nil, dns.RcodeServerFailure, err
+	}
+	if len(r.Ips) == 0 {
+		return nil, dns.RcodeNameError, nil
+	}
+	answer := dnsproxy.RRs{}
+	hdr := dns.RR_Header{Name: dns.Fqdn(name), Rrtype: qType, Class: dns.ClassINET}
+	for _, ip := range r.Ips {
+		switch ip := net.IP(ip); {
+		case qType == dns.TypeA && ip.To4() != nil:
+			answer = append(answer, &dns.A{Hdr: hdr, A: ip.To4()})
+		case qType == dns.TypeAAAA && ip.To4() == nil:
+			answer = append(answer, &dns.AAAA{Hdr: hdr, AAAA: ip})
+		}
+	}
+	return answer, dns.RcodeSuccess, nil
+}
@@ -0,0 +1,176 @@
+package dns
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/daemon"
+)
+
+// queryLogSize is the number of queries that are kept in the query log.
+const queryLogSize = 1000
+
+// queryLog is a ring buffer of the most recent queries that the server has answered. New queries are
+// also sent to the subscribers of the log.
+type queryLog struct {
+	sync.Mutex
+	entries     []*rpc.DNSQuery
+	next        int
+	subscribers map[chan *rpc.DNSQuery]struct{}
+	closed      bool
+}
+
+func newQueryLog(size int) *queryLog {
+	return &queryLog{
+		entries:     make([]*rpc.DNSQuery, 0, size),
+		subscribers: make(map[chan *rpc.DNSQuery]struct{}),
+	}
+}
+
+// add adds the given query to the log and sends it to all subscribers. A subscriber that doesn't keep up
+// will miss queries rather than block the server.
+func (l *queryLog) add(q *rpc.DNSQuery) {
+	l.Lock()
+	defer l.Unlock()
+	if len(l.entries) < cap(l.entries) {
+		l.entries = append(l.entries, q)
+	} else {
+		l.entries[l.next] = q
+		l.next = (l.next + 1) % len(l.entries)
+	}
+	for ch := range l.subscribers {
+		select {
+		case ch <- q:
+		default:
+		}
+	}
+}
+
+// recent returns the given number of most recent queries, oldest first. A negative number means all.
+func (l *queryLog) recent(n int) []*rpc.DNSQuery {
+	sz := len(l.entries)
+	if n < 0 || n > sz {
+		n = sz
+	}
+	qs := make([]*rpc.DNSQuery, n)
+	for i := 0; i < n; i++ {
+		qs[i] = l.entries[(l.next+sz-n+i)%sz]
+	}
+	return qs
+}
+
+// subscribe returns the given number of most recent queries and a channel that receives new queries.
+// The channel is closed when the log is closed, or when the unsubscribe function is called.
+func (l *queryLog) subscribe(tail int) ([]*rpc.DNSQuery, <-chan *rpc.DNSQuery, func()) {
+	l.Lock()
+	defer l.Unlock()
+	ch := make(chan *rpc.DNSQuery, 100)
+	if l.closed {
+		close(ch)
+	} else {
+		l.subscribers[ch] = struct{}{}
+	}
+	return l.recent(tail), ch, func() {
+		l.Lock()
+		defer l.Unlock()
+		if _, ok := l.subscribers[ch]; ok {
+			delete(l.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// close closes the channels of all subscribers.
+func (l *queryLog) close() {
+	l.Lock()
+	defer l.Unlock()
+	l.closed = true
+	for ch := range l.subscribers {
+		delete(l.subscribers, ch)
+		close(ch)
+	}
+}
+
+type queryInfoKey struct{}
+
+// queryInfo collects information about how a query was resolved.
+type queryInfo struct {
+	sync.Mutex
+	cached         bool
+	clusterLookups []string
+}
+
+func withQueryInfo(c context.Context, qi *queryInfo) context.Context {
+	return context.WithValue(c, queryInfoKey{}, qi)
+}
+
+// addClusterLookup records that the given name was looked up in the cluster on behalf of the query
+// of the given context.
+func addClusterLookup(c context.Context, name string) {
+	if qi, ok := c.Value(queryInfoKey{}).(*queryInfo); ok {
+		qi.Lock()
+		qi.clusterLookups = append(qi.clusterLookups, name)
+		qi.Unlock()
+	}
+}
+
+// setCached records that the query of the given context was answered from the cache.
+func setCached(c context.Context) {
+	if qi, ok := c.Value(queryInfoKey{}).(*queryInfo); ok {
+		qi.Lock()
+		qi.cached = true
+		qi.Unlock()
+	}
+}
+
+// logQuery adds the given query and the response message to the query log.
+func (s *Server) logQuery(start time.Time, q *dns.Question, qi *queryInfo, fallback bool, msg *dns.Msg, err error) {
+	qi.Lock()
+	defer qi.Unlock()
+	dq := &rpc.DNSQuery{
+		Time:           timestamppb.New(start),
+		Name:           q.Name,
+		Type:           dns.TypeToString[q.Qtype],
+		ClusterLookups: qi.clusterLookups,
+		RCode:          dns.RcodeToString[msg.Rcode],
+		Latency:        durationpb.New(time.Since(start)),
+	}
+	switch {
+	case fallback:
+		dq.Source = "fallback"
+	case qi.cached:
+		dq.Source = "cache"
+	case len(qi.clusterLookups) > 0:
+		dq.Source = "cluster"
+	default:
+		dq.Source = "local"
+	}
+	if len(msg.Answer) > 0 {
+		dq.Answers = make([]string, len(msg.Answer))
+		for i, rr := range msg.Answer {
+			dq.Answers[i] = rr.String()
+		}
+	}
+	if err != nil && msg.Rcode == dns.RcodeServerFailure {
+		dq.Error = err.Error()
+	}
+	s.queryLog.add(dq)
+}
+
+// SubscribeQueries returns the given number of most recent queries that this server has answered, and a
+// channel that receives the queries that it answers from now on. The channel is closed when the server
+// stops, or when the returned unsubscribe function is called.
+func (s *Server) SubscribeQueries(tail int) (recent []*rpc.DNSQuery, queries <-chan *rpc.DNSQuery, unsubscribe func()) {
+	return s.queryLog.subscribe(tail)
+}
+
+// CloseQueryLog closes the channels of the subscribers of the query log. It's called when the server
+// has stopped for good.
+func (s *Server) CloseQueryLog() {
+	s.queryLog.close()
+}
@@ -0,0 +1,110 @@
+package dns
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/daemon"
+	"github.com/TinderBackend/telepresence/v2/pkg/dnsproxy"
+	"github.com/datawire/dlib/dlog"
+)
+
+func TestQueryLog(t *testing.T) {
+	l := newQueryLog(3)
+	names := func(qs []*rpc.DNSQuery) []string {
+		ns := make([]string, len(qs))
+		for i, q := range qs {
+			ns[i] = q.Name
+		}
+		return ns
+	}
+	for i := 0; i < 2; i++ {
+		l.add(&rpc.DNSQuery{Name: strconv.Itoa(i)})
+	}
+	assert.Equal(t, []string{"0", "1"}, names(l.recent(-1)))
+
+	recent, ch, unsubscribe := l.subscribe(1)
+	assert.Equal(t, []string{"1"}, names(recent))
+	for i := 2; i < 5; i++ {
+		l.add(&rpc.DNSQuery{Name: strconv.Itoa(i)})
+		assert.Equal(t, strconv.Itoa(i), (<-ch).Name)
+	}
+	assert.Equal(t, []string{"2", "3", "4"}, names(l.recent(-1)))
+	assert.Equal(t, []string{"3", "4"}, names(l.recent(2)))
+	unsubscribe()
+	_, ok := <-ch
+	assert.False(t, ok)
+
+	_, ch, _ = l.subscribe(0)
+	l.close()
+	_, ok = <-ch
+	assert.False(t, ok)
+}
+
+// responseWriter is a dns.ResponseWriter that discards the response.
+type responseWriter struct {
+	dns.ResponseWriter
+}
+
+func (responseWriter) WriteMsg(*dns.Msg) error {
+	return nil
+}
+
+func TestServeDNSQueryLog(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+	lookup := func(_ context.Context, qType uint16, name string) (dnsproxy.RRs, int, error) {
+		if qType == dns.TypeA && name == "echo.default" {
+			rr, err := dns.NewRR("echo.default. 30 IN A 10.1.0.5")
+			return dnsproxy.RRs{rr}, dns.RcodeSuccess, err
+		}
+		return nil, dns.RcodeNameError, nil
+	}
+	s := NewServer(&rpc.DNSConfig{Overrides: map[string]string{"payments.default": "127.0.0.1"}}, lookup)
+	s.ctx = ctx
+	s.resolve = s.resolveInCluster
+	s.cacheResolve = s.resolveThruCache
+
+	_, queries, unsubscribe := s.SubscribeQueries(0)
+	defer unsubscribe()
+	serve := func(name string) *rpc.DNSQuery {
+		t.Helper()
+		msg := new(dns.Msg)
+		msg.SetQuestion(name, dns.TypeA)
+		s.ServeDNS(responseWriter{}, msg)
+		return <-queries
+	}
+
+	q := serve("echo.default.")
+	assert.Equal(t, "echo.default.", q.Name)
+	assert.Equal(t, "A", q.Type)
+	assert.Equal(t, "cluster", q.Source)
+	assert.Equal(t, []string{"echo.default."}, q.ClusterLookups)
+	assert.Equal(t, "NOERROR", q.RCode)
+	require.Len(t, q.Answers, 1)
+	assert.Contains(t, q.Answers[0], "10.1.0.5")
+
+	q = serve("echo.default.")
+	assert.Equal(t, "cache", q.Source)
+	assert.Empty(t, q.ClusterLookups)
+
+	q = serve("payments.default.")
+	assert.Equal(t, "local", q.Source)
+	require.Len(t, q.Answers, 1)
+
+	q = serve("missing.default.")
+	assert.Equal(t, "cluster", q.Source)
+	assert.Equal(t, "NXDOMAIN", q.RCode)
+	assert.Empty(t, q.Answers)
+
+	// Names that aren't dispatched to the cluster
+	q = serve("example.com.")
+	assert.Equal(t, "local", q.Source)
+	assert.Equal(t, "NXDOMAIN", q.RCode)
+
+	assert.Len(t, s.queryLog.recent(-1), 5)
+}
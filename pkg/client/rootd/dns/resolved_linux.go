@@ -90,7 +90,7 @@ func (s *Server) tryResolveD(c context.Context, dev *vif.Device, configureDNS fu
 				s.processSearchPaths(g, s.updateLinkDomains, dev)
 				return nil
 			}
-			s.flushDNS()
+			s.Flush()
 			dtime.SleepWithContext(cmdC, 100*time.Millisecond)
 		}
 		dlog.Error(c, "resolver did not receive requests from systemd-resolved")
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -15,6 +17,7 @@ import (
 
 	rpc "github.com/TinderBackend/telepresence/rpc/v2/daemon"
 	"github.com/TinderBackend/telepresence/v2/pkg/client"
+	"github.com/TinderBackend/telepresence/v2/pkg/dnsproxy"
 	"github.com/TinderBackend/telepresence/v2/pkg/iputil"
 	"github.com/TinderBackend/telepresence/v2/pkg/vif"
 	"github.com/datawire/dlib/dcontext"
@@ -22,7 +25,9 @@ import (
 	"github.com/datawire/dlib/dlog"
 )
 
-type Resolver func(ctx context.Context, domain string) ([]net.IP, error)
+// Resolver resolves the given question and returns the answer and the response code. The answer is
+// nil unless the response code is dns.RcodeSuccess.
+type Resolver func(ctx context.Context, q *dns.Question) (dnsproxy.RRs, int, error)
 
 // recursionCheck is a special host name in a well known namespace that isn't expected to exist. It
 // is used once for determining if the cluster's DNS resolver will call the Telepresence DNS resolver
@@ -44,16 +49,24 @@ type Server struct {
 	fallbackPool FallbackPool
 	resolve      Resolver
 	requestCount int64
+	cacheHits    int64
+	cacheMisses  int64
+	lastPurge    int64 // unix nano time of the last purge of expired cache entries
 	cache        sync.Map
-	recursive    int32 // 0 = never tested, 1 = not recursive, 2 = recursive
-	cacheResolve func(*dns.Question) ([]dns.RR, error)
+	cacheLock    sync.Mutex // serializes the replacement and removal of existing cache entries
+	recursive    int32      // 0 = never tested, 1 = not recursive, 2 = recursive
+	cacheResolve func(context.Context, *dns.Question) (dnsproxy.RRs, int, error)
+	queryLog     *queryLog
 
 	// Namespaces, accessible using <service-name>.<namespace-name>
 	namespaces map[string]struct{}
 	domains    map[string]struct{}
 	search     []string
 
-	// The domainsLock locks usage of namespaces, domains, and search
+	// Subnets of the cluster. Reverse lookups of IPs in these subnets are dispatched to the cluster
+	clusterSubnets []*net.IPNet
+
+	// The domainsLock locks usage of namespaces, domains, search, and clusterSubnets
 	domainsLock sync.RWMutex
 
 	// searchPathCh receives requests to change the search path.
@@ -64,26 +77,49 @@ type Server struct {
 	// clusterDomain reported by the traffic-manager
 	clusterDomain string
 
-	// Function that sends a lookup requrest to the traffic-manager
-	clusterLookup func(context.Context, string) ([][]byte, error)
+	// Function that sends a lookup request to the traffic-manager
+	clusterLookup ClusterLookup
+
+	// Overrides of names, keyed by lower case FQDN. The value is an IP or the FQDN of an alias
+	overrides map[string]string
+}
+
+// ClusterLookup performs a DNS lookup of the given query type and name in the cluster. The name has no
+// trailing dot, so it's subject to the search path of the cluster.
+type ClusterLookup func(ctx context.Context, qType uint16, name string) (dnsproxy.RRs, int, error)
+
+// cacheKey is the key of an entry in the local DNS cache.
+type cacheKey struct {
+	name  string
+	qType uint16
 }
 
 type cacheEntry struct {
-	created      time.Time
-	currentQType int32 // will be set to the current qType during call to cluster
-	answer       []dns.RR
+	expires      time.Time // set when the query has been resolved
+	currentQType int32     // will be set to the current qType during call to cluster
+	answer       dnsproxy.RRs
+	rCode        int
 	wait         chan struct{}
 }
 
-// cacheTTL is the time to live for an entry in the local DNS cache.
-const cacheTTL = 60 * time.Second
+// defaultCacheTTL is the maximum time to live for an entry in the local DNS cache, unless configured.
+const defaultCacheTTL = 60 * time.Second
+
+// defaultNegativeCacheTTL is the time to live for an entry in the local DNS cache that has no records,
+// unless configured.
+const defaultNegativeCacheTTL = 5 * time.Second
 
 func (dv *cacheEntry) expired() bool {
-	return time.Since(dv.created) > cacheTTL
+	return !time.Now().Before(dv.expires)
+}
+
+// negative returns true if the entry is for a name, or a query type, that wasn't found.
+func (dv *cacheEntry) negative() bool {
+	return len(dv.answer) == 0
 }
 
 // NewServer returns a new dns.Server
-func NewServer(config *rpc.DNSConfig, clusterLookup func(context.Context, string) ([][]byte, error)) *Server {
+func NewServer(config *rpc.DNSConfig, clusterLookup ClusterLookup) *Server {
 	if config == nil {
 		config = &rpc.DNSConfig{}
 	}
@@ -100,6 +136,15 @@ func NewServer(config *rpc.DNSConfig, clusterLookup func(context.Context, string
 	if config.LookupTimeout.AsDuration() <= 0 {
 		config.LookupTimeout = durationpb.New(8 * time.Second)
 	}
+	if config.CacheTtl.AsDuration() <= 0 {
+		config.CacheTtl = durationpb.New(defaultCacheTTL)
+	}
+	if config.NegativeCacheTtl == nil {
+		config.NegativeCacheTtl = durationpb.New(defaultNegativeCacheTTL)
+	}
+	if config.NegativeCacheTtl.AsDuration() > config.CacheTtl.AsDuration() {
+		config.NegativeCacheTtl = config.CacheTtl
+	}
 	s := &Server{
 		config:        config,
 		namespaces:    make(map[string]struct{}),
@@ -108,6 +153,14 @@ func NewServer(config *rpc.DNSConfig, clusterLookup func(context.Context, string
 		searchPathCh:  make(chan []string, 5),
 		clusterDomain: defaultClusterDomain,
 		clusterLookup: clusterLookup,
+		overrides:     make(map[string]string, len(config.Overrides)),
+		queryLog:      newQueryLog(queryLogSize),
+	}
+	for name, target := range config.Overrides {
+		if net.ParseIP(target) == nil {
+			target = dns.Fqdn(strings.ToLower(target))
+		}
+		s.overrides[dns.Fqdn(strings.ToLower(name))] = target
 	}
 	s.cacheResolve = s.resolveWithRecursionCheck
 	return s
@@ -127,11 +180,32 @@ const tel2SubDomainDot = tel2SubDomain + "."
 
 var localhostIPs = []net.IP{{127, 0, 0, 1}, {0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}}
 
-func (s *Server) shouldDoClusterLookup(query string) bool {
+// ipRRs returns the A or AAAA records of the given name and IPs that match the query type.
+func ipRRs(name string, qType uint16, ips ...net.IP) dnsproxy.RRs {
+	answer := dnsproxy.RRs{}
+	hdr := dns.RR_Header{Name: name, Rrtype: qType, Class: dns.ClassINET, Ttl: dnsTTL}
+	for _, ip := range ips {
+		ip4 := ip.To4()
+		switch {
+		case qType == dns.TypeA && ip4 != nil:
+			answer = append(answer, &dns.A{Hdr: hdr, A: ip4})
+		case qType == dns.TypeAAAA && ip4 == nil:
+			answer = append(answer, &dns.AAAA{Hdr: hdr, AAAA: ip})
+		}
+	}
+	return answer
+}
+
+func (s *Server) shouldDoClusterLookup(query string, qType uint16) bool {
 	if strings.HasSuffix(query, "."+s.clusterDomain) && strings.Count(query, ".") < 4 {
 		return false
 	}
 
+	if qType == dns.TypePTR && (strings.HasSuffix(query, ".in-addr.arpa.") || strings.HasSuffix(query, ".ip6.arpa.")) {
+		// Reverse lookups are only dispatched to the cluster when the IP belongs to it
+		return s.isClusterIP(reverseIP(query))
+	}
+
 	query = query[:len(query)-1] // skip last dot
 
 	// Always include configured includeSuffixes
@@ -150,8 +224,58 @@ func (s *Server) shouldDoClusterLookup(query string) bool {
 	return true
 }
 
-func (s *Server) resolveInCluster(c context.Context, query string) (results []net.IP, err error) {
-	query = strings.ToLower(query)
+// isClusterIP returns true if the given IP belongs to one of the subnets of the cluster.
+func (s *Server) isClusterIP(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	s.domainsLock.RLock()
+	defer s.domainsLock.RUnlock()
+	for _, sn := range s.clusterSubnets {
+		if sn.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// reverseIP returns the IP of the given "in-addr.arpa." or "ip6.arpa." name, or nil if the name
+// isn't the reverse name of an IP.
+func reverseIP(name string) net.IP {
+	var labels []string
+	var ip net.IP
+	switch {
+	case strings.HasSuffix(name, ".in-addr.arpa."):
+		if labels = strings.Split(strings.TrimSuffix(name, ".in-addr.arpa."), "."); len(labels) != net.IPv4len {
+			return nil
+		}
+		ip = make(net.IP, net.IPv4len)
+		for i, l := range labels {
+			b, err := strconv.ParseUint(l, 10, 8)
+			if err != nil {
+				return nil
+			}
+			ip[net.IPv4len-1-i] = byte(b)
+		}
+	case strings.HasSuffix(name, ".ip6.arpa."):
+		if labels = strings.Split(strings.TrimSuffix(name, ".ip6.arpa."), "."); len(labels) != 2*net.IPv6len {
+			return nil
+		}
+		ip = make(net.IP, net.IPv6len)
+		for i, l := range labels {
+			n, err := strconv.ParseUint(l, 16, 4)
+			if err != nil {
+				return nil
+			}
+			// The nibbles are in reverse order, least significant first
+			ip[net.IPv6len-1-i/2] |= byte(n) << (4 * (i % 2))
+		}
+	}
+	return ip
+}
+
+func (s *Server) resolveInCluster(c context.Context, q *dns.Question) (dnsproxy.RRs, int, error) {
+	query := strings.ToLower(q.Name)
 	query = strings.TrimSuffix(query, tel2SubDomainDot)
 
 	if query == "localhost." {
@@ -162,29 +286,78 @@ func (s *Server) resolveInCluster(c context.Context, query string) (results []ne
 		// But it does, so I need this in order to be
 		// productive at home.  We should really
 		// root-cause this, because it's weird.
-		return localhostIPs, nil
+		return ipRRs(q.Name, q.Qtype, localhostIPs...), dns.RcodeSuccess, nil
 	}
 
-	if !s.shouldDoClusterLookup(query) {
-		return nil, nil
+	if _, ok := s.overrides[query]; !ok && !s.shouldDoClusterLookup(query, q.Qtype) {
+		return nil, dns.RcodeNameError, nil
+	}
+	return s.lookupInCluster(c, q, query)
+}
+
+// lookupInCluster performs a lookup of the given query in the cluster, unless the query is overridden.
+// The records of the query in the answer are given the name of the question.
+func (s *Server) lookupInCluster(c context.Context, q *dns.Question, query string) (dnsproxy.RRs, int, error) {
+	if target, ok := s.overrides[query]; ok {
+		return s.resolveOverride(c, q, target)
 	}
+	answer, rCode, err := s.lookupWithTimeout(c, q.Qtype, query)
+	for _, rr := range answer {
+		if hdr := rr.Header(); strings.EqualFold(hdr.Name, query) {
+			hdr.Name = q.Name
+		}
+	}
+	return answer, rCode, err
+}
 
+func (s *Server) lookupWithTimeout(c context.Context, qType uint16, query string) (dnsproxy.RRs, int, error) {
 	// Give the cluster lookup a reasonable timeout.
 	c, cancel := context.WithTimeout(c, s.config.LookupTimeout.AsDuration())
 	defer cancel()
+	addClusterLookup(c, query)
 
-	result, err := s.clusterLookup(c, query[:len(query)-1])
+	answer, rCode, err := s.clusterLookup(c, qType, query[:len(query)-1])
 	if err != nil {
-		return nil, client.CheckTimeout(c, err)
+		return nil, dns.RcodeServerFailure, client.CheckTimeout(c, err)
 	}
-	if len(result) == 0 {
-		return nil, nil
+	return answer, rCode, nil
+}
+
+// maxAliases is the maximum number of overrides that are followed when an override is an alias of
+// another overridden name.
+const maxAliases = 8
+
+// resolveOverride answers the question using the given override target, which is either an IP or a
+// name that the name of the question is an alias of. The records of such a name are looked up in the
+// cluster, and follow a CNAME record in the answer.
+func (s *Server) resolveOverride(c context.Context, q *dns.Question, target string) (dnsproxy.RRs, int, error) {
+	answer := dnsproxy.RRs{}
+	name := q.Name
+	for i := 0; ; i++ {
+		if ip := net.ParseIP(target); ip != nil {
+			return append(answer, ipRRs(name, q.Qtype, ip)...), dns.RcodeSuccess, nil
+		}
+		answer = append(answer, &dns.CNAME{
+			Hdr:    dns.RR_Header{Name: name, Rrtype: dns.TypeCNAME, Class: dns.ClassINET, Ttl: dnsTTL},
+			Target: target,
+		})
+		if q.Qtype == dns.TypeCNAME {
+			return answer, dns.RcodeSuccess, nil
+		}
+		next, ok := s.overrides[target]
+		if !ok {
+			break
+		}
+		if i == maxAliases {
+			return nil, dns.RcodeServerFailure, fmt.Errorf("the DNS override of %s has more than %d aliases", q.Name, maxAliases)
+		}
+		name, target = target, next
 	}
-	ips := make(iputil.IPs, len(result))
-	for i, ip := range result {
-		ips[i] = ip
+	rrs, rCode, err := s.lookupWithTimeout(c, q.Qtype, target)
+	if err != nil || rCode != dns.RcodeSuccess {
+		return nil, rCode, err
 	}
-	return ips, nil
+	return append(answer, rrs...), rCode, nil
 }
 
 func (s *Server) GetConfig() *rpc.DNSConfig {
@@ -194,6 +367,7 @@ func (s *Server) GetConfig() *rpc.DNSConfig {
 		dnsConfig.ExcludeSuffixes = s.config.ExcludeSuffixes
 		dnsConfig.IncludeSuffixes = s.config.IncludeSuffixes
 		dnsConfig.LookupTimeout = s.config.LookupTimeout
+		dnsConfig.Overrides = s.config.Overrides
 	}
 	return dnsConfig
 }
@@ -208,6 +382,14 @@ func (s *Server) SetClusterDomainAndDNS(domain string, dnsIP net.IP) {
 	}
 }
 
+// SetClusterSubnets updates the subnets of the cluster. Reverse lookups of IPs in these subnets are
+// dispatched to the cluster.
+func (s *Server) SetClusterSubnets(subnets []*net.IPNet) {
+	s.domainsLock.Lock()
+	s.clusterSubnets = subnets
+	s.domainsLock.Unlock()
+}
+
 // SetSearchPath updates the DNS search path used by the resolver
 func (s *Server) SetSearchPath(ctx context.Context, paths, namespaces []string) {
 	// Provide direct access to intercepted namespaces
@@ -270,13 +452,90 @@ func (s *Server) processSearchPaths(g *dgroup.Group, processor func(context.Cont
 	})
 }
 
-func (s *Server) flushDNS() {
+// Flush removes all entries from the cache.
+func (s *Server) Flush() {
 	s.cache.Range(func(key, _ interface{}) bool {
 		s.cache.Delete(key)
 		return true
 	})
 }
 
+// purgeExpired removes expired entries from the cache, so that names that are looked up once, e.g. the
+// expansions of the search path, don't stay in the cache. The purge is performed at most once per
+// cache TTL.
+func (s *Server) purgeExpired() {
+	now := time.Now()
+	last := atomic.LoadInt64(&s.lastPurge)
+	if now.Sub(time.Unix(0, last)) < s.config.CacheTtl.AsDuration() || !atomic.CompareAndSwapInt64(&s.lastPurge, last, now.UnixNano()) {
+		return
+	}
+	s.cache.Range(func(key, _ interface{}) bool {
+		s.deleteIfExpired(key.(cacheKey))
+		return true
+	})
+}
+
+// deleteIfExpired removes the entry with the given key from the cache if it's resolved and expired. The entry
+// is loaded under the cacheLock, so an expired entry that a concurrent query has replaced isn't mistaken for
+// the entry that replaced it.
+func (s *Server) deleteIfExpired(key cacheKey) {
+	s.cacheLock.Lock()
+	defer s.cacheLock.Unlock()
+	v, ok := s.cache.Load(key)
+	if !ok {
+		return
+	}
+	dv := v.(*cacheEntry)
+	select {
+	case <-dv.wait:
+		if dv.expired() {
+			s.cache.Delete(key)
+		}
+	default:
+	}
+}
+
+// replaceEntry replaces the entry with the given key with the given entry.
+func (s *Server) replaceEntry(key cacheKey, dv *cacheEntry) {
+	s.cacheLock.Lock()
+	s.cache.Store(key, dv)
+	s.cacheLock.Unlock()
+}
+
+// deleteEntry removes the given entry from the cache, unless it has been replaced.
+func (s *Server) deleteEntry(key cacheKey, dv *cacheEntry) {
+	s.cacheLock.Lock()
+	defer s.cacheLock.Unlock()
+	if v, ok := s.cache.Load(key); ok && v == dv {
+		s.cache.Delete(key)
+	}
+}
+
+// Stats returns the request and cache statistics of this server.
+func (s *Server) Stats() *rpc.DNSStats {
+	stats := &rpc.DNSStats{
+		RequestCount: atomic.LoadInt64(&s.requestCount),
+		CacheHits:    atomic.LoadInt64(&s.cacheHits),
+		CacheMisses:  atomic.LoadInt64(&s.cacheMisses),
+	}
+	s.cache.Range(func(_, v interface{}) bool {
+		dv := v.(*cacheEntry)
+		select {
+		case <-dv.wait:
+			if !dv.expired() {
+				stats.CacheEntries++
+				if dv.negative() {
+					stats.NegativeCacheEntries++
+				}
+			}
+		default:
+			// still resolving
+		}
+		return true
+	})
+	return stats
+}
+
 // splitToUDPAddr splits the given address into an UDPAddr. It's
 // an  error if the address is based on a hostname rather than an IP.
 func splitToUDPAddr(netAddr net.Addr) (*net.UDPAddr, error) {
@@ -292,62 +551,83 @@ func (s *Server) RequestCount() int {
 	return int(atomic.LoadInt64(&s.requestCount))
 }
 
-func copyRRs(rrs []dns.RR, qType uint16) []dns.RR {
+// copyRRs returns a deep copy of the given records, with a TTL that is no larger than the given ttl.
+func copyRRs(rrs dnsproxy.RRs, ttl uint32) dnsproxy.RRs {
 	if len(rrs) == 0 {
 		return rrs
 	}
-	cp := make([]dns.RR, 0, len(rrs))
-	for _, rr := range rrs {
-		if rr.Header().Rrtype == qType {
-			cp = append(cp, dns.Copy(rr))
+	cp := make(dnsproxy.RRs, len(rrs))
+	for i, rr := range rrs {
+		rr = dns.Copy(rr)
+		if hdr := rr.Header(); hdr.Ttl > ttl {
+			hdr.Ttl = ttl
 		}
+		cp[i] = rr
 	}
 	return cp
 }
 
+// cachedAnswer returns a copy of the answer of the given entry. The TTL of the records is decreased
+// by the time that they have been cached.
+func cachedAnswer(dv *cacheEntry) dnsproxy.RRs {
+	ttl := uint32(dnsTTL)
+	if remain := uint32(math.Ceil(time.Until(dv.expires).Seconds())); remain < ttl {
+		ttl = remain
+	}
+	return copyRRs(dv.answer, ttl)
+}
+
 // resolveThruCache resolves the given query by first performing a cache lookup. If a cached
 // entry is found that hasn't expired, it's returned. If not, this function will call
 // resolveQuery() to resolve and store in the case.
-func (s *Server) resolveThruCache(q *dns.Question) ([]dns.RR, error) {
-	newDv := &cacheEntry{wait: make(chan struct{}), created: time.Now()}
-	if v, loaded := s.cache.LoadOrStore(q.Name, newDv); loaded {
+func (s *Server) resolveThruCache(c context.Context, q *dns.Question) (dnsproxy.RRs, int, error) {
+	key := cacheKey{name: q.Name, qType: q.Qtype}
+	newDv := &cacheEntry{wait: make(chan struct{})}
+	if v, loaded := s.cache.LoadOrStore(key, newDv); loaded {
 		oldDv := v.(*cacheEntry)
 		if atomic.LoadInt32(&s.recursive) == 2 && atomic.LoadInt32(&oldDv.currentQType) == int32(q.Qtype) {
 			// We have to assume that this is a recursion from the cluster.
-			return nil, nil
+			return nil, dns.RcodeNameError, nil
 		}
 		<-oldDv.wait
 		if !oldDv.expired() {
-			return copyRRs(oldDv.answer, q.Qtype), nil
+			atomic.AddInt64(&s.cacheHits, 1)
+			setCached(c)
+			return cachedAnswer(oldDv), oldDv.rCode, nil
 		}
-		s.cache.Store(q.Name, newDv)
+		s.replaceEntry(key, newDv)
 	}
-	return s.resolveQuery(q, newDv)
+	atomic.AddInt64(&s.cacheMisses, 1)
+	return s.resolveQuery(c, q, newDv)
 }
 
 // resolveWithRecursionCheck is a special version of resolveThruCache which is only used until the
 // recursionCheck query has completed, and it has been determined whether a query that is propagated
 // to the cluster will recurse back to this resolver or not.
-func (s *Server) resolveWithRecursionCheck(q *dns.Question) ([]dns.RR, error) {
-	newDv := &cacheEntry{wait: make(chan struct{}), created: time.Now()}
-	if v, loaded := s.cache.LoadOrStore(q.Name, newDv); loaded {
+func (s *Server) resolveWithRecursionCheck(c context.Context, q *dns.Question) (dnsproxy.RRs, int, error) {
+	key := cacheKey{name: q.Name, qType: q.Qtype}
+	newDv := &cacheEntry{wait: make(chan struct{})}
+	if v, loaded := s.cache.LoadOrStore(key, newDv); loaded {
 		oldDv := v.(*cacheEntry)
 		if atomic.LoadInt32(&oldDv.currentQType) == int32(q.Qtype) {
 			if q.Name == recursionCheck {
 				atomic.StoreInt32(&s.recursive, 2)
 			}
 			if atomic.LoadInt32(&s.recursive) != 1 {
-				return nil, nil
+				return nil, dns.RcodeNameError, nil
 			}
 		}
 		<-oldDv.wait
 		if !oldDv.expired() {
-			return copyRRs(oldDv.answer, q.Qtype), nil
+			atomic.AddInt64(&s.cacheHits, 1)
+			setCached(c)
+			return cachedAnswer(oldDv), oldDv.rCode, nil
 		}
-		s.cache.Store(q.Name, newDv)
+		s.replaceEntry(key, newDv)
 	}
+	atomic.AddInt64(&s.cacheMisses, 1)
 
-	answer, err := s.resolveQuery(q, newDv)
+	answer, rCode, err := s.resolveQuery(c, q, newDv)
 	if q.Name == recursionCheck {
 		if atomic.LoadInt32(&s.recursive) == 2 {
 			dlog.Debug(s.ctx, "DNS resolver is recursive")
@@ -357,7 +637,7 @@ func (s *Server) resolveWithRecursionCheck(q *dns.Question) ([]dns.RR, error) {
 		}
 		s.cacheResolve = s.resolveThruCache
 	}
-	return answer, err
+	return answer, rCode, err
 }
 
 // dfs is a func that implements the fmt.Stringer interface. Used in log statements to ensure
@@ -394,6 +674,7 @@ func (s *Server) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 
 	q := &r.Question[0]
 	atomic.AddInt64(&s.requestCount, 1)
+	start := time.Now()
 
 	answerString := func(a []dns.RR) string {
 		if a == nil {
@@ -410,8 +691,10 @@ func (s *Server) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 	}
 
 	qts := dns.TypeToString[q.Qtype]
-	answer, err := s.cacheResolve(q)
+	qi := &queryInfo{}
+	answer, rCode, err := s.cacheResolve(withQueryInfo(c, qi), q)
 	var rc int
+	fallback := false
 	var pfx dfs = func() string { return "" }
 	var txt dfs = func() string { return "" }
 	var rct dfs = func() string { return dns.RcodeToString[rc] }
@@ -421,9 +704,10 @@ func (s *Server) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 	defer func() {
 		dlog.Debugf(c, "%s%-6s %s -> %s %s", pfx, qts, q.Name, rct, txt)
 		_ = w.WriteMsg(msg)
+		s.logQuery(start, q, qi, fallback, msg, err)
 	}()
 
-	if err == nil && answer != nil {
+	if err == nil && rCode == dns.RcodeSuccess {
 		rc = dns.RcodeSuccess
 		msg = new(dns.Msg)
 		msg.SetReply(r)
@@ -442,7 +726,7 @@ func (s *Server) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 	// fallback DNS-server.
 	if s.fallbackPool == nil || strings.HasPrefix(q.Name, recursionCheck) || strings.HasSuffix(q.Name, s.clusterDomain) {
 		if err == nil {
-			rc = dns.RcodeNameError
+			rc = rCode
 		} else {
 			rc = dns.RcodeServerFailure
 			if errors.Is(err, context.DeadlineExceeded) {
@@ -456,6 +740,7 @@ func (s *Server) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 		return
 	}
 
+	fallback = true
 	pfx = func() string { return fmt.Sprintf("(%s) ", s.fallbackPool.RemoteAddr()) }
 	dc := &dns.Client{Net: "udp", Timeout: s.config.LookupTimeout.AsDuration()}
 	msg, _, err = s.fallbackPool.Exchange(c, dc, r)
@@ -483,55 +768,63 @@ func (s *Server) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 // keep this low to avoid such caching.
 const dnsTTL = 4
 
-func (s *Server) resolveQuery(q *dns.Question, dv *cacheEntry) ([]dns.RR, error) {
+func (s *Server) resolveQuery(c context.Context, q *dns.Question, dv *cacheEntry) (dnsproxy.RRs, int, error) {
 	atomic.StoreInt32(&dv.currentQType, int32(q.Qtype))
 	defer func() {
 		atomic.StoreInt32(&dv.currentQType, int32(dns.TypeNone))
 		close(dv.wait)
 	}()
 
-	var err error
-	switch q.Qtype {
-	case dns.TypeA, dns.TypeAAAA:
-		var ips []net.IP
-		if ips, err = s.resolve(s.ctx, q.Name); err != nil || len(ips) == 0 {
-			break
-		}
-		answer := make([]dns.RR, 0, len(ips))
-		for _, ip := range ips {
-			var rr dns.RR
-			if ip4 := ip.To4(); ip4 != nil {
-				rr = &dns.A{
-					Hdr: dns.RR_Header{Name: q.Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: dnsTTL},
-					A:   ip4,
-				}
-			} else {
-				rr = &dns.AAAA{
-					Hdr:  dns.RR_Header{Name: q.Name, Rrtype: dns.TypeAAAA, Class: dns.ClassINET, Ttl: dnsTTL},
-					AAAA: ip,
-				}
-			}
-			answer = append(answer, rr)
-		}
-		dv.answer = answer
-	default:
-		var ips []net.IP
-		if ips, err = s.resolve(s.ctx, q.Name); err != nil {
-			break
-		}
-		if len(ips) > 0 {
+	answer, rCode, err := s.resolve(c, q)
+	if err != nil {
+		rCode = dns.RcodeServerFailure
+		answer = nil
+	} else if rCode == dns.RcodeSuccess {
+		if answer == nil {
 			// a reply exists, but for another type, so our reply here is EMPTY
-			dv.answer = []dns.RR{}
+			answer = dnsproxy.RRs{}
 		}
+	} else {
+		answer = nil
+	}
+	dv.answer = answer
+	dv.rCode = rCode
+	ttl := time.Duration(0)
+	if err == nil {
+		ttl = s.cacheTTL(answer, rCode)
 	}
-	if err != nil || len(dv.answer) == 0 {
-		s.cache.Delete(q.Name) // Don't cache unless the entry is found.
+	if ttl > 0 {
+		dv.expires = time.Now().Add(ttl)
+	} else {
+		s.deleteEntry(cacheKey{name: q.Name, qType: q.Qtype}, dv)
 	}
+	s.purgeExpired()
 
 	// Return a result for the correct query type. The result will be nil (nxdomain) if nothing was found. It might
 	// also be empty if no RRs were found for the given query type and that is OK.
 	// See https://datatracker.ietf.org/doc/html/rfc4074#section-3
-	return copyRRs(dv.answer, q.Qtype), err
+	return copyRRs(answer, dnsTTL), rCode, err
+}
+
+// cacheTTL returns the time that the given answer can be cached. Found records are cached no longer
+// than the smallest TTL of the records, and no longer than the configured cache TTL. Names, or query
+// types, that weren't found are cached for the configured negative cache TTL. Other response codes
+// aren't cached.
+func (s *Server) cacheTTL(answer dnsproxy.RRs, rCode int) time.Duration {
+	switch {
+	case rCode == dns.RcodeSuccess && len(answer) > 0:
+		ttl := s.config.CacheTtl.AsDuration()
+		for _, rr := range answer {
+			if rrTTL := time.Duration(rr.Header().Ttl) * time.Second; rrTTL < ttl {
+				ttl = rrTTL
+			}
+		}
+		return ttl
+	case rCode == dns.RcodeSuccess, rCode == dns.RcodeNameError:
+		return s.config.NegativeCacheTtl.AsDuration()
+	default:
+		return 0
+	}
 }
 
 // Run starts the DNS server(s) and waits for them to end
@@ -169,7 +169,7 @@ func (s *Server) Worker(c context.Context, dev *vif.Device, configureDNS func(ne
 		for domain := range s.domains {
 			_ = os.Remove(domainResolverFile(resolverDirName, domain))
 		}
-		s.flushDNS()
+		s.Flush()
 	}()
 
 	// Start local DNS server
@@ -263,7 +263,7 @@ func (s *Server) updateResolverFiles(c context.Context, resolverDirName, resolve
 	if err = rf.write(resolverFileName); err != nil {
 		return err
 	}
-	s.flushDNS()
+	s.Flush()
 	return nil
 }
 
@@ -11,6 +11,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/miekg/dns"
+
+	"github.com/TinderBackend/telepresence/v2/pkg/dnsproxy"
 	"github.com/TinderBackend/telepresence/v2/pkg/vif"
 	"github.com/datawire/dlib/dexec"
 	"github.com/datawire/dlib/dgroup"
@@ -76,22 +79,25 @@ func (s *Server) shouldApplySearch(query string) bool {
 // TODO: With the DNS lookups now being done in the cluster, there's only one reason left to have a search path,
 // and that's the local-only intercepts which means that using search-paths really should be limited to that
 // use-case.
-func (s *Server) resolveInSearch(c context.Context, query string) ([]net.IP, error) {
-	query = strings.ToLower(query)
+func (s *Server) resolveInSearch(c context.Context, q *dns.Question) (dnsproxy.RRs, int, error) {
+	query := strings.ToLower(q.Name)
 	query = strings.TrimSuffix(query, tel2SubDomainDot)
 
-	if !s.shouldDoClusterLookup(query) {
-		return nil, nil
+	if _, ok := s.overrides[query]; ok {
+		return s.lookupInCluster(c, q, query)
+	}
+	if !s.shouldDoClusterLookup(query, q.Qtype) {
+		return nil, dns.RcodeNameError, nil
 	}
 
 	if s.shouldApplySearch(query) {
 		for _, sp := range s.search {
-			if ips, err := s.resolveInCluster(c, query+sp); err != nil || len(ips) > 0 {
-				return ips, err
+			if answer, rCode, err := s.lookupInCluster(c, q, query+sp); err != nil || len(answer) > 0 {
+				return answer, rCode, err
 			}
 		}
 	}
-	return s.resolveInCluster(c, query)
+	return s.resolveInCluster(c, q)
 }
 
 func (s *Server) runOverridingServer(c context.Context, dev *vif.Device) error {
@@ -166,7 +172,7 @@ func (s *Server) runOverridingServer(c context.Context, dev *vif.Device) error {
 			s.namespaces = namespaces
 			s.search = search
 			s.domainsLock.Unlock()
-			s.flushDNS()
+			s.Flush()
 			return nil
 		}, dev)
 		return s.Run(c, serverStarted, listeners, pool, s.resolveInSearch)
@@ -186,9 +192,9 @@ func (s *Server) runOverridingServer(c context.Context, dev *vif.Device) error {
 			defer func() {
 				c := context.Background()
 				unrouteDNS(c)
-				s.flushDNS()
+				s.Flush()
 			}()
-			s.flushDNS()
+			s.Flush()
 			<-serverDone // Stay alive until DNS server is done
 		}
 		return nil
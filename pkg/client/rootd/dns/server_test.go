@@ -0,0 +1,273 @@
+package dns
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/daemon"
+	"github.com/TinderBackend/telepresence/v2/pkg/dnsproxy"
+	"github.com/datawire/dlib/dlog"
+)
+
+func TestReverseIP(t *testing.T) {
+	tests := map[string]string{
+		"5.0.1.10.in-addr.arpa.": "10.1.0.5",
+		"b.a.9.8.7.6.5.0.4.0.0.0.3.0.0.0.2.0.0.0.1.0.0.0.0.0.0.0.1.2.3.4.ip6.arpa.": "4321:0:1:2:3:4:567:89ab",
+	}
+	for name, ip := range tests {
+		assert.Equal(t, net.ParseIP(ip).String(), reverseIP(name).String(), name)
+	}
+	assert.Nil(t, reverseIP("0.1.10.in-addr.arpa."))
+	assert.Nil(t, reverseIP("x.0.1.10.in-addr.arpa."))
+	assert.Nil(t, reverseIP("example.com."))
+}
+
+func TestResolveInCluster(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+
+	var lookups []string
+	zone := map[uint16]map[string]string{
+		dns.TypeSRV: {"_grpc._tcp.echo.default": "_grpc._tcp.echo.default. 30 IN SRV 0 100 8080 echo-0.echo.default.svc.cluster.local."},
+		dns.TypePTR: {"5.0.1.10.in-addr.arpa": "5.0.1.10.in-addr.arpa. 30 IN PTR echo-0.echo.default.svc.cluster.local."},
+		dns.TypeA:   {"echo.default": "echo.default. 30 IN A 10.1.0.5"},
+	}
+	lookup := func(_ context.Context, qType uint16, name string) (dnsproxy.RRs, int, error) {
+		lookups = append(lookups, name)
+		if s, ok := zone[qType][name]; ok {
+			rr, err := dns.NewRR(s)
+			if err != nil {
+				return nil, dns.RcodeServerFailure, err
+			}
+			return dnsproxy.RRs{rr}, dns.RcodeSuccess, nil
+		}
+		for _, names := range zone {
+			if _, ok := names[name]; ok {
+				return dnsproxy.RRs{}, dns.RcodeSuccess, nil
+			}
+		}
+		return nil, dns.RcodeNameError, nil
+	}
+
+	s := NewServer(nil, lookup)
+	s.ctx = ctx
+	s.resolve = s.resolveInCluster
+	s.cacheResolve = s.resolveThruCache
+	_, sn, err := net.ParseCIDR("10.1.0.0/16")
+	require.NoError(t, err)
+	s.SetClusterSubnets([]*net.IPNet{sn})
+
+	resolve := func(qType uint16, name string) (dnsproxy.RRs, int) {
+		t.Helper()
+		rrs, rCode, err := s.cacheResolve(ctx, &dns.Question{Name: name, Qtype: qType, Qclass: dns.ClassINET})
+		require.NoError(t, err)
+		return rrs, rCode
+	}
+
+	// Records are returned with the name of the question, and a TTL that discourages caching
+	rrs, rCode := resolve(dns.TypeSRV, "_grpc._tcp.echo.default.tel2-search.")
+	assert.Equal(t, dns.RcodeSuccess, rCode)
+	require.Len(t, rrs, 1)
+	assert.Equal(t, "_grpc._tcp.echo.default.tel2-search.", rrs[0].Header().Name)
+	assert.Equal(t, uint32(dnsTTL), rrs[0].Header().Ttl)
+
+	// The cache is per query type
+	rrs, rCode = resolve(dns.TypeA, "echo.default.")
+	assert.Equal(t, dns.RcodeSuccess, rCode)
+	require.Len(t, rrs, 1)
+	assert.Equal(t, "10.1.0.5", rrs[0].(*dns.A).A.String())
+	rrs, rCode = resolve(dns.TypeTXT, "echo.default.")
+	assert.Equal(t, dns.RcodeSuccess, rCode)
+	assert.NotNil(t, rrs)
+	assert.Empty(t, rrs)
+	lookups = nil
+	_, _ = resolve(dns.TypeA, "echo.default.")
+	assert.Empty(t, lookups, "expected a cached answer")
+
+	// Reverse lookups are dispatched to the cluster for the IPs of the cluster only
+	rrs, rCode = resolve(dns.TypePTR, "5.0.1.10.in-addr.arpa.")
+	assert.Equal(t, dns.RcodeSuccess, rCode)
+	require.Len(t, rrs, 1)
+	assert.Equal(t, "echo-0.echo.default.svc.cluster.local.", rrs[0].(*dns.PTR).Ptr)
+	lookups = nil
+	rrs, rCode = resolve(dns.TypePTR, "5.0.2.10.in-addr.arpa.")
+	assert.Equal(t, dns.RcodeNameError, rCode)
+	assert.Nil(t, rrs)
+	assert.Empty(t, lookups)
+
+	rrs, rCode = resolve(dns.TypeAAAA, "localhost.")
+	assert.Equal(t, dns.RcodeSuccess, rCode)
+	require.Len(t, rrs, 1)
+	assert.Equal(t, "::1", rrs[0].(*dns.AAAA).AAAA.String())
+}
+
+func TestOverrides(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+
+	lookup := func(_ context.Context, qType uint16, name string) (dnsproxy.RRs, int, error) {
+		if qType == dns.TypeA && name == "payments-v2.default" {
+			rr, err := dns.NewRR("payments-v2.default. 30 IN A 10.1.0.7")
+			return dnsproxy.RRs{rr}, dns.RcodeSuccess, err
+		}
+		return nil, dns.RcodeNameError, nil
+	}
+	s := NewServer(&rpc.DNSConfig{Overrides: map[string]string{
+		"Payments.default": "127.0.0.1",
+		"orders.default":   "payments-v2.default",
+		"billing.default":  "orders.default",
+		"example.com":      "::1",
+		"loop1.default":    "loop2.default",
+		"loop2.default":    "loop1.default",
+	}}, lookup)
+	s.ctx = ctx
+	s.resolve = s.resolveInCluster
+
+	resolve := func(qType uint16, name string) (dnsproxy.RRs, int, error) {
+		return s.resolveThruCache(ctx, &dns.Question{Name: name, Qtype: qType, Qclass: dns.ClassINET})
+	}
+
+	// A name mapped to an IP
+	rrs, rCode, err := resolve(dns.TypeA, "payments.default.")
+	require.NoError(t, err)
+	assert.Equal(t, dns.RcodeSuccess, rCode)
+	require.Len(t, rrs, 1)
+	assert.Equal(t, "127.0.0.1", rrs[0].(*dns.A).A.String())
+	rrs, rCode, err = resolve(dns.TypeAAAA, "payments.default.")
+	require.NoError(t, err)
+	assert.Equal(t, dns.RcodeSuccess, rCode)
+	assert.Empty(t, rrs)
+
+	// Overrides apply also to names that are excluded from cluster lookups
+	rrs, _, err = resolve(dns.TypeAAAA, "example.com.")
+	require.NoError(t, err)
+	require.Len(t, rrs, 1)
+	assert.Equal(t, "::1", rrs[0].(*dns.AAAA).AAAA.String())
+
+	// An alias of an alias, resolved in the cluster
+	rrs, rCode, err = resolve(dns.TypeA, "billing.default.")
+	require.NoError(t, err)
+	assert.Equal(t, dns.RcodeSuccess, rCode)
+	require.Len(t, rrs, 3)
+	assert.Equal(t, "orders.default.", rrs[0].(*dns.CNAME).Target)
+	assert.Equal(t, "payments-v2.default.", rrs[1].(*dns.CNAME).Target)
+	assert.Equal(t, "10.1.0.7", rrs[2].(*dns.A).A.String())
+
+	_, _, err = resolve(dns.TypeA, "loop1.default.")
+	assert.Error(t, err)
+}
+
+func TestCache(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+
+	lookups := 0
+	zone := map[string]string{
+		"short.default": "short.default. 2 IN A 10.1.0.6",
+		"long.default":  "long.default. 300 IN A 10.1.0.7",
+	}
+	lookup := func(_ context.Context, qType uint16, name string) (dnsproxy.RRs, int, error) {
+		lookups++
+		if s, ok := zone[name]; ok && qType == dns.TypeA {
+			rr, err := dns.NewRR(s)
+			return dnsproxy.RRs{rr}, dns.RcodeSuccess, err
+		}
+		return nil, dns.RcodeNameError, nil
+	}
+	s := NewServer(&rpc.DNSConfig{CacheTtl: durationpb.New(time.Minute)}, lookup)
+	s.ctx = ctx
+	s.resolve = s.resolveInCluster
+
+	resolve := func(name string) (dnsproxy.RRs, int) {
+		t.Helper()
+		rrs, rCode, err := s.resolveThruCache(ctx, &dns.Question{Name: name, Qtype: dns.TypeA, Qclass: dns.ClassINET})
+		require.NoError(t, err)
+		return rrs, rCode
+	}
+	expires := func(name string) time.Duration {
+		t.Helper()
+		v, ok := s.cache.Load(cacheKey{name: name, qType: dns.TypeA})
+		require.True(t, ok, "expected a cache entry for %s", name)
+		return time.Until(v.(*cacheEntry).expires)
+	}
+
+	// Answers are cached no longer than the TTL of the records, and no longer than the configured cache TTL
+	rrs, _ := resolve("long.default.")
+	require.Len(t, rrs, 1)
+	assert.Equal(t, uint32(dnsTTL), rrs[0].Header().Ttl)
+	assert.InDelta(t, time.Minute, expires("long.default."), float64(time.Second))
+	_, _ = resolve("short.default.")
+	assert.InDelta(t, 2*time.Second, expires("short.default."), float64(time.Second))
+	rrs, _ = resolve("short.default.")
+	require.Len(t, rrs, 1)
+	assert.LessOrEqual(t, rrs[0].Header().Ttl, uint32(2))
+	assert.Equal(t, 2, lookups)
+
+	// Names that aren't found are cached for the negative cache TTL
+	_, rCode := resolve("missing.default.")
+	assert.Equal(t, dns.RcodeNameError, rCode)
+	rrs, rCode = resolve("missing.default.")
+	assert.Equal(t, dns.RcodeNameError, rCode)
+	assert.Nil(t, rrs)
+	assert.Equal(t, 3, lookups)
+	assert.InDelta(t, defaultNegativeCacheTTL, expires("missing.default."), float64(time.Second))
+
+	stats := s.Stats()
+	assert.Equal(t, int64(2), stats.CacheHits)
+	assert.Equal(t, int64(3), stats.CacheMisses)
+	assert.Equal(t, int64(3), stats.CacheEntries)
+	assert.Equal(t, int64(1), stats.NegativeCacheEntries)
+
+	// Expired entries are looked up again
+	v, _ := s.cache.Load(cacheKey{name: "missing.default.", qType: dns.TypeA})
+	v.(*cacheEntry).expires = time.Now()
+	_, _ = resolve("missing.default.")
+	assert.Equal(t, 4, lookups)
+
+	s.Flush()
+	stats = s.Stats()
+	assert.Zero(t, stats.CacheEntries)
+	_, _ = resolve("long.default.")
+	assert.Equal(t, 5, lookups)
+}
+
+func TestCacheDeleteReplaced(t *testing.T) {
+	s := NewServer(nil, nil)
+	key := cacheKey{name: "replaced.default.", qType: dns.TypeA}
+	resolved := func(expires time.Time) *cacheEntry {
+		dv := &cacheEntry{wait: make(chan struct{}), expires: expires}
+		close(dv.wait)
+		return dv
+	}
+	cached := func() *cacheEntry {
+		v, ok := s.cache.Load(key)
+		if !ok {
+			return nil
+		}
+		return v.(*cacheEntry)
+	}
+
+	// An expired entry is removed
+	s.cache.Store(key, resolved(time.Now()))
+	s.deleteIfExpired(key)
+	assert.Nil(t, cached())
+
+	// An expired entry that a concurrent query has replaced is never mistaken for the entry that replaced it
+	expired := resolved(time.Now())
+	s.cache.Store(key, expired)
+	pending := &cacheEntry{wait: make(chan struct{})}
+	s.replaceEntry(key, pending)
+	s.deleteIfExpired(key)
+	s.deleteEntry(key, expired)
+	assert.Same(t, pending, cached())
+
+	fresh := resolved(time.Now().Add(time.Minute))
+	s.replaceEntry(key, fresh)
+	s.deleteIfExpired(key)
+	s.deleteEntry(key, pending)
+	assert.Same(t, fresh, cached())
+}
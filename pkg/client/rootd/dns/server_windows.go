@@ -47,7 +47,7 @@ func (s *Server) updateRouterDNS(c context.Context, paths []string, dev *vif.Dev
 	s.search = search
 	s.domainsLock.Unlock()
 	err := dev.SetDNS(c, s.config.RemoteIp, search)
-	s.flushDNS()
+	s.Flush()
 	if err != nil {
 		return fmt.Errorf("failed to set DNS: %w", err)
 	}
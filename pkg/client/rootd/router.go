@@ -96,9 +96,19 @@ func (s *session) handlePacket(c context.Context, data *buffer.Data) {
 			if data == nil {
 				return
 			}
-			v4Hdr = data.Buf()
+			ipHdr = ip.V4Header(data.Buf())
 		}
-	} // TODO: similar for ipv6 using segments
+	} else {
+		v6Hdr := ipHdr.(ip.V6Header)
+		if v6Hdr.IsFragment() {
+			dlog.Debug(c, "Packet concat")
+			data = v6Hdr.ConcatFragments(data, s.v6FragmentCache)
+			if data == nil {
+				return
+			}
+			ipHdr = ip.V6Header(data.Buf())
+		}
+	}
 
 	switch ipHdr.L4Protocol() {
 	case ipproto.TCP:
@@ -123,10 +133,13 @@ func (s *session) handlePacket(c context.Context, data *buffer.Data) {
 		}
 		data = nil
 		s.udp(c, dg)
-	case ipproto.ICMP:
-	case ipproto.ICMPV6:
+	case ipproto.ICMP, ipproto.ICMPV6:
 		pkt := icmp.PacketFromData(ipHdr, data)
 		dlog.Tracef(c, "<- TUN %s", pkt)
+		if icmp.IsEchoRequest(pkt) {
+			data = nil
+			s.icmp(c, pkt)
+		}
 	default:
 		// An L4 protocol that we don't handle.
 		dlog.Tracef(c, "Unhandled protocol %d", ipHdr.L4Protocol())
@@ -219,6 +232,24 @@ func (s *session) udp(c context.Context, dg udp.Datagram) {
 	uh.(udp.DatagramHandler).HandleDatagram(c, dg)
 }
 
+func (s *session) icmp(c context.Context, pkt icmp.Packet) {
+	ipHdr := pkt.IPHeader()
+	connID := tunnel.NewConnID(ipHdr.L4Protocol(), ipHdr.Source(), ipHdr.Destination(), icmp.EchoID(pkt), 0)
+	eh, _, err := s.handlers.GetOrCreate(c, connID, func(c context.Context, remove func()) (tunnel.Handler, error) {
+		stream, err := s.streamCreator(connID)(c)
+		if err != nil {
+			return nil, err
+		}
+		return icmp.NewEchoHandler(stream, vifWriter{s.dev}, connID, remove), nil
+	})
+	if err != nil {
+		dlog.Error(c, err)
+		pkt.Release()
+		return
+	}
+	eh.(icmp.PacketHandler).HandlePacket(c, pkt)
+}
+
 func (s *session) streamCreator(id tunnel.ConnID) tcp.StreamCreator {
 	return func(c context.Context) (tunnel.Stream, error) {
 		dlog.Debugf(c, "Opening tunnel for id %s", id)
@@ -227,6 +258,10 @@ func (s *session) streamCreator(id tunnel.ConnID) tcp.StreamCreator {
 			return nil, err
 		}
 		tc := client.GetConfig(c).Timeouts
-		return tunnel.NewClientStream(c, ct, id, s.session.SessionId, tc.Get(client.TimeoutRoundtripLatency), tc.Get(client.TimeoutEndpointDial))
+		ts, err := tunnel.NewClientStream(c, ct, id, s.session.SessionId, tc.Get(client.TimeoutRoundtripLatency), tc.Get(client.TimeoutEndpointDial))
+		if err != nil {
+			return nil, err
+		}
+		return tunnel.WithFaults(ts, s.faultsFor(id.Destination())), nil
 	}
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/TinderBackend/telepresence/rpc/v2/manager"
 	"github.com/TinderBackend/telepresence/v2/pkg/client"
 	"github.com/TinderBackend/telepresence/v2/pkg/client/logging"
+	"github.com/TinderBackend/telepresence/v2/pkg/client/rootd/dns"
 	"github.com/TinderBackend/telepresence/v2/pkg/client/scout"
 	"github.com/TinderBackend/telepresence/v2/pkg/filelocation"
 	"github.com/TinderBackend/telepresence/v2/pkg/log"
@@ -195,6 +196,56 @@ func (d *service) SetLogLevel(ctx context.Context, request *manager.LogLevelRequ
 	return &empty.Empty{}, logging.SetAndStoreTimedLevel(ctx, d.timedLogLevel, request.LogLevel, duration, ProcessName)
 }
 
+func (d *service) FlushDNS(ctx context.Context, _ *empty.Empty) (*empty.Empty, error) {
+	dlog.Debug(ctx, "Received gRPC FlushDNS")
+	err := d.withSession(ctx, func(ctx context.Context, session *session) error {
+		session.dnsServer.Flush()
+		return nil
+	})
+	return &empty.Empty{}, err
+}
+
+func (d *service) GetDNSStats(ctx context.Context, _ *empty.Empty) (stats *rpc.DNSStats, err error) {
+	err = d.withSession(ctx, func(ctx context.Context, session *session) error {
+		stats = session.dnsServer.Stats()
+		return nil
+	})
+	return stats, err
+}
+
+func (d *service) TraceDNS(request *rpc.TraceDNSRequest, stream rpc.Daemon_TraceDNSServer) error {
+	ctx := stream.Context()
+	dlog.Debug(ctx, "Received gRPC TraceDNS")
+	var dnsServer *dns.Server
+	err := d.withSession(ctx, func(_ context.Context, session *session) error {
+		dnsServer = session.dnsServer
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	recent, queries, unsubscribe := dnsServer.SubscribeQueries(int(request.Tail))
+	defer unsubscribe()
+	for _, q := range recent {
+		if err := stream.Send(q); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case q, ok := <-queries:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(q); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 func (d *service) configReload(c context.Context) error {
 	return client.Watch(c, func(c context.Context) error {
 		return logging.ReloadDaemonConfig(c, true)
@@ -28,6 +28,7 @@ import (
 	"github.com/TinderBackend/telepresence/v2/pkg/tunnel"
 	"github.com/TinderBackend/telepresence/v2/pkg/vif"
 	"github.com/TinderBackend/telepresence/v2/pkg/vif/buffer"
+	"github.com/TinderBackend/telepresence/v2/pkg/vif/ip"
 	"github.com/TinderBackend/telepresence/v2/pkg/vif/routing"
 	"github.com/datawire/dlib/dcontext"
 	"github.com/datawire/dlib/dgroup"
@@ -35,6 +36,12 @@ import (
 	"github.com/datawire/dlib/dtime"
 )
 
+// Limits for the reassembly of ipv6 fragments. The timeout is the one recommended by RFC 8200.
+const (
+	maxV6FragmentedPackets = 64
+	v6FragmentTimeout      = 60 * time.Second
+)
+
 // session resolves DNS names and routes outbound traffic that is centered around a TUN device. The router is
 // similar to a TUN-to-SOCKS5 but uses a bidirectional gRPC muxTunnel instead of SOCKS when communicating with the
 // traffic-manager. The addresses of the device are derived from IP addresses sent to it from the user
@@ -84,6 +91,9 @@ type session struct {
 	// fragmentMap is when concatenating ipv4 fragments
 	fragmentMap map[uint16][]*buffer.Data
 
+	// v6FragmentCache is used when reassembling ipv6 fragments
+	v6FragmentCache *ip.V6FragmentCache
+
 	// The local dns server
 	dnsServer *dns.Server
 
@@ -104,6 +114,10 @@ type session struct {
 
 	// Subnets configured not to be proxied
 	neverProxySubnets []routing.Route
+
+	// Faults to inject into outbound connections, per subnet
+	outboundFaults []outboundFault
+
 	// Subnets that the router is currently configured with. Managed, and only used in
 	// the refreshSubnets() method.
 	curSubnets      []*net.IPNet
@@ -198,6 +212,32 @@ func convertNeverProxySubnets(c context.Context, ms []*manager.IPNet) []routing.
 	return rs
 }
 
+// outboundFault is a set of faults to inject into the outbound connections to a subnet.
+type outboundFault struct {
+	subnet *net.IPNet
+	faults *manager.FaultSpec
+}
+
+func convertOutboundFaults(c context.Context, ms []*manager.OutboundFault) []outboundFault {
+	fs := make([]outboundFault, 0, len(ms))
+	for _, m := range ms {
+		n := iputil.IPNetFromRPC(m.Subnet)
+		dlog.Infof(c, "Injecting faults %s into outbound connections to %s", tunnel.FormatFaultSpec(m.Faults), n)
+		fs = append(fs, outboundFault{subnet: n, faults: m.Faults})
+	}
+	return fs
+}
+
+// faultsFor returns the faults to inject into outbound connections to the given IP, or nil if there are none.
+func (s *session) faultsFor(ip net.IP) *manager.FaultSpec {
+	for _, of := range s.outboundFaults {
+		if of.subnet.Contains(ip) {
+			return of.faults
+		}
+	}
+	return nil
+}
+
 // newSession returns a new properly initialized session object.
 func newSession(c context.Context, scout *scout.Reporter, mi *rpc.OutboundInfo) (*session, error) {
 	conn, mc, err := connectToManager(c)
@@ -216,35 +256,20 @@ func newSession(c context.Context, scout *scout.Reporter, mi *rpc.OutboundInfo)
 		dev:               dev,
 		handlers:          tunnel.NewPool(),
 		fragmentMap:       make(map[uint16][]*buffer.Data),
+		v6FragmentCache:   ip.NewV6FragmentCache(maxV6FragmentedPackets, v6FragmentTimeout),
 		rndSource:         rand.NewSource(time.Now().UnixNano()),
 		session:           mi.Session,
 		managerClient:     mc,
 		clientConn:        conn,
 		alsoProxySubnets:  convertAlsoProxySubnets(c, mi.AlsoProxySubnets),
 		neverProxySubnets: convertNeverProxySubnets(c, mi.NeverProxySubnets),
+		outboundFaults:    convertOutboundFaults(c, mi.OutboundFaults),
 		proxyCluster:      true,
 	}
 	s.dnsServer = dns.NewServer(mi.Dns, s.clusterLookup)
 	return s, nil
 }
 
-// clusterLookup sends a LookupHost request to the traffic-manager and returns the result
-func (s *session) clusterLookup(ctx context.Context, key string) ([][]byte, error) {
-	dlog.Debugf(ctx, "LookupHost %q", key)
-	s.dnsLookups++
-	r, err := s.managerClient.LookupHost(ctx, &manager.LookupHostRequest{
-		Session: s.session,
-		Host:    key,
-	})
-	if err != nil || len(r.Ips) == 0 {
-		s.dnsFailures++
-	}
-	if err != nil {
-		return nil, err
-	}
-	return r.Ips, nil
-}
-
 func (s *session) getInfo() *rpc.OutboundInfo {
 	info := rpc.OutboundInfo{
 		Session: s.session,
@@ -267,6 +292,13 @@ func (s *session) getInfo() *rpc.OutboundInfo {
 		}
 	}
 
+	if len(s.outboundFaults) > 0 {
+		info.OutboundFaults = make([]*manager.OutboundFault, len(s.outboundFaults))
+		for i, of := range s.outboundFaults {
+			info.OutboundFaults[i] = &manager.OutboundFault{Subnet: iputil.IPNetToRPC(of.subnet), Faults: of.faults}
+		}
+	}
+
 	return &info
 }
 
@@ -422,6 +454,7 @@ func (s *session) onClusterInfo(ctx context.Context, mgrInfo *manager.ClusterInf
 	}
 
 	s.clusterSubnets = subnets
+	s.dnsServer.SetClusterSubnets(subnets)
 	if err := s.refreshSubnets(ctx); err != nil {
 		dlog.Error(ctx, err)
 	}
@@ -466,6 +499,7 @@ func (s *session) run(c context.Context) error {
 	// 3. The routerWorker terminates.
 	g.Go("dns", func(ctx context.Context) error {
 		defer s.stop(c)
+		defer s.dnsServer.CloseQueryLog()
 		return s.dnsServer.Worker(ctx, s.dev, s.configureDNS)
 	})
 	g.Go("router", s.routerWorker)
@@ -9,6 +9,7 @@ import (
 	"sync"
 
 	"github.com/blang/semver"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/TinderBackend/telepresence/v2/pkg/client"
@@ -28,6 +29,9 @@ type Cluster struct {
 	// Main
 	ki kubernetes.Interface
 
+	// Dynamic, used for workloads that are custom resources
+	di dynamic.Interface
+
 	// Current Namespace snapshot, get set by namespace Watcher.
 	// The boolean value indicates if this client is allowed to
 	// watch services and retrieve workloads in the namespace
@@ -139,6 +143,11 @@ func NewCluster(c context.Context, kubeFlags *Config, namespaces []string) (*Clu
 		return nil, err
 	}
 	c = k8sapi.WithK8sInterface(c, cs)
+	di, err := dynamic.NewForConfig(rs)
+	if err != nil {
+		return nil, err
+	}
+	c = k8sapi.WithDynamicInterface(c, di)
 
 	if len(namespaces) == 1 && namespaces[0] == "all" {
 		namespaces = nil
@@ -150,6 +159,7 @@ func NewCluster(c context.Context, kubeFlags *Config, namespaces []string) (*Clu
 		Config:           kubeFlags,
 		mappedNamespaces: namespaces,
 		ki:               cs,
+		di:               di,
 	}
 
 	timedC, cancel := client.GetConfig(c).Timeouts.TimeoutContext(c, client.TimeoutClusterConnect)
@@ -194,5 +204,5 @@ func (kc *Cluster) GetClusterId(ctx context.Context) string {
 }
 
 func (kc *Cluster) WithK8sInterface(c context.Context) context.Context {
-	return k8sapi.WithK8sInterface(c, kc.ki)
+	return k8sapi.WithDynamicInterface(k8sapi.WithK8sInterface(c, kc.ki), kc.di)
 }
@@ -11,9 +11,12 @@ import (
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/datawire/dlib/dlog"
@@ -31,7 +34,7 @@ type Watcher struct {
 	cancel     context.CancelFunc
 	resource   string
 	namespace  string
-	getter     cache.Getter
+	newLW      func(context.Context) cache.ListerWatcher
 	objType    runtime.Object
 	cond       *sync.Cond
 	controller cache.Controller
@@ -60,14 +63,42 @@ func newListerWatcher(c context.Context, getter cache.Getter, resource, namespac
 	return &cache.ListWatch{ListFunc: listFunc, WatchFunc: watchFunc}
 }
 
+func newDynamicListerWatcher(c context.Context, ri dynamic.ResourceInterface) cache.ListerWatcher {
+	listFunc := func(options meta.ListOptions) (runtime.Object, error) {
+		return ri.List(c, options)
+	}
+	watchFunc := func(options meta.ListOptions) (watch.Interface, error) {
+		options.Watch = true
+		return ri.Watch(c, options)
+	}
+	return &cache.ListWatch{ListFunc: listFunc, WatchFunc: watchFunc}
+}
+
 func NewWatcher(resource, namespace string, getter cache.Getter, objType runtime.Object, cond *sync.Cond, equals func(runtime.Object, runtime.Object) bool) *Watcher {
 	return &Watcher{
 		resource:  resource,
 		namespace: namespace,
 		equals:    equals,
-		getter:    getter,
-		objType:   objType,
-		cond:      cond,
+		newLW: func(c context.Context) cache.ListerWatcher {
+			return newListerWatcher(c, getter, resource, namespace)
+		},
+		objType: objType,
+		cond:    cond,
+	}
+}
+
+// NewDynamicWatcher returns a Watcher for a resource that has no typed client, such as a custom resource. The
+// watched objects are *unstructured.Unstructured.
+func NewDynamicWatcher(gvr schema.GroupVersionResource, namespace string, di dynamic.Interface, cond *sync.Cond, equals func(runtime.Object, runtime.Object) bool) *Watcher {
+	return &Watcher{
+		resource:  gvr.Resource,
+		namespace: namespace,
+		equals:    equals,
+		newLW: func(c context.Context) cache.ListerWatcher {
+			return newDynamicListerWatcher(c, di.Resource(gvr).Namespace(namespace))
+		},
+		objType: &unstructured.Unstructured{},
+		cond:    cond,
 	}
 }
 
@@ -143,7 +174,7 @@ func (w *Watcher) startLocked(c context.Context, ready *sync.WaitGroup) {
 	// we get immediate access to the Process function and can skip the ResourceEventHandlerFuncs
 	config := cache.Config{
 		Queue:         fifo,
-		ListerWatcher: newListerWatcher(c, w.getter, w.resource, w.namespace),
+		ListerWatcher: w.newLW(c),
 		Process: func(obj interface{}) error {
 			return w.process(c, obj.(cache.Deltas), eventCh)
 		},
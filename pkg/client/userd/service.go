@@ -3,6 +3,7 @@ package userd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sync"
@@ -66,6 +67,7 @@ type service struct {
 	daemonClient      daemon.DaemonClient
 	loginExecutor     auth.LoginExecutor
 	userNotifications func(context.Context) <-chan string
+	notifications     io.Writer
 	ucn               int64
 
 	scout *scout.Reporter
@@ -108,6 +110,13 @@ func (s *service) LoginExecutor() auth.LoginExecutor {
 	return s.loginExecutor
 }
 
+// Notify sends the given message to the CLI commands that listen to UserNotifications, and displays it
+// in a desktop notification.
+func (s *service) Notify(c context.Context, message string) {
+	fmt.Fprintln(s.notifications, message)
+	Notify(c, message)
+}
+
 // Command returns the CLI sub-command for "connector-foreground"
 func Command(getCommands CommandFactory, daemonServices []DaemonService, sessionServices []trafficmgr.SessionService) *cobra.Command {
 	c := &cobra.Command{
@@ -255,6 +264,7 @@ func run(c context.Context, getCommands CommandFactory, daemonServices []DaemonS
 		managerProxy:      trafficmgr.NewManagerProxy(),
 		loginExecutor:     auth.NewStandardLoginExecutor(cliio, sr),
 		userNotifications: func(ctx context.Context) <-chan string { return cliio.Subscribe(ctx) },
+		notifications:     cliio,
 		timedLogLevel:     log.NewTimedLevel(cfg.LogLevels.UserDaemon.String(), log.SetLevel),
 		getCommands:       getCommands,
 	}
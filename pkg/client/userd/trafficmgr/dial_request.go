@@ -12,6 +12,7 @@ func (tm *TrafficManager) dialRequestWatcher(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	tunnel.DialWaitLoop(ctx, tm.managerClient, dialerStream, tm.sessionInfo.SessionId)
+	// Connections delivered by intercepts that record their traffic are recorded by the dialer
+	tunnel.DialWaitLoop(tunnel.WithConnRecorder(ctx, tm), tm.managerClient, dialerStream, tm.sessionInfo.SessionId)
 	return nil
 }
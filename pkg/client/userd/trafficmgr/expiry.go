@@ -0,0 +1,61 @@
+package trafficmgr
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/TinderBackend/telepresence/rpc/v2/manager"
+)
+
+// expiryWarning is how long before an intercept expires that the user is warned about it.
+var expiryWarning = 5 * time.Minute
+
+// expiryTimer warns the user about an intercept that is about to expire.
+type expiryTimer struct {
+	expiresAt time.Time
+	timer     *time.Timer
+}
+
+// reconcileExpiryTimers ensures that the user is warned shortly before each of the given intercepts that has
+// a time-to-live expires, and notifies the user when an intercept disappears because it has expired.
+func (tm *TrafficManager) reconcileExpiryTimers(ctx context.Context, intercepts []*manager.InterceptInfo) {
+	expiries := make(map[string]time.Time)
+	for _, ii := range intercepts {
+		if ii.ExpiresAt != nil {
+			expiries[ii.Spec.Name] = ii.ExpiresAt.AsTime()
+		}
+	}
+
+	tm.expiryTimers.Range(func(k, v interface{}) bool {
+		name := k.(string)
+		et := v.(*expiryTimer)
+		expiresAt, ok := expiries[name]
+		if ok && expiresAt.Equal(et.expiresAt) {
+			delete(expiries, name)
+			return true
+		}
+		et.timer.Stop()
+		tm.expiryTimers.Delete(name)
+		if !ok && !time.Now().Before(et.expiresAt) {
+			tm.notify(ctx, fmt.Sprintf("Intercept %s has expired and was removed by the traffic-manager", name))
+		}
+		return true
+	})
+
+	for name, expiresAt := range expiries {
+		name, expiresAt := name, expiresAt
+		timer := time.AfterFunc(time.Until(expiresAt)-expiryWarning, func() {
+			if ctx.Err() != nil {
+				// The session has ended
+				return
+			}
+			left := time.Until(expiresAt).Round(time.Second)
+			if left < 0 {
+				left = 0
+			}
+			tm.notify(ctx, fmt.Sprintf("Intercept %s expires in %s", name, left))
+		})
+		tm.expiryTimers.Store(name, &expiryTimer{expiresAt: expiresAt, timer: timer})
+	}
+}
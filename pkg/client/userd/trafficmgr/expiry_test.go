@@ -0,0 +1,49 @@
+package trafficmgr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/datawire/dlib/dlog"
+)
+
+func TestReconcileExpiryTimers(t *testing.T) {
+	ctx, cancel := context.WithCancel(dlog.NewTestContext(t, false))
+	defer cancel()
+
+	messages := make(chan string, 10)
+	tm := &TrafficManager{notify: func(_ context.Context, msg string) { messages <- msg }}
+	intercept := func(name string, expiresIn time.Duration) *manager.InterceptInfo {
+		return &manager.InterceptInfo{
+			Spec:      &manager.InterceptSpec{Name: name},
+			ExpiresAt: timestamppb.New(time.Now().Add(expiresIn)),
+		}
+	}
+	next := func() string {
+		select {
+		case msg := <-messages:
+			return msg
+		case <-time.After(2 * time.Second):
+			return ""
+		}
+	}
+
+	// An intercept that expires within the warning period is warned about at once
+	soon := intercept("soon", time.Minute)
+	later := intercept("later", time.Hour)
+	tm.reconcileExpiryTimers(ctx, []*manager.InterceptInfo{soon, later})
+	assert.Equal(t, "Intercept soon expires in 1m0s", next())
+
+	// Removing an intercept that hasn't expired is silent, but the removal of an expired one is notified
+	expired := intercept("expired", -time.Second)
+	tm.reconcileExpiryTimers(ctx, []*manager.InterceptInfo{expired})
+	assert.Equal(t, "Intercept expired expires in 0s", next())
+	tm.reconcileExpiryTimers(ctx, nil)
+	assert.Equal(t, "Intercept expired has expired and was removed by the traffic-manager", next())
+	assert.Empty(t, messages)
+}
@@ -11,15 +11,14 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
-	apps "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
 	errors2 "k8s.io/apimachinery/pkg/api/errors"
-	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/TinderBackend/telepresence/rpc/v2/manager"
 	"github.com/TinderBackend/telepresence/v2/pkg/client"
+	"github.com/TinderBackend/telepresence/v2/pkg/client/errcat"
 	"github.com/TinderBackend/telepresence/v2/pkg/client/userd/k8s"
 	"github.com/TinderBackend/telepresence/v2/pkg/install"
 	"github.com/TinderBackend/telepresence/v2/pkg/install/helm"
@@ -144,12 +143,32 @@ func (ki *installer) RemoveManagerAndAgents(c context.Context, agentsOnly bool,
 
 // recreates "kubectl rollout restart <obj>" for obj
 func (ki *installer) rolloutRestart(c context.Context, obj k8sapi.Object) error {
-	restartAnnotation := fmt.Sprintf(
-		`{"spec": {"template": {"metadata": {"annotations": {"%srestartedAt": "%s"}}}}}`,
-		install.DomainPrefix,
-		time.Now().Format(time.RFC3339),
-	)
-	return obj.Patch(c, types.StrategicMergePatchType, []byte(restartAnnotation))
+	now := time.Now().Format(time.RFC3339)
+	switch obj.GetKind() {
+	case "Rollout":
+		// Argo Rollouts have their own restart mechanism. A Rollout is a custom resource, so it
+		// doesn't support strategic merge patches
+		return obj.Patch(c, types.MergePatchType, []byte(fmt.Sprintf(`{"spec": {"restartAt": "%s"}}`, now)))
+	case "CronJob":
+		// Affects the jobs that are created from now on
+		restartAnnotation := fmt.Sprintf(
+			`{"spec": {"jobTemplate": {"spec": {"template": {"metadata": {"annotations": {"%srestartedAt": "%s"}}}}}}}`,
+			install.DomainPrefix,
+			now,
+		)
+		return obj.Patch(c, types.StrategicMergePatchType, []byte(restartAnnotation))
+	case "Job":
+		// The pod template of a Job is immutable, but the job controller replaces deleted pods
+		// until the job completes
+		return ki.deleteOwnedPods(c, obj)
+	default:
+		restartAnnotation := fmt.Sprintf(
+			`{"spec": {"template": {"metadata": {"annotations": {"%srestartedAt": "%s"}}}}}`,
+			install.DomainPrefix,
+			now,
+		)
+		return obj.Patch(c, types.StrategicMergePatchType, []byte(restartAnnotation))
+	}
 }
 
 // Finds the Referenced Service in an objects' annotations
@@ -262,6 +281,16 @@ func (ki *installer) ensureInjectedAgent(
 	return nil
 }
 
+// hasAgentContainer returns true if the given pod template has a traffic-agent container.
+func hasAgentContainer(podTpl *core.PodTemplateSpec) bool {
+	for i := range podTpl.Spec.Containers {
+		if podTpl.Spec.Containers[i].Name == install.AgentContainerName {
+			return true
+		}
+	}
+	return false
+}
+
 func useAutoInstall(podTpl *core.PodTemplateSpec) (bool, error) {
 	a := podTpl.ObjectMeta.Annotations
 	webhookInjected := a != nil && a[install.InjectAnnotation] == "enabled"
@@ -278,6 +307,10 @@ type ServiceProps struct {
 	ServicePort        *core.ServicePort
 	Container          *core.Container
 	ContainerPortIndex int
+
+	// Other ports of the Service that map to the same Container, and the indexes of their container ports.
+	AdditionalServicePorts         []*core.ServicePort
+	AdditionalContainerPortIndexes []int
 }
 
 // exploreSvc finds the matching service, its containers, and their ports
@@ -298,6 +331,12 @@ func exploreSvc(c context.Context, portNameOrNumber, svcName string, obj k8sapi.
 		return nil, k8sapi.ObjErrorf(obj, err.Error())
 	}
 
+	// An agent fronts all ports of the service that map to the container, so find the others too.
+	servicePorts, _, containerPortIndexes, err := install.FindMatchingPorts(cns, install.ServicePortIdentifier(servicePort), matchingSvc)
+	if err != nil {
+		return nil, k8sapi.ObjErrorf(obj, err.Error())
+	}
+
 	if err := checkSvcSame(c, obj, svcName, portNameOrNumber); err != nil {
 		msg := fmt.Sprintf(
 			`%s already being used for intercept with a different service
@@ -308,13 +347,59 @@ already exist for this service`, kind, name)
 	}
 
 	return &ServiceProps{
-		Service:            matchingSvc,
-		ServicePort:        servicePort,
-		Container:          container,
-		ContainerPortIndex: containerPortIndex,
+		Service:                        matchingSvc,
+		ServicePort:                    servicePort,
+		Container:                      container,
+		ContainerPortIndex:             containerPortIndex,
+		AdditionalServicePorts:         servicePorts[1:],
+		AdditionalContainerPortIndexes: containerPortIndexes[1:],
 	}, nil
 }
 
+// resolveContainerPorts sets the container port of the given intercept spec and of each of its additional
+// service ports, so that an agent that fronts several ports can tell what ports to intercept.
+func resolveContainerPorts(spec *manager.InterceptSpec, svcprops *ServiceProps, obj k8sapi.Workload) error {
+	cn := svcprops.Container
+	containerPort := func(servicePort *core.ServicePort, containerPortIndex int) (int32, error) {
+		cp, err := takeoverPort(obj, servicePort, cn, containerPortIndex)
+		if err != nil {
+			return 0, err
+		}
+		port := int32(cp.Number)
+		if cn.Name == install.AgentContainerName {
+			// The service port has already been taken over by the agent, so use the app port that the agent forwards to.
+			appPort, ok := install.AgentAppPort(cn, port)
+			if !ok {
+				return 0, k8sapi.ObjErrorf(obj, "the %s doesn't forward port %d", install.AgentContainerName, port)
+			}
+			port = appPort
+		}
+		return port, nil
+	}
+
+	var err error
+	if spec.ContainerPort, err = containerPort(svcprops.ServicePort, svcprops.ContainerPortIndex); err != nil {
+		return err
+	}
+	for _, ip := range spec.ServicePorts {
+		found := false
+		for i, sp := range svcprops.AdditionalServicePorts {
+			if ip.ServicePortIdentifier == sp.Name || ip.ServicePortIdentifier == strconv.Itoa(int(sp.Port)) {
+				if ip.ContainerPort, err = containerPort(sp, svcprops.AdditionalContainerPortIndexes[i]); err != nil {
+					return err
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return k8sapi.ObjErrorf(obj, "service %s has no port %q that maps to container %s",
+				svcprops.Service.Name, ip.ServicePortIdentifier, cn.Name)
+		}
+	}
+	return nil
+}
+
 // EnsureAgent does a lot of things but at a high level it ensures that the traffic agent
 // is installed alongside the proper workload. In doing that, it also ensures that
 // the workload is referenced by a service. Lastly, it returns the service UID
@@ -339,6 +424,14 @@ func (ki *installer) EnsureAgent(
 		return "", "", err
 	}
 
+	if autoInstall && kind == "Job" && !hasAgentContainer(podTemplate) {
+		// The pod template of a Job is immutable, so only the agent injector can add an agent to its pods
+		return "", "", errcat.User.Newf(
+			"unable to add a traffic-agent to Job %s.%s because the pod template of a Job can't be changed. "+
+				"Create the Job with the annotation %s: enabled in its pod template so that the agent is injected",
+			name, namespace, install.InjectAnnotation)
+	}
+
 	if !autoInstall {
 		err := ki.ensureInjectedAgent(c, svcprops.Service, name, namespace, podTemplate, obj)
 		if err != nil {
@@ -425,8 +518,8 @@ func (ki *installer) waitForApply(c context.Context, name, namespace string, obj
 	}
 
 	var err error
-	if rs, ok := k8sapi.ReplicaSetImpl(obj); ok {
-		if err = ki.refreshReplicaSet(c, namespace, rs); err != nil {
+	if _, ok := k8sapi.ReplicaSetImpl(obj); ok {
+		if err = ki.deleteOwnedPods(c, obj); err != nil {
 			return err
 		}
 	}
@@ -446,11 +539,11 @@ func (ki *installer) waitForApply(c context.Context, name, namespace string, obj
 	}
 }
 
-// refreshReplicaSet finds pods owned by a given ReplicaSet and deletes them.
+// deleteOwnedPods finds pods owned by a given ReplicaSet or Job and deletes them.
 // We need this because updating a Replica Set does *not* generate new
-// pods if the desired amount already exists.
-func (ki *installer) refreshReplicaSet(c context.Context, namespace string, rs *apps.ReplicaSet) error {
-	pods, err := k8sapi.Pods(c, namespace)
+// pods if the desired amount already exists, and a Job cannot be updated.
+func (ki *installer) deleteOwnedPods(c context.Context, owner k8sapi.Object) error {
+	pods, err := k8sapi.Pods(c, owner.GetNamespace())
 	if err != nil {
 		return err
 	}
@@ -458,8 +551,8 @@ func (ki *installer) refreshReplicaSet(c context.Context, namespace string, rs *
 	for _, pod := range pods {
 		podImpl, _ := k8sapi.PodImpl(pod)
 		for _, ownerRef := range podImpl.OwnerReferences {
-			if ownerRef.UID == rs.UID {
-				dlog.Infof(c, "Deleting pod %s.%s owned by rs %s", podImpl.Name, podImpl.Namespace, rs.Name)
+			if ownerRef.UID == owner.GetUID() {
+				dlog.Infof(c, "Deleting pod %s.%s owned by %s %s", podImpl.Name, podImpl.Namespace, owner.GetKind(), owner.GetName())
 				if err = pod.Delete(c); err != nil {
 					if errors2.IsNotFound(err) || errors2.IsConflict(err) {
 						// If an intercept creates a new pod by installing an agent, and the agent is then uninstalled shortly after, the
@@ -538,12 +631,14 @@ func undoObjectMods(c context.Context, obj k8sapi.Object) (string, error) {
 			return "", err
 		}
 	}
-	mObj := obj.(meta.ObjectMetaAccessor).GetObjectMeta()
-	annotations := mObj.GetAnnotations()
+	// Not all objects return their own map from GetAnnotations (an unstructured Rollout returns a
+	// copy), so the result must be set explicitly.
+	annotations := obj.GetAnnotations()
 	delete(annotations, annTelepresenceActions)
 	if len(annotations) == 0 {
-		mObj.SetAnnotations(nil)
+		annotations = nil
 	}
+	obj.SetAnnotations(annotations)
 	explainUndo(c, &actions, obj)
 	return actions.ReferencedService, nil
 }
@@ -612,56 +707,25 @@ func addAgentToWorkload(
 
 	version := client.Semver().String()
 
-	// Try to detect the container port we'll be taking over.
-	var containerPort struct {
-		Name     string // If the existing container port doesn't have a name, we'll make one up.
-		Number   uint16
-		Protocol core.Protocol
-	}
-
-	// Start by filling from the servicePort; if these are the zero values, that's OK.
-	svcHasTargetPort := true
-	if servicePort.TargetPort.Type == intstr.Int {
-		if servicePort.TargetPort.IntVal == 0 {
-			containerPort.Number = uint16(servicePort.Port)
-			svcHasTargetPort = false
-		} else {
-			containerPort.Number = uint16(servicePort.TargetPort.IntVal)
-		}
-	} else {
-		containerPort.Name = servicePort.TargetPort.StrVal
+	containerPort, err := takeoverPort(object, servicePort, container, containerPortIndex)
+	if err != nil {
+		return nil, nil, false, err
 	}
-	containerPort.Protocol = servicePort.Protocol
 
-	// Now fill from the Deployment's containerPort.
-	usedContainerName := false
-	if containerPortIndex >= 0 {
-		if containerPort.Name == "" {
-			containerPort.Name = container.Ports[containerPortIndex].Name
-			if containerPort.Name != "" {
-				usedContainerName = true
-			}
-		}
-		if containerPort.Number == 0 {
-			containerPort.Number = uint16(container.Ports[containerPortIndex].ContainerPort)
-		}
-		if containerPort.Protocol == "" {
-			containerPort.Protocol = container.Ports[containerPortIndex].Protocol
-		}
-	}
-	if containerPort.Number == 0 {
-		return nil, nil, false, k8sapi.ObjErrorf(object, "unable to add: the container port cannot be determined")
-	}
-	if containerPort.Name == "" {
-		containerPort.Name = fmt.Sprintf("tx-%d", containerPort.Number)
+	// The agent also fronts the destinations of outbound connections that can be intercepted, and
+	// the init container is needed to redirect those connections to the agent.
+	egress, err := install.EgressDestinations(object.GetPodTemplate().Annotations)
+	if err != nil {
+		return nil, nil, false, err
 	}
 
 	var initContainerAction *addInitContainerAction
-	if matchingService.Spec.ClusterIP == "None" {
+	if matchingService.Spec.ClusterIP == "None" || len(egress) > 0 {
 		initContainerAction = &addInitContainerAction{
-			AppPortProto:  containerPort.Protocol,
-			AppPortNumber: containerPort.Number,
-			ImageName:     agentImageName,
+			AppPortProto:       containerPort.Protocol,
+			AppPortNumber:      containerPort.Number,
+			EgressDestinations: egress,
+			ImageName:          agentImageName,
 		}
 	}
 
@@ -688,57 +752,51 @@ func addAgentToWorkload(
 			ContainerPortAppProto:   k8sapi.GetAppProto(c, client.GetConfig(c).Intercept.AppProtocolStrategy, servicePort),
 			ContainerPortNumber:     containerPort.Number,
 			APIPortNumber:           telepresenceAPIPort,
+			EgressDestinations:      egress,
 			ImageName:               agentImageName,
 		},
 		AddTPEnvironmentAction: addTPEnvAction,
 	}
-	// Depending on whether the Service refers to the port by name or by number, we either need
-	// to patch the names in the deployment, or the number in the service.
-	var serviceMod *svcActions
-	if servicePort.TargetPort.Type == intstr.Int {
-		// Change the port number that the Service refers to.
-		serviceMod = &svcActions{Version: version}
-		if svcHasTargetPort {
-			serviceMod.MakePortSymbolic = &makePortSymbolicAction{
-				PortName:     servicePort.Name,
-				TargetPort:   containerPort.Number,
-				SymbolicName: containerPort.Name,
-			}
-		} else {
-			serviceMod.AddSymbolicPort = &addSymbolicPortAction{
-				makePortSymbolicAction{
-					PortName:     servicePort.Name,
-					TargetPort:   containerPort.Number,
-					SymbolicName: containerPort.Name,
-				},
-			}
+	serviceMod := &svcActions{Version: version}
+	hide, makeSymbolic, addSymbolic := containerPort.actions(container.Name, 0)
+	workloadMod.HideContainerPort = hide
+	serviceMod.MakePortSymbolic = makeSymbolic
+	serviceMod.AddSymbolicPort = addSymbolic
+
+	// The agent takes over all other ports of the service that map to the same container.
+	for i, asp := range svcprops.AdditionalServicePorts {
+		acp, err := takeoverPort(object, asp, container, svcprops.AdditionalContainerPortIndexes[i])
+		if err != nil {
+			return nil, nil, false, err
 		}
-		// Since we are updating the service to use the containerPort.Name
-		// if that value came from the container, then we need to hide it
-		// since the service is using the targetPort's int.
-		if usedContainerName {
-			workloadMod.HideContainerPort = &hideContainerPortAction{
-				ContainerName: container.Name,
-				PortName:      containerPort.Name,
-				ordinal:       0,
-			}
+		if initContainerAction != nil {
+			initContainerAction.AdditionalAppPortNumbers = append(initContainerAction.AdditionalAppPortNumbers, acp.Number)
 		}
-	} else {
-		// Hijack the port name in the Deployment.
-		workloadMod.HideContainerPort = &hideContainerPortAction{
-			ContainerName: container.Name,
-			PortName:      containerPort.Name,
-			ordinal:       0,
+		workloadMod.AddTrafficAgent.AdditionalContainerPorts = append(workloadMod.AddTrafficAgent.AdditionalContainerPorts, &agentContainerPort{
+			Name:   acp.Name,
+			Proto:  acp.Protocol,
+			Number: acp.Number,
+		})
+		hide, makeSymbolic, addSymbolic = acp.actions(container.Name, i+1)
+		if hide != nil {
+			workloadMod.HideAdditionalContainerPorts = append(workloadMod.HideAdditionalContainerPorts, hide)
+		}
+		if makeSymbolic != nil {
+			serviceMod.MakeAdditionalPortsSymbolic = append(serviceMod.MakeAdditionalPortsSymbolic, makeSymbolic)
+		}
+		if addSymbolic != nil {
+			serviceMod.AddAdditionalSymbolicPorts = append(serviceMod.AddAdditionalSymbolicPorts, addSymbolic)
 		}
 	}
+	if len(serviceMod.actions()) == 0 {
+		serviceMod = nil
+	}
 
 	// Apply the actions on the workload.
-	var err error
 	if err = workloadMod.Do(object); err != nil {
 		return nil, nil, false, err
 	}
-	mObj := object.(meta.ObjectMetaAccessor).GetObjectMeta()
-	annotations := mObj.GetAnnotations()
+	annotations := object.GetAnnotations()
 	if annotations == nil {
 		annotations = make(map[string]string)
 	}
@@ -746,7 +804,7 @@ func addAgentToWorkload(
 	if err != nil {
 		return nil, nil, false, err
 	}
-	mObj.SetAnnotations(annotations)
+	object.SetAnnotations(annotations)
 	explainDo(c, workloadMod, object)
 
 	// Apply the actions on the Service.
@@ -770,6 +828,102 @@ func addAgentToWorkload(
 	return object, svc, updateService, nil
 }
 
+// portTakeover describes a container port that the traffic-agent takes over.
+type portTakeover struct {
+	servicePort *core.ServicePort
+
+	Name     string // If the existing container port doesn't have a name, we'll make one up.
+	Number   uint16
+	Protocol core.Protocol
+
+	svcHasTargetPort  bool
+	usedContainerName bool
+}
+
+// takeoverPort detects the container port that the traffic-agent will take over for the given service port.
+func takeoverPort(object k8sapi.Workload, servicePort *core.ServicePort, container *core.Container, containerPortIndex int) (*portTakeover, error) {
+	containerPort := &portTakeover{servicePort: servicePort, svcHasTargetPort: true}
+
+	// Start by filling from the servicePort; if these are the zero values, that's OK.
+	if servicePort.TargetPort.Type == intstr.Int {
+		if servicePort.TargetPort.IntVal == 0 {
+			containerPort.Number = uint16(servicePort.Port)
+			containerPort.svcHasTargetPort = false
+		} else {
+			containerPort.Number = uint16(servicePort.TargetPort.IntVal)
+		}
+	} else {
+		containerPort.Name = servicePort.TargetPort.StrVal
+	}
+	containerPort.Protocol = servicePort.Protocol
+
+	// Now fill from the Deployment's containerPort.
+	if containerPortIndex >= 0 {
+		if containerPort.Name == "" {
+			containerPort.Name = container.Ports[containerPortIndex].Name
+			if containerPort.Name != "" {
+				containerPort.usedContainerName = true
+			}
+		}
+		if containerPort.Number == 0 {
+			containerPort.Number = uint16(container.Ports[containerPortIndex].ContainerPort)
+		}
+		if containerPort.Protocol == "" {
+			containerPort.Protocol = container.Ports[containerPortIndex].Protocol
+		}
+	}
+	if containerPort.Number == 0 {
+		return nil, k8sapi.ObjErrorf(object, "unable to add: the container port cannot be determined")
+	}
+	if containerPort.Name == "" {
+		containerPort.Name = fmt.Sprintf("tx-%d", containerPort.Number)
+	}
+	return containerPort, nil
+}
+
+// actions returns the actions needed to make the service port refer to the traffic-agent instead of the container
+// port. Depending on whether the Service refers to the port by name or by number, we either need to patch the names
+// in the workload, or the number in the service.
+func (cp *portTakeover) actions(containerName string, ordinal int) (
+	hide *hideContainerPortAction,
+	makeSymbolic *makePortSymbolicAction,
+	addSymbolic *addSymbolicPortAction,
+) {
+	servicePort := cp.servicePort
+	if servicePort.TargetPort.Type != intstr.Int {
+		// Hijack the port name in the Deployment.
+		return &hideContainerPortAction{
+			ContainerName: containerName,
+			PortName:      cp.Name,
+			ordinal:       ordinal,
+		}, nil, nil
+	}
+
+	// Change the port number that the Service refers to.
+	symbolic := makePortSymbolicAction{
+		PortName:     servicePort.Name,
+		TargetPort:   cp.Number,
+		SymbolicName: cp.Name,
+	}
+	if cp.svcHasTargetPort {
+		makeSymbolic = &symbolic
+	} else {
+		addSymbolic = &addSymbolicPortAction{symbolic}
+	}
+
+	// Since we are updating the service to use the containerPort.Name
+	// if that value came from the container, then we need to hide it
+	// since the service is using the targetPort's int.
+	if cp.usedContainerName {
+		hide = &hideContainerPortAction{
+			ContainerName: containerName,
+			PortName:      cp.Name,
+			ordinal:       ordinal,
+		}
+	}
+	return hide, makeSymbolic, addSymbolic
+}
+
 func (ki *installer) EnsureManager(c context.Context) error {
 	return helm.EnsureTrafficManager(c, ki.ConfigFlags, ki.GetManagerNamespace())
 }
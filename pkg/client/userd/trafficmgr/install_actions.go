@@ -12,7 +12,6 @@ import (
 	"github.com/blang/semver"
 	"github.com/hashicorp/go-multierror"
 	core "k8s.io/api/core/v1"
-	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/TinderBackend/telepresence/v2/pkg/install"
@@ -59,8 +58,7 @@ type completeAction interface {
 }
 
 func nameAndNamespace(obj k8sapi.Object) string {
-	mObj := obj.(meta.ObjectMetaAccessor).GetObjectMeta()
-	return mObj.GetName() + "." + mObj.GetNamespace()
+	return obj.GetName() + "." + obj.GetNamespace()
 }
 
 func explainDo(c context.Context, a completeAction, obj k8sapi.Object) {
@@ -285,6 +283,10 @@ type svcActions struct {
 	Version          string                  `json:"version"`
 	MakePortSymbolic *makePortSymbolicAction `json:"make_port_symbolic,omitempty"`
 	AddSymbolicPort  *addSymbolicPortAction  `json:"add_symbolic_port,omitempty"`
+
+	// Actions for the additional ports that the traffic-agent takes over
+	MakeAdditionalPortsSymbolic []*makePortSymbolicAction `json:"make_additional_ports_symbolic,omitempty"`
+	AddAdditionalSymbolicPorts  []*addSymbolicPortAction  `json:"add_additional_symbolic_ports,omitempty"`
 }
 
 var _ completeAction = (*svcActions)(nil)
@@ -296,6 +298,12 @@ func (s *svcActions) actions() (actions multiAction) {
 	if s.AddSymbolicPort != nil {
 		actions = append(actions, s.AddSymbolicPort)
 	}
+	for _, a := range s.MakeAdditionalPortsSymbolic {
+		actions = append(actions, a)
+	}
+	for _, a := range s.AddAdditionalSymbolicPorts {
+		actions = append(actions, a)
+	}
 	return actions
 }
 
@@ -347,6 +355,12 @@ type addTrafficAgentAction struct {
 	ContainerPortNumber   uint16        `json:"app_port"`
 	APIPortNumber         uint16        `json:"api_port,omitempty"`
 
+	// Additional pre-existing container ports that the agent will take over.
+	AdditionalContainerPorts []*agentContainerPort `json:"additional_container_ports,omitempty"`
+
+	// The "host:port" destinations of outbound connections that the agent will front.
+	EgressDestinations []string `json:"egress_destinations,omitempty"`
+
 	// The image name of the agent to add
 	ImageName string `json:"image_name"`
 
@@ -359,6 +373,17 @@ type addTrafficAgentAction struct {
 
 var _ partialAction = (*addTrafficAgentAction)(nil)
 
+// agentContainerPort is a pre-existing container port that the agent will take over.
+type agentContainerPort struct {
+	Name   string        `json:"name"`
+	Proto  core.Protocol `json:"proto"`
+	Number uint16        `json:"number"`
+}
+
+// agentPortNumber is the number of the first port of the traffic-agent container. Additional ports
+// use the numbers that follow.
+const agentPortNumber = 9900
+
 func (ata *addTrafficAgentAction) appContainer(cns []core.Container) *core.Container {
 	for i := range cns {
 		cn := &cns[i]
@@ -382,18 +407,31 @@ func (ata *addTrafficAgentAction) Do(obj k8sapi.Object) error {
 	// We ignore the error from this since we don't care if the volume isn't already present
 	_ = ata.dropAgentAnnotationVolume(obj, tplSpec)
 
+	cps := append([]*agentContainerPort{{
+		Name:   ata.ContainerPortName,
+		Proto:  ata.ContainerPortProto,
+		Number: ata.ContainerPortNumber,
+	}}, ata.AdditionalContainerPorts...)
+	ports := make([]install.AgentPort, len(cps))
+	for i, cp := range cps {
+		ports[i] = install.AgentPort{
+			Port: core.ContainerPort{
+				Name:          cp.Name,
+				Protocol:      cp.Proto,
+				ContainerPort: agentPortNumber + int32(i),
+			},
+			AppPort: int(cp.Number),
+		}
+	}
+
 	tplSpec.Spec.Volumes = append(tplSpec.Spec.Volumes, install.AgentVolume())
 	tplSpec.Spec.Containers = append(tplSpec.Spec.Containers,
 		install.AgentContainer(
-			obj.(meta.ObjectMetaAccessor).GetObjectMeta().GetName(),
+			obj.GetName(),
 			ata.ImageName,
 			appContainer,
-			core.ContainerPort{
-				Name:          ata.ContainerPortName,
-				Protocol:      ata.ContainerPortProto,
-				ContainerPort: 9900,
-			},
-			int(ata.ContainerPortNumber),
+			ports,
+			ata.EgressDestinations,
 			ata.ContainerPortAppProto,
 			int(ata.APIPortNumber),
 			ata.trafficManagerNamespace,
@@ -474,6 +512,12 @@ type addInitContainerAction struct {
 	AppPortProto  core.Protocol `json:"container_port_proto"`
 	AppPortNumber uint16        `json:"app_port"`
 
+	// The numbers of additional pre-existing container ports that the agent will take over.
+	AdditionalAppPortNumbers []uint16 `json:"additional_app_ports,omitempty"`
+
+	// The "host:port" destinations of outbound connections that the agent will front.
+	EgressDestinations []string `json:"egress_destinations,omitempty"`
+
 	// The image name of the initContainer to add -- usually the same as the traffic agent image that will be used
 	ImageName string `json:"image_name"`
 }
@@ -485,14 +529,17 @@ func (ica *addInitContainerAction) Do(obj k8sapi.Object) error {
 	if tplSpec.Spec.InitContainers == nil {
 		tplSpec.Spec.InitContainers = []core.Container{}
 	}
-	tplSpec.Spec.InitContainers = append(tplSpec.Spec.InitContainers, install.InitContainer(
-		ica.ImageName,
-		core.ContainerPort{
-			ContainerPort: 9900,
-			Protocol:      ica.AppPortProto,
-		},
-		int(ica.AppPortNumber),
-	))
+	ports := make([]install.AgentPort, 1+len(ica.AdditionalAppPortNumbers))
+	for i, appPort := range append([]uint16{ica.AppPortNumber}, ica.AdditionalAppPortNumbers...) {
+		ports[i] = install.AgentPort{
+			Port: core.ContainerPort{
+				ContainerPort: agentPortNumber + int32(i),
+				Protocol:      ica.AppPortProto,
+			},
+			AppPort: int(appPort),
+		}
+	}
+	tplSpec.Spec.InitContainers = append(tplSpec.Spec.InitContainers, install.InitContainer(ica.ImageName, ports, ica.EgressDestinations))
 
 	return nil
 }
@@ -629,7 +676,6 @@ type hideContainerPortAction struct {
 
 	// ordinal is only used for avoiding ambiguities when generating the HiddenName. It
 	// is the zero based order of all hideContainerPortAction instances for a workload.
-	// Right now we only use one port so this is always zero.
 	ordinal int
 }
 
@@ -722,6 +768,9 @@ type workloadActions struct {
 	AddTrafficAgent           *addTrafficAgentAction   `json:"add_traffic_agent,omitempty"`
 	AddInitContainer          *addInitContainerAction  `json:"add_init_container,omitempty"`
 	AddTPEnvironmentAction    *addTPEnvironmentAction  `json:"add_tp_env,omitempty"`
+
+	// HideAdditionalContainerPorts hides the additional ports that the traffic-agent takes over
+	HideAdditionalContainerPorts []*hideContainerPortAction `json:"hide_additional_container_ports,omitempty"`
 }
 
 var _ completeAction = (*workloadActions)(nil)
@@ -730,6 +779,9 @@ func (d *workloadActions) actions() (actions multiAction) {
 	if d.HideContainerPort != nil {
 		actions = append(actions, d.HideContainerPort)
 	}
+	for _, a := range d.HideAdditionalContainerPorts {
+		actions = append(actions, a)
+	}
 	if d.AddTrafficAgent != nil {
 		actions = append(actions, d.AddTrafficAgent)
 	}
@@ -13,12 +13,16 @@ import (
 	"text/template"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	apps "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 	"sigs.k8s.io/yaml"
 
 	"github.com/TinderBackend/telepresence/v2/pkg/client"
+	"github.com/TinderBackend/telepresence/v2/pkg/client/errcat"
 	"github.com/TinderBackend/telepresence/v2/pkg/install"
 	"github.com/TinderBackend/telepresence/v2/pkg/k8sapi"
 	"github.com/TinderBackend/telepresence/v2/pkg/version"
@@ -134,7 +138,7 @@ func TestAddAgentToWorkload(t *testing.T) {
 				cns := obj.GetPodTemplate().Spec.Containers
 				agent_image_name := managerImageName(ctx)
 
-				servicePort, container, containerPortIndex, err := install.FindMatchingPort(cns, tc.InputPortName, svc)
+				servicePorts, container, containerPortIndexes, err := install.FindMatchingPorts(cns, tc.InputPortName, svc)
 				if err != nil {
 					return
 				}
@@ -142,10 +146,12 @@ func TestAddAgentToWorkload(t *testing.T) {
 				actualWrk, actualSvc, _, actualErr := addAgentToWorkload(
 					ctx,
 					&ServiceProps{
-						Service:            svc,
-						ServicePort:        servicePort,
-						Container:          container,
-						ContainerPortIndex: containerPortIndex,
+						Service:                        svc,
+						ServicePort:                    servicePorts[0],
+						Container:                      container,
+						ContainerPortIndex:             containerPortIndexes[0],
+						AdditionalServicePorts:         servicePorts[1:],
+						AdditionalContainerPortIndexes: containerPortIndexes[1:],
 					},
 					agent_image_name, // ignore extensions
 					env.ManagerNamespace,
@@ -225,6 +231,43 @@ func TestAddAgentToWorkload(t *testing.T) {
 	})
 }
 
+func TestEnsureAgentJob(t *testing.T) {
+	job := &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default", UID: "job-uid"},
+		Spec: batch.JobSpec{Template: core.PodTemplateSpec{Spec: core.PodSpec{
+			Containers: []core.Container{{Name: "hello", Image: "hello"}},
+		}}},
+	}
+	pod := &core.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:            "hello-abcde",
+		Namespace:       "default",
+		OwnerReferences: []metav1.OwnerReference{{Kind: "Job", Name: "hello", UID: "job-uid"}},
+	}}
+	ki := fake.NewSimpleClientset(job, pod)
+	ctx := k8sapi.WithK8sInterface(dlog.NewTestContext(t, false), ki)
+	inst := &installer{}
+
+	// The pod template of a Job is immutable, so an agent can't be added to it
+	svcProps := &ServiceProps{Service: &core.Service{ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default"}}}
+	_, _, err := inst.EnsureAgent(ctx, k8sapi.Job(job.DeepCopy()), svcProps, "tel2:2.5.5", 0)
+	require.Error(t, err)
+	assert.Equal(t, errcat.User, errcat.GetCategory(err))
+	assert.Contains(t, err.Error(), install.InjectAnnotation)
+	for _, action := range ki.Actions() {
+		assert.NotEqual(t, "update", action.GetVerb(), "the Job must not be updated")
+	}
+
+	// The pods of a Job that the agent injector adds agents to are deleted, so that the job controller
+	// recreates them, but the Job isn't updated
+	require.NoError(t, inst.rolloutRestart(ctx, k8sapi.Job(job.DeepCopy())))
+	_, err = ki.CoreV1().Pods("default").Get(ctx, pod.Name, metav1.GetOptions{})
+	assert.Error(t, err, "the pod of the Job is deleted")
+	for _, action := range ki.Actions() {
+		assert.NotEqual(t, "update", action.GetVerb(), "the Job must not be updated")
+		assert.NotEqual(t, "patch", action.GetVerb(), "the Job must not be patched")
+	}
+}
+
 func sanitizeWorkload(obj k8sapi.Workload) {
 	mObj := obj.(metav1.ObjectMetaAccessor).GetObjectMeta()
 	mObj.SetResourceVersion("")
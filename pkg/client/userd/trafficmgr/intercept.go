@@ -192,7 +192,7 @@ func (tm *TrafficManager) workerPortForwardIntercepts(ctx context.Context) error
 				var iceptError error
 				switch intercept.Disposition {
 				case manager.InterceptDispositionType_ACTIVE:
-				case manager.InterceptDispositionType_WAITING:
+				case manager.InterceptDispositionType_WAITING, manager.InterceptDispositionType_SUSPENDED:
 					continue
 				default:
 					iceptError = fmt.Errorf("intercept in error state %v: %v", intercept.Disposition, intercept.Message)
@@ -216,6 +216,8 @@ func (tm *TrafficManager) workerPortForwardIntercepts(ctx context.Context) error
 			}
 			portForwards.cancelUnwanted(ctx)
 			tm.reconcileMountPoints(ctx, allNames)
+			tm.reconcileRecorders(ctx, allNames)
+			tm.reconcileExpiryTimers(ctx, intercepts)
 			if ctx.Err() == nil {
 				tm.setInterceptedNamespaces(ctx, namespaces)
 			}
@@ -354,6 +356,9 @@ func (tm *TrafficManager) CanIntercept(c context.Context, ir *rpc.CreateIntercep
 		// Intercept is not established here, so I am not sure this is still the right error type
 		return interceptError(rpc.InterceptError_FAILED_TO_ESTABLISH, err), nil, nil
 	}
+	if err = resolveContainerPorts(spec, svcprops, obj); err != nil {
+		return interceptError(rpc.InterceptError_FAILED_TO_ESTABLISH, err), nil, nil
+	}
 
 	return nil, obj, svcprops
 }
@@ -486,6 +491,19 @@ func (tm *TrafficManager) AddIntercept(c context.Context, ir *rpc.CreateIntercep
 			dlog.Errorf(c, "error getting apiKey for agent: %s", err)
 		}
 	}
+	var recorder *interceptRecorder
+	if ir.RecordFile != "" {
+		if recorder, err = newInterceptRecorder(ir.RecordFile, spec); err != nil {
+			return interceptError(rpc.InterceptError_FAILED_TO_ESTABLISH, errcat.User.Newf("unable to create recording: %w", err)), nil
+		}
+		// Close the recorder unless it's handed over to the recorders map
+		defer func() {
+			if recorder != nil {
+				_ = recorder.Close()
+			}
+		}()
+	}
+
 	dlog.Debugf(c, "creating intercept %s", spec.Name)
 	tos := &client.GetConfig(c).Timeouts
 	spec.RoundtripLatency = int64(tos.Get(client.TimeoutRoundtripLatency)) * 2 // Account for extra hop
@@ -503,6 +521,7 @@ func (tm *TrafficManager) AddIntercept(c context.Context, ir *rpc.CreateIntercep
 		Session:       tm.session(),
 		InterceptSpec: spec,
 		ApiKey:        apiKey,
+		Ttl:           ir.Ttl,
 	})
 	if err != nil {
 		dlog.Debugf(c, "manager responded to CreateIntercept with error %v", err)
@@ -510,6 +529,13 @@ func (tm *TrafficManager) AddIntercept(c context.Context, ir *rpc.CreateIntercep
 		return &rpc.InterceptResult{Error: rpc.InterceptError_TRAFFIC_MANAGER_ERROR, ErrorText: err.Error()}, nil
 	}
 	dlog.Debugf(c, "created intercept %s", ii.Spec.Name)
+	if recorder != nil {
+		if prev, loaded := tm.recorders.LoadAndDelete(spec.Name); loaded {
+			_ = prev.(*interceptRecorder).Close()
+		}
+		tm.recorders.Store(spec.Name, recorder)
+		recorder = nil
+	}
 
 	select {
 	case <-c.Done():
@@ -522,6 +548,10 @@ func (tm *TrafficManager) AddIntercept(c context.Context, ir *rpc.CreateIntercep
 			if err != nil {
 				dlog.Warnf(c, "failed to remove failed intercept %s: %v", wr.intercept.Spec.Namespace, err)
 			}
+			if wr.intercept.Disposition == manager.InterceptDispositionType_FORBIDDEN {
+				// A policy violation is something that the user must resolve with the cluster's admin
+				return interceptError(rpc.InterceptError_FAILED_TO_ESTABLISH, errcat.User.New(wr.intercept.Message)), nil
+			}
 			return interceptError(rpc.InterceptError_FAILED_TO_ESTABLISH, wr.err), nil
 		}
 		result.InterceptInfo = wr.intercept
@@ -0,0 +1,69 @@
+package trafficmgr
+
+import (
+	"context"
+	"net"
+
+	"github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/pkg/recording"
+	"github.com/TinderBackend/telepresence/v2/pkg/tunnel"
+	"github.com/datawire/dlib/dlog"
+)
+
+// interceptRecorder records the connections that are delivered by one intercept.
+type interceptRecorder struct {
+	*recording.Recorder
+
+	// targetPorts are the local ports that the intercept delivers connections to
+	targetPorts map[uint16]struct{}
+}
+
+func newInterceptRecorder(path string, spec *manager.InterceptSpec) (*interceptRecorder, error) {
+	r, err := recording.Create(path, &recording.Header{
+		Intercept: spec.Name,
+		Namespace: spec.Namespace,
+		Workload:  spec.Agent,
+		Mechanism: spec.Mechanism,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ports := map[uint16]struct{}{uint16(spec.TargetPort): {}}
+	for _, sp := range spec.ServicePorts {
+		ports[uint16(sp.TargetPort)] = struct{}{}
+	}
+	return &interceptRecorder{Recorder: r, targetPorts: ports}, nil
+}
+
+// RecordConn implements tunnel.ConnRecorder. The connection is recorded by the recorder of the intercept that
+// delivers connections to its destination port. Intercepts delivering to the same port can't be told apart, so
+// the connection is then recorded by one of them.
+func (tm *TrafficManager) RecordConn(ctx context.Context, id tunnel.ConnID, conn net.Conn) net.Conn {
+	port := id.DestinationPort()
+	tm.recorders.Range(func(_, v interface{}) bool {
+		r := v.(*interceptRecorder)
+		if _, ok := r.targetPorts[port]; ok {
+			conn = r.RecordConn(ctx, id, conn)
+			return false
+		}
+		return true
+	})
+	return conn
+}
+
+// reconcileRecorders closes the recorders for which there no longer is an intercept
+func (tm *TrafficManager) reconcileRecorders(ctx context.Context, existingIntercepts map[string]struct{}) {
+	tm.recorders.Range(func(k, v interface{}) bool {
+		if _, ok := existingIntercepts[k.(string)]; !ok {
+			if _, loaded := tm.recorders.LoadAndDelete(k); loaded {
+				r := v.(*interceptRecorder)
+				if err := r.Close(); err != nil {
+					dlog.Errorf(ctx, "Failed to close recording %q: %v", r.Name(), err)
+				} else {
+					dlog.Infof(ctx, "Recording of intercept %s saved in %q", k, r.Name())
+				}
+			}
+		}
+		return true
+	})
+}
@@ -333,6 +333,26 @@ func (p *mgrProxy) WatchLookupHost(*managerrpc.SessionInfo, managerrpc.Manager_W
 	return status.Error(codes.Unimplemented, "must call manager.WatchLookupHost from an agent (intercepted Pod), not from a client (workstation)")
 }
 
+func (p *mgrProxy) LookupDNS(ctx context.Context, arg *managerrpc.DNSRequest) (*managerrpc.DNSResponse, error) {
+	client, callOptions, err := p.get()
+	if err != nil {
+		return nil, err
+	}
+	return client.LookupDNS(ctx, arg, callOptions...)
+}
+
+func (p *mgrProxy) AgentLookupDNSResponse(ctx context.Context, arg *managerrpc.DNSAgentResponse) (*empty.Empty, error) {
+	client, callOptions, err := p.get()
+	if err != nil {
+		return nil, err
+	}
+	return client.AgentLookupDNSResponse(ctx, arg, callOptions...)
+}
+
+func (p *mgrProxy) WatchLookupDNS(*managerrpc.SessionInfo, managerrpc.Manager_WatchLookupDNSServer) error {
+	return status.Error(codes.Unimplemented, "must call manager.WatchLookupDNS from an agent (intercepted Pod), not from a client (workstation)")
+}
+
 func (p *mgrProxy) WatchClusterInfo(arg *managerrpc.SessionInfo, srv managerrpc.Manager_WatchClusterInfoServer) error {
 	client, callOptions, err := p.get()
 	if err != nil {
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"github.com/TinderBackend/telepresence/v2/pkg/ignisconfig"
 	"net"
+	"net/http"
 	"net/url"
 	"os"
 	"os/user"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -20,6 +22,7 @@ import (
 	empty "google.golang.org/protobuf/types/known/emptypb"
 	core "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
 
 	"github.com/TinderBackend/telepresence/rpc/v2/connector"
 	rpc "github.com/TinderBackend/telepresence/rpc/v2/connector"
@@ -27,6 +30,7 @@ import (
 	"github.com/TinderBackend/telepresence/rpc/v2/manager"
 	"github.com/TinderBackend/telepresence/v2/pkg/a8rcloud"
 	"github.com/TinderBackend/telepresence/v2/pkg/client"
+	"github.com/TinderBackend/telepresence/v2/pkg/client/cache"
 	"github.com/TinderBackend/telepresence/v2/pkg/client/errcat"
 	"github.com/TinderBackend/telepresence/v2/pkg/client/scout"
 	"github.com/TinderBackend/telepresence/v2/pkg/client/userd/auth"
@@ -80,6 +84,7 @@ type Service interface {
 	RootDaemonClient(context.Context) (daemon.DaemonClient, error)
 	SetManagerClient(manager.ManagerClient, ...grpc.CallOption)
 	LoginExecutor() auth.LoginExecutor
+	Notify(c context.Context, message string)
 }
 
 type apiServer struct {
@@ -101,6 +106,9 @@ type TrafficManager struct {
 
 	getCloudAPIKey func(context.Context, string, bool) (string, error)
 
+	// notify sends a message to the user
+	notify func(context.Context, string)
+
 	ingressInfo []*manager.IngressInfo
 
 	// manager client
@@ -113,6 +121,7 @@ type TrafficManager struct {
 	rootDaemon daemon.DaemonClient
 
 	sessionInfo *manager.SessionInfo // sessionInfo returned by the traffic-manager
+	sessionKey  string               // key of the session's resume token in the user cache
 
 	// Map of desired mount points for intercepts
 	mountPoints sync.Map
@@ -121,6 +130,12 @@ type TrafficManager struct {
 	// mount points concurrently
 	mountMutexes sync.Map
 
+	// Map of recorders of intercepts that record their connections, keyed by intercept name
+	recorders sync.Map
+
+	// Map of timers that warn about intercepts that are about to expire, keyed by intercept name
+	expiryTimers sync.Map
+
 	wlWatcher *workloadsAndServicesWatcher
 
 	insLock sync.Mutex
@@ -151,6 +166,16 @@ type TrafficManager struct {
 
 	sessionServices []SessionService
 	sr              *scout.Reporter
+
+	// outboundFaults are the faults to inject into outbound connections, as requested by the connect command
+	outboundFaults []*manager.OutboundFault
+
+	// dnsOverrides are the DNS overrides of the client configuration, merged with those requested by the connect command
+	dnsOverrides map[string]string
+
+	// dnsCacheTTL and dnsNegativeCacheTTL control the caching of the root daemon's resolver. Zero means default
+	dnsCacheTTL         time.Duration
+	dnsNegativeCacheTTL time.Duration
 }
 
 // interceptResult is what gets written to the activeInterceptsWaiters channels
@@ -186,7 +211,7 @@ func NewSession(c context.Context, sr *scout.Reporter, cr *rpc.ConnectRequest, s
 	connectStart := time.Now()
 
 	dlog.Info(c, "Connecting to traffic manager...")
-	tmgr, err := connectMgr(c, cluster, sr.InstallID(), svc, rootDaemon)
+	tmgr, err := connectMgr(c, cluster, sr.InstallID(), svc, rootDaemon, egressRules(cluster, cr.EgressRules))
 
 	if err != nil {
 		dlog.Errorf(c, "Unable to connect to TrafficManager: %s", err)
@@ -195,11 +220,16 @@ func NewSession(c context.Context, sr *scout.Reporter, cr *rpc.ConnectRequest, s
 
 	tmgr.sessionServices = extraServices
 	tmgr.sr = sr
+	tmgr.outboundFaults = cr.OutboundFaults
+
+	cfg := client.GetConfig(c)
+	tmgr.dnsOverrides = dnsOverrides(cfg.DNS.Overrides, cr.DnsOverrides)
+	tmgr.dnsCacheTTL = cfg.DNS.CacheTTL
+	tmgr.dnsNegativeCacheTTL = cfg.DNS.NegativeCacheTTL
 
 	// Must call SetManagerClient before calling daemon.Connect which tells the
 	// daemon to use the proxy.
 	var opts []grpc.CallOption
-	cfg := client.GetConfig(c)
 	if !cfg.Grpc.MaxReceiveSize.IsZero() {
 		if mz, ok := cfg.Grpc.MaxReceiveSize.AsInt64(); ok {
 			opts = append(opts, grpc.MaxCallRecvMsgSize(int(mz)))
@@ -296,8 +326,50 @@ func connectCluster(c context.Context, cr *rpc.ConnectRequest) (*k8s.Cluster, er
 	return cluster, nil
 }
 
+// roundTripperFunc is an http.RoundTripper that calls itself.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(rq *http.Request) (*http.Response, error) {
+	return f(rq)
+}
+
+// kubeToken returns the bearer token of the given config, so that the traffic-manager can verify the Kubernetes
+// identity of the client. The token is obtained the same way as when the config is used to call the API server,
+// so tokens from token files, exec plugins, and auth providers are included. An empty string is returned when
+// the config doesn't use a bearer token, e.g. when it uses a client certificate.
+func kubeToken(c context.Context, rc *rest.Config) (string, error) {
+	var token string
+	rt, err := rest.HTTPWrappersForConfig(rc, roundTripperFunc(func(rq *http.Request) (*http.Response, error) {
+		if auth := rq.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+			token = strings.TrimPrefix(auth, "Bearer ")
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: rq}, nil
+	}))
+	if err != nil {
+		return "", err
+	}
+	// The request never leaves this process
+	rq, err := http.NewRequestWithContext(c, http.MethodGet, "https://kubernetes.default/api", nil)
+	if err != nil {
+		return "", err
+	}
+	rs, err := rt.RoundTrip(rq)
+	if err != nil {
+		return "", err
+	}
+	rs.Body.Close()
+	return token, nil
+}
+
 // connectMgr returns a session for the given cluster that is connected to the traffic-manager.
-func connectMgr(c context.Context, cluster *k8s.Cluster, installID string, svc Service, rootDaemon daemon.DaemonClient) (*TrafficManager, error) {
+func connectMgr(
+	c context.Context,
+	cluster *k8s.Cluster,
+	installID string,
+	svc Service,
+	rootDaemon daemon.DaemonClient,
+	egressRules []*manager.EgressRule,
+) (*TrafficManager, error) {
 	clientConfig := client.GetConfig(c)
 	tos := &clientConfig.Timeouts
 
@@ -366,32 +438,98 @@ func connectMgr(c context.Context, cluster *k8s.Cluster, installID string, svc S
 	userAndHost := fmt.Sprintf("%s@%s", userinfo.Username, host)
 	mClient := manager.NewManagerClient(conn)
 
+	// A session that wasn't ended by a depart, e.g. because the connection to the cluster was lost, is
+	// resumed together with its intercepts if the traffic-manager still knows about it.
+	sessionKey := cluster.Server + "/" + cluster.GetManagerNamespace()
+	resumeSession, err := cache.LoadSessionFromUserCache(c, sessionKey)
+	if err != nil {
+		dlog.Errorf(c, "unable to load session from cache: %v", err)
+	}
+
+	// The bearer token is only sent when the traffic-manager needs it to verify the client's identity
+	var token string
+	vi, err := mClient.Version(tc, &empty.Empty{})
+	if err != nil {
+		return nil, client.CheckTimeout(tc, fmt.Errorf("manager.Version: %w", err))
+	}
+	if vi.KubeTokenRequired {
+		if token, err = kubeToken(c, restConfig); err != nil {
+			return nil, fmt.Errorf("unable to get the bearer token of the kubeconfig: %w", err)
+		}
+		if token == "" {
+			dlog.Warn(c, "the traffic-manager has an intercept policy, but the kubeconfig doesn't authenticate using "+
+				"a bearer token, so the policy can't verify your Kubernetes identity")
+		}
+	}
+
 	dlog.Debugf(c, "traffic-manager port-forward established, making client known to the traffic-manager as %q", userAndHost)
 	si, err := mClient.ArriveAsClient(tc, &manager.ClientInfo{
-		Name:      userAndHost,
-		InstallId: installID,
-		Product:   "telepresence",
-		Version:   client.Version(),
-		ApiKey:    apiKey,
+		Name:          userAndHost,
+		InstallId:     installID,
+		Product:       "telepresence",
+		Version:       client.Version(),
+		ApiKey:        apiKey,
+		KubeToken:     token,
+		ResumeSession: resumeSession,
+		EgressRules:   egressRules,
 	})
 	if err != nil {
 		return nil, client.CheckTimeout(tc, fmt.Errorf("manager.ArriveAsClient: %w", err))
 	}
+	if resumeSession != nil && resumeSession.SessionId == si.SessionId {
+		dlog.Infof(c, "Resumed session %s", si.SessionId)
+	}
+	if si.ResumeToken != "" {
+		if err := cache.SaveSessionToUserCache(c, sessionKey, si); err != nil {
+			dlog.Errorf(c, "unable to save session to cache: %v", err)
+		}
+	}
+	// The resume token is only needed when arriving, so it's not sent in other calls
+	si = &manager.SessionInfo{SessionId: si.SessionId}
 
 	return &TrafficManager{
 		installer:       ti.(*installer),
 		installID:       installID,
 		userAndHost:     userAndHost,
 		getCloudAPIKey:  svc.LoginExecutor().GetCloudAPIKey,
+		notify:          svc.Notify,
 		managerClient:   mClient,
 		managerConn:     conn,
 		sessionInfo:     si,
+		sessionKey:      sessionKey,
 		rootDaemon:      rootDaemon,
 		localIntercepts: map[string]string{},
 		wlWatcher:       newWASWatcher(),
 	}, nil
 }
 
+// dnsOverrides returns the overrides of the client configuration merged with the given overrides, which
+// take precedence.
+func dnsOverrides(configured, requested map[string]string) map[string]string {
+	if len(configured) == 0 {
+		return requested
+	}
+	overrides := make(map[string]string, len(configured)+len(requested))
+	for name, target := range configured {
+		overrides[name] = target
+	}
+	for name, target := range requested {
+		overrides[name] = target
+	}
+	return overrides
+}
+
+// egressRules returns the given rules, with the namespace of the cluster's context as the namespace of
+// rules that don't have one.
+func egressRules(cluster *k8s.Cluster, rules []*manager.EgressRule) []*manager.EgressRule {
+	for _, rule := range rules {
+		if rule.Namespace == "" {
+			rule.Namespace = cluster.Namespace
+		}
+	}
+	return rules
+}
+
 func connectError(t rpc.ConnectInfo_ErrType, err error) *rpc.ConnectInfo {
 	return &rpc.ConnectInfo{
 		Error:         t,
@@ -643,6 +781,8 @@ func (tm *TrafficManager) remain(c context.Context) error {
 		}
 		if _, err := tm.managerClient.Depart(c, tm.session()); err != nil {
 			dlog.Errorf(c, "failed to depart from manager: %v", err)
+		} else if err := cache.DeleteSessionFromUserCache(c, tm.sessionKey); err != nil {
+			dlog.Errorf(c, "failed to delete session from cache: %v", err)
 		}
 		tm.managerConn.Close()
 	}()
@@ -814,6 +954,7 @@ func (tm *TrafficManager) getOutboundInfo(ctx context.Context) *daemon.OutboundI
 	info := &daemon.OutboundInfo{
 		Session:           tm.sessionInfo,
 		NeverProxySubnets: neverProxy,
+		OutboundFaults:    tm.outboundFaults,
 	}
 
 	if tm.DNS != nil {
@@ -829,6 +970,18 @@ func (tm *TrafficManager) getOutboundInfo(ctx context.Context) *daemon.OutboundI
 			info.Dns.RemoteIp = tm.DNS.RemoteIP.IP()
 		}
 	}
+	if len(tm.dnsOverrides) > 0 || tm.dnsCacheTTL > 0 || tm.dnsNegativeCacheTTL > 0 {
+		if info.Dns == nil {
+			info.Dns = &daemon.DNSConfig{}
+		}
+		info.Dns.Overrides = tm.dnsOverrides
+		if tm.dnsCacheTTL > 0 {
+			info.Dns.CacheTtl = durationpb.New(tm.dnsCacheTTL)
+		}
+		if tm.dnsNegativeCacheTTL > 0 {
+			info.Dns.NegativeCacheTtl = durationpb.New(tm.dnsNegativeCacheTTL)
+		}
+	}
 
 	if len(tm.AlsoProxy) > 0 {
 		info.AlsoProxySubnets = make([]*manager.IPNet, len(tm.AlsoProxy))
@@ -0,0 +1,62 @@
+package trafficmgr
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/datawire/dlib/dlog"
+)
+
+func TestKubeToken(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("file-token\n"), 0o600))
+
+	tests := []struct {
+		name   string
+		config *rest.Config
+		token  string
+	}{
+		{
+			name:   "token",
+			config: &rest.Config{BearerToken: "static-token"},
+			token:  "static-token",
+		},
+		{
+			name:   "token file",
+			config: &rest.Config{BearerTokenFile: tokenFile},
+			token:  "file-token",
+		},
+		{
+			name: "exec plugin",
+			config: &rest.Config{ExecProvider: &api.ExecConfig{
+				APIVersion: "client.authentication.k8s.io/v1beta1",
+				Command:    "sh",
+				Args: []string{"-c", `echo '{"apiVersion":"client.authentication.k8s.io/v1beta1",` +
+					`"kind":"ExecCredential","status":{"token":"exec-token"}}'`},
+			}},
+			token: "exec-token",
+		},
+		{
+			name:   "basic auth",
+			config: &rest.Config{Username: "alice", Password: "secret"},
+		},
+		{
+			name:   "client certificate",
+			config: &rest.Config{TLSClientConfig: rest.TLSClientConfig{CertFile: "client.crt", KeyFile: "client.key"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := kubeToken(ctx, tt.config)
+			require.NoError(t, err)
+			assert.Equal(t, tt.token, token)
+		})
+	}
+}
@@ -8,10 +8,13 @@ import (
 	"time"
 
 	apps "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
 	core "k8s.io/api/core/v1"
-	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/cache"
 
@@ -24,16 +27,31 @@ type workloadsAndServicesWatcher struct {
 	sync.Mutex
 	nsWatchers map[string]*namespacedWASWatcher
 	cond       sync.Cond
+
+	// hasCronJobs and hasRollouts are true when the cluster serves batch/v1 CronJobs (Kubernetes 1.21 and
+	// later) and Argo Rollouts. They're determined when the first namespace is watched.
+	discoverOnce sync.Once
+	hasCronJobs  bool
+	hasRollouts  bool
 }
 
 const deployments = 0
 const replicasets = 1
 const statefulsets = 2
+const daemonsets = 3
+const jobs = 4
+const cronjobs = 5
+const rollouts = 6
+const workloadKindCount = 7
+
+// workloadKinds are the kinds of the workloads that are watched, in watcher index order
+var workloadKinds = [workloadKindCount]string{"Deployment", "ReplicaSet", "StatefulSet", "DaemonSet", "Job", "CronJob", "Rollout"}
 
-// namespacedWASWatcher is watches Workloads And Services (WAS) for a namespace
+// namespacedWASWatcher is watches Workloads And Services (WAS) for a namespace. The watchers
+// for cronjobs and rollouts are nil unless the cluster serves them.
 type namespacedWASWatcher struct {
 	svcWatcher *k8s.Watcher
-	wlWatchers [3]*k8s.Watcher
+	wlWatchers [workloadKindCount]*k8s.Watcher
 }
 
 // svcEquals compare only the Service fields that are of interest to Telepresence. They are
@@ -67,7 +85,7 @@ nextMP:
 	return true
 }
 
-// workloadEquals compare only the workload (Deployment, ResourceSet, or StatefulSet) fields that are of interest to Telepresence. They are
+// workloadEquals compare only the workload (Deployment, ReplicaSet, StatefulSet, DaemonSet, Job, CronJob, or Rollout) fields that are of interest to Telepresence. They are
 //
 //   - UID
 //   - Name
@@ -139,32 +157,66 @@ func workloadEquals(oa, ob runtime.Object) bool {
 	return true
 }
 
-func newNamespaceWatcher(c context.Context, namespace string, cond *sync.Cond) *namespacedWASWatcher {
+func newNamespaceWatcher(c context.Context, namespace string, cond *sync.Cond, withCronJobs, withRollouts bool) *namespacedWASWatcher {
 	ki := k8sapi.GetK8sInterface(c)
 	appsGetter := ki.AppsV1().RESTClient()
+	batchGetter := ki.BatchV1().RESTClient()
 	w := &namespacedWASWatcher{
 		svcWatcher: k8s.NewWatcher("services", namespace, ki.CoreV1().RESTClient(), &core.Service{}, cond, svcEquals),
-		wlWatchers: [3]*k8s.Watcher{
+		wlWatchers: [workloadKindCount]*k8s.Watcher{
 			k8s.NewWatcher("deployments", namespace, appsGetter, &apps.Deployment{}, cond, workloadEquals),
 			k8s.NewWatcher("replicasets", namespace, appsGetter, &apps.ReplicaSet{}, cond, workloadEquals),
 			k8s.NewWatcher("statefulsets", namespace, appsGetter, &apps.StatefulSet{}, cond, workloadEquals),
+			k8s.NewWatcher("daemonsets", namespace, appsGetter, &apps.DaemonSet{}, cond, workloadEquals),
+			k8s.NewWatcher("jobs", namespace, batchGetter, &batch.Job{}, cond, workloadEquals),
 		},
 	}
+	if withCronJobs {
+		w.wlWatchers[cronjobs] = k8s.NewWatcher("cronjobs", namespace, batchGetter, &batch.CronJob{}, cond, workloadEquals)
+	}
+	if withRollouts {
+		w.wlWatchers[rollouts] = k8s.NewDynamicWatcher(k8sapi.RolloutGVR, namespace, k8sapi.GetDynamicInterface(c), cond, workloadEquals)
+	}
 	return w
 }
 
 func (nw *namespacedWASWatcher) cancel() {
 	nw.svcWatcher.Cancel()
 	for _, w := range nw.wlWatchers {
-		w.Cancel()
+		if w != nil {
+			w.Cancel()
+		}
 	}
 }
 
 func (nw *namespacedWASWatcher) hasSynced() bool {
-	return nw.svcWatcher.HasSynced() &&
-		nw.wlWatchers[0].HasSynced() &&
-		nw.wlWatchers[1].HasSynced() &&
-		nw.wlWatchers[2].HasSynced()
+	if !nw.svcWatcher.HasSynced() {
+		return false
+	}
+	for _, w := range nw.wlWatchers {
+		if w != nil && !w.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+// serverHasResource returns true if the cluster serves the given resource.
+func serverHasResource(c context.Context, gvr schema.GroupVersionResource) bool {
+	gv := gvr.GroupVersion().String()
+	rl, err := k8sapi.GetK8sInterface(c).Discovery().ServerResourcesForGroupVersion(gv)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			dlog.Warnf(c, "unable to discover %s resources: %v", gv, err)
+		}
+		return false
+	}
+	for _, r := range rl.APIResources {
+		if r.Name == gvr.Resource {
+			return true
+		}
+	}
+	return false
 }
 
 func newWASWatcher() *workloadsAndServicesWatcher {
@@ -263,8 +315,14 @@ func (w *workloadsAndServicesWatcher) setNamespacesToWatch(c context.Context, ns
 			nw.cancel()
 		}
 	}
+	if len(adds) > 0 {
+		w.discoverOnce.Do(func() {
+			w.hasCronJobs = serverHasResource(c, batch.SchemeGroupVersion.WithResource("cronjobs"))
+			w.hasRollouts = k8sapi.GetDynamicInterface(c) != nil && serverHasResource(c, k8sapi.RolloutGVR)
+		})
+	}
 	for _, ns := range adds {
-		w.nsWatchers[ns] = newNamespaceWatcher(c, ns, &w.cond)
+		w.nsWatchers[ns] = newNamespaceWatcher(c, ns, &w.cond, w.hasCronJobs, w.hasRollouts)
 	}
 	w.Unlock()
 }
@@ -310,16 +368,16 @@ func (nw *namespacedWASWatcher) findMatchingWorkloads(c context.Context, svc *co
 	}
 
 	var allWls []k8sapi.Workload
-	for i, wlw := range nw.wlWatchers {
+	for _, wlw := range nw.wlWatchers {
+		if wlw == nil {
+			continue
+		}
 		for _, o := range wlw.List(c) {
-			var wl k8sapi.Workload
-			switch i {
-			case deployments:
-				wl = k8sapi.Deployment(o.(*apps.Deployment))
-			case replicasets:
-				wl = k8sapi.ReplicaSet(o.(*apps.ReplicaSet))
-			case statefulsets:
-				wl = k8sapi.StatefulSet(o.(*apps.StatefulSet))
+			wl, err := k8sapi.WrapWorkload(o.(runtime.Object))
+			if err != nil {
+				// A Rollout that uses a workloadRef has no pod template to match
+				dlog.Debug(c, err)
+				continue
 			}
 			if selector.Matches(labels.Set(wl.GetLabels())) {
 				owl, err := nw.maybeReplaceWithOwner(c, wl)
@@ -339,32 +397,41 @@ func (nw *namespacedWASWatcher) findMatchingWorkloads(c context.Context, svc *co
 	return allWls, nil
 }
 
+// ownerWatchers maps the kinds of workloads that own other workloads to the index of their watcher
+var ownerWatchers = map[string]int{
+	"Deployment": deployments,
+	"CronJob":    cronjobs,
+	"Rollout":    rollouts,
+}
+
 func (nw *namespacedWASWatcher) maybeReplaceWithOwner(c context.Context, wl k8sapi.Workload) (k8sapi.Workload, error) {
 	var err error
 	for _, or := range wl.GetOwnerReferences() {
-		if or.Controller != nil && *or.Controller && or.Kind == "Deployment" {
+		if or.Controller == nil || !*or.Controller {
+			continue
+		}
+		if wi, ok := ownerWatchers[or.Kind]; ok && nw.wlWatchers[wi] != nil {
 			// Chances are that the owner's labels doesn't match, but we really want the owner anyway.
-			wl, err = nw.replaceWithOwner(c, wl, or.Kind, or.Name)
+			wl, err = nw.replaceWithOwner(c, wl, wi, or.Name)
 			break
 		}
 	}
 	return wl, err
 }
 
-func (nw *namespacedWASWatcher) replaceWithOwner(c context.Context, wl k8sapi.Workload, kind, name string) (k8sapi.Workload, error) {
-	od, found, err := nw.wlWatchers[deployments].Get(c, &apps.Deployment{
-		ObjectMeta: meta.ObjectMeta{
-			Name:      name,
-			Namespace: wl.GetNamespace(),
-		},
-	})
+func (nw *namespacedWASWatcher) replaceWithOwner(c context.Context, wl k8sapi.Workload, wi int, name string) (k8sapi.Workload, error) {
+	kind := workloadKinds[wi]
+	key := &unstructured.Unstructured{}
+	key.SetName(name)
+	key.SetNamespace(wl.GetNamespace())
+	od, found, err := nw.wlWatchers[wi].Get(c, key)
 	switch {
 	case err != nil:
 		return nil, fmt.Errorf("get %s owner %s for %s %s.%s: %v",
 			kind, name, wl.GetKind(), wl.GetName(), wl.GetNamespace(), err)
 	case found:
 		dlog.Debugf(c, "replacing %s %s.%s, with owner %s %s", wl.GetKind(), wl.GetName(), wl.GetNamespace(), kind, name)
-		return k8sapi.Deployment(od.(*apps.Deployment)), nil
+		return k8sapi.WrapWorkload(od.(runtime.Object))
 	default:
 		return nil, fmt.Errorf("get %s owner %s for %s %s.%s: not found", kind, name, wl.GetKind(), wl.GetName(), wl.GetNamespace())
 	}
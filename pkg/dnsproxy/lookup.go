@@ -0,0 +1,120 @@
+// Package dnsproxy performs DNS lookups of any record type on behalf of a client. It's used by the
+// traffic-manager and the traffic-agents, and the results are passed to the client in DNS wire format.
+package dnsproxy
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/miekg/dns"
+)
+
+const resolvConf = "/etc/resolv.conf"
+
+// RRs is a slice of resource records that is printed as a comma separated list.
+type RRs []dns.RR
+
+func (rrs RRs) String() string {
+	if rrs == nil {
+		return "NOT FOUND"
+	}
+	if len(rrs) == 0 {
+		return "EMPTY"
+	}
+	ss := make([]string, len(rrs))
+	for i, rr := range rrs {
+		ss[i] = rr.String()
+	}
+	return strings.Join(ss, ", ")
+}
+
+var clientConfig struct {
+	sync.Once
+	config *dns.ClientConfig
+	err    error
+}
+
+func loadClientConfig() (*dns.ClientConfig, error) {
+	clientConfig.Do(func() {
+		clientConfig.config, clientConfig.err = dns.ClientConfigFromFile(resolvConf)
+	})
+	return clientConfig.config, clientConfig.err
+}
+
+// Lookup performs a DNS lookup of the given query type and name using the nameservers and the search
+// path of /etc/resolv.conf. It returns the answer and the response code. The answer is nil when the
+// response code isn't dns.RcodeSuccess.
+func Lookup(ctx context.Context, qType uint16, name string) (RRs, int, error) {
+	cfg, err := loadClientConfig()
+	if err != nil {
+		return nil, dns.RcodeServerFailure, err
+	}
+	return lookup(ctx, cfg, qType, name)
+}
+
+func lookup(ctx context.Context, cfg *dns.ClientConfig, qType uint16, name string) (RRs, int, error) {
+	if len(cfg.Servers) == 0 {
+		return nil, dns.RcodeServerFailure, fmt.Errorf("no nameservers found in %s", resolvConf)
+	}
+
+	// The name is found when a nameserver responds with something else than NXDOMAIN for one
+	// of the names of the search path. A response without answers is only used when no name
+	// of the search path has answers.
+	var empty RRs
+	for _, qn := range cfg.NameList(name) {
+		r, err := exchange(ctx, cfg, qType, qn)
+		if err != nil {
+			return nil, dns.RcodeServerFailure, err
+		}
+		switch r.Rcode {
+		case dns.RcodeNameError:
+			continue
+		case dns.RcodeSuccess:
+			if len(r.Answer) > 0 {
+				return renameRRs(r.Answer, qn, dns.Fqdn(name)), dns.RcodeSuccess, nil
+			}
+			empty = RRs{}
+		default:
+			return nil, r.Rcode, nil
+		}
+	}
+	if empty != nil {
+		return empty, dns.RcodeSuccess, nil
+	}
+	return nil, dns.RcodeNameError, nil
+}
+
+// exchange sends the query to the nameservers in turn until one of them responds.
+func exchange(ctx context.Context, cfg *dns.ClientConfig, qType uint16, qName string) (r *dns.Msg, err error) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(qName, qType)
+	for _, server := range cfg.Servers {
+		addr := net.JoinHostPort(server, cfg.Port)
+		dc := &dns.Client{Net: "udp"}
+		if r, _, err = dc.ExchangeContext(ctx, msg, addr); err == nil && r.Truncated {
+			dc.Net = "tcp"
+			r, _, err = dc.ExchangeContext(ctx, msg, addr)
+		}
+		if err == nil || ctx.Err() != nil {
+			break
+		}
+	}
+	return r, err
+}
+
+// renameRRs gives the records of the name that was found using the search path the name that was
+// asked for, so that they match the question of the client.
+func renameRRs(rrs []dns.RR, from, to string) RRs {
+	if from == to {
+		return rrs
+	}
+	for _, rr := range rrs {
+		if hdr := rr.Header(); strings.EqualFold(hdr.Name, from) {
+			hdr.Name = to
+		}
+	}
+	return rrs
+}
@@ -0,0 +1,132 @@
+package dnsproxy
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/datawire/dlib/dlog"
+)
+
+// zone is a dns.Handler that answers from a fixed set of records.
+type zone map[string][]dns.RR
+
+func (z zone) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
+	q := r.Question[0]
+	msg := new(dns.Msg)
+	rrs, ok := z[q.Name]
+	if !ok {
+		msg.SetRcode(r, dns.RcodeNameError)
+	} else {
+		msg.SetReply(r)
+		for _, rr := range rrs {
+			if rr.Header().Rrtype == q.Qtype {
+				msg.Answer = append(msg.Answer, rr)
+			}
+		}
+	}
+	_ = w.WriteMsg(msg)
+}
+
+func startServer(t *testing.T, z zone) *dns.ClientConfig {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	started := make(chan struct{})
+	srv := &dns.Server{PacketConn: pc, Handler: z, NotifyStartedFunc: func() { close(started) }}
+	go func() { _ = srv.ActivateAndServe() }()
+	t.Cleanup(func() { _ = srv.Shutdown() })
+	<-started
+
+	host, port, err := net.SplitHostPort(pc.LocalAddr().String())
+	require.NoError(t, err)
+	return &dns.ClientConfig{
+		Servers: []string{host},
+		Port:    port,
+		Search:  []string{"default.svc.cluster.local", "svc.cluster.local"},
+		Ndots:   5,
+	}
+}
+
+func mustRR(t *testing.T, s string) dns.RR {
+	rr, err := dns.NewRR(s)
+	require.NoError(t, err)
+	return rr
+}
+
+func TestLookup(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+	cfg := startServer(t, zone{
+		"_grpc._tcp.echo.default.svc.cluster.local.": {
+			mustRR(t, "_grpc._tcp.echo.default.svc.cluster.local. 30 IN SRV 0 100 8080 echo-0.echo.default.svc.cluster.local."),
+		},
+		"echo.default.svc.cluster.local.": {
+			mustRR(t, "echo.default.svc.cluster.local. 30 IN A 10.1.0.5"),
+			mustRR(t, `echo.default.svc.cluster.local. 30 IN TXT "hello"`),
+		},
+		"5.0.1.10.in-addr.arpa.": {
+			mustRR(t, "5.0.1.10.in-addr.arpa. 30 IN PTR echo-0.echo.default.svc.cluster.local."),
+		},
+	})
+
+	t.Run("search path", func(t *testing.T) {
+		rrs, rCode, err := lookup(ctx, cfg, dns.TypeSRV, "_grpc._tcp.echo")
+		require.NoError(t, err)
+		assert.Equal(t, dns.RcodeSuccess, rCode)
+		require.Len(t, rrs, 1)
+		srv, ok := rrs[0].(*dns.SRV)
+		require.True(t, ok)
+		assert.Equal(t, "_grpc._tcp.echo.", srv.Hdr.Name)
+		assert.Equal(t, uint16(8080), srv.Port)
+	})
+
+	t.Run("fully qualified", func(t *testing.T) {
+		rrs, rCode, err := lookup(ctx, cfg, dns.TypePTR, "5.0.1.10.in-addr.arpa.")
+		require.NoError(t, err)
+		assert.Equal(t, dns.RcodeSuccess, rCode)
+		require.Len(t, rrs, 1)
+		assert.Equal(t, "echo-0.echo.default.svc.cluster.local.", rrs[0].(*dns.PTR).Ptr)
+	})
+
+	t.Run("no records of type", func(t *testing.T) {
+		rrs, rCode, err := lookup(ctx, cfg, dns.TypeAAAA, "echo.default")
+		require.NoError(t, err)
+		assert.Equal(t, dns.RcodeSuccess, rCode)
+		assert.NotNil(t, rrs)
+		assert.Empty(t, rrs)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		rrs, rCode, err := lookup(ctx, cfg, dns.TypeA, "missing")
+		require.NoError(t, err)
+		assert.Equal(t, dns.RcodeNameError, rCode)
+		assert.Nil(t, rrs)
+	})
+
+	t.Run("rpc", func(t *testing.T) {
+		rrs, rCode, err := lookup(ctx, cfg, dns.TypeTXT, "echo")
+		require.NoError(t, err)
+		r, err := ToRPC(rrs, rCode)
+		require.NoError(t, err)
+		rrs2, rCode2, err := FromRPC(r)
+		require.NoError(t, err)
+		assert.Equal(t, rCode, rCode2)
+		assert.Equal(t, rrs.String(), rrs2.String())
+
+		r, err = ToRPC(nil, dns.RcodeNameError)
+		require.NoError(t, err)
+		rrs2, rCode2, err = FromRPC(r)
+		require.NoError(t, err)
+		assert.Equal(t, dns.RcodeNameError, rCode2)
+		assert.Nil(t, rrs2)
+	})
+
+	t.Run("no nameservers", func(t *testing.T) {
+		_, rCode, err := lookup(context.Background(), &dns.ClientConfig{}, dns.TypeA, "echo")
+		assert.Error(t, err)
+		assert.Equal(t, dns.RcodeServerFailure, rCode)
+	})
+}
@@ -0,0 +1,36 @@
+package dnsproxy
+
+import (
+	"github.com/miekg/dns"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+)
+
+// ToRPC returns the given answer and response code as a DNSResponse.
+func ToRPC(rrs RRs, rCode int) (*rpc.DNSResponse, error) {
+	msg := new(dns.Msg)
+	msg.Rcode = rCode
+	msg.Answer = rrs
+	bs, err := msg.Pack()
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.DNSResponse{RCode: int32(rCode), Rrs: bs}, nil
+}
+
+// FromRPC returns the answer and the response code of the given DNSResponse. The answer is nil unless
+// the response code is NOERROR.
+func FromRPC(r *rpc.DNSResponse) (RRs, int, error) {
+	rCode := int(r.GetRCode())
+	if rCode != dns.RcodeSuccess {
+		return nil, rCode, nil
+	}
+	msg := new(dns.Msg)
+	if err := msg.Unpack(r.GetRrs()); err != nil {
+		return nil, dns.RcodeServerFailure, err
+	}
+	if msg.Answer == nil {
+		return RRs{}, rCode, nil
+	}
+	return msg.Answer, rCode, nil
+}
@@ -5,24 +5,31 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"net/http"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/blang/semver"
 
 	"github.com/TinderBackend/telepresence/rpc/v2/manager"
 	"github.com/TinderBackend/telepresence/v2/pkg/iputil"
+	"github.com/TinderBackend/telepresence/v2/pkg/matcher"
 	"github.com/TinderBackend/telepresence/v2/pkg/restapi"
 	"github.com/TinderBackend/telepresence/v2/pkg/tunnel"
 	"github.com/datawire/dlib/dlog"
 )
 
 type Forwarder struct {
+	// stats is accessed atomically, so it must be the first field to be 64-bit aligned
+	stats Stats
+
 	mu sync.Mutex
 
 	lCtx       context.Context
 	lCancel    context.CancelFunc
-	listenAddr *net.TCPAddr
+	listenAddr net.Addr
 
 	tCtx       context.Context
 	tCancel    context.CancelFunc
@@ -32,15 +39,33 @@ type Forwarder struct {
 	manager     manager.ManagerClient
 	sessionInfo *manager.SessionInfo
 
-	intercept  *manager.InterceptInfo
+	intercepts []*intercept
 	mgrVersion semver.Version
+
+	// mirrorSlots limits the number of mirrored HTTP requests in flight
+	mirrorSlots chan struct{}
+}
+
+// intercept is an intercept that the forwarder forwards to, together with the matcher that decides
+// what HTTP requests it receives. A nil matcher means that all connections are intercepted.
+type intercept struct {
+	info    *manager.InterceptInfo
+	matcher matcher.Request
+}
+
+func (ic *intercept) String() string {
+	is := ic.info.Spec
+	return fmt.Sprintf("'%s' (%s:%d)", is.Name, is.Client, is.TargetPort)
 }
 
-func NewForwarder(listen *net.TCPAddr, targetHost string, targetPort int32) *Forwarder {
+// NewForwarder creates a forwarder that forwards from the given listen address to the given target. The
+// forwarder forwards UDP datagrams when the listen address is a *net.UDPAddr and TCP connections otherwise.
+func NewForwarder(listen net.Addr, targetHost string, targetPort int32) *Forwarder {
 	return &Forwarder{
-		listenAddr: listen,
-		targetHost: targetHost,
-		targetPort: targetPort,
+		listenAddr:  listen,
+		targetHost:  targetHost,
+		targetPort:  targetPort,
+		mirrorSlots: make(chan struct{}, maxPendingMirrors),
 	}
 }
 
@@ -53,6 +78,13 @@ func (f *Forwarder) SetManager(sessionInfo *manager.SessionInfo, manager manager
 }
 
 func (f *Forwarder) Serve(ctx context.Context) error {
+	if _, ok := f.listenAddr.(*net.UDPAddr); ok {
+		conn, err := f.ListenUDP(ctx)
+		if err != nil {
+			return err
+		}
+		return f.ServeUDP(ctx, conn)
+	}
 	listener, err := f.Listen(ctx)
 	if err != nil {
 		return err
@@ -95,7 +127,17 @@ func (f *Forwarder) ServeListener(ctx context.Context, listener *net.TCPListener
 }
 
 func (f *Forwarder) Listen(ctx context.Context) (*net.TCPListener, error) {
+	listenAddr, ok := f.startListen(ctx).(*net.TCPAddr)
+	if !ok {
+		return nil, fmt.Errorf("unable to listen for TCP connections on %s", f.listenAddr)
+	}
+	return net.ListenTCP("tcp", listenAddr)
+}
+
+// startListen sets up the listener and target lifetimes and returns the address to listen to.
+func (f *Forwarder) startListen(ctx context.Context) net.Addr {
 	f.mu.Lock()
+	defer f.mu.Unlock()
 
 	// Set up listener lifetime (same as the overall forwarder lifetime)
 	f.lCtx, f.lCancel = context.WithCancel(ctx)
@@ -103,10 +145,7 @@ func (f *Forwarder) Listen(ctx context.Context) (*net.TCPListener, error) {
 
 	// Set up target lifetime
 	f.tCtx, f.tCancel = context.WithCancel(f.lCtx)
-	listenAddr := f.listenAddr
-
-	f.mu.Unlock()
-	return net.ListenTCP("tcp", listenAddr)
+	return f.listenAddr
 }
 
 func (f *Forwarder) Close() error {
@@ -121,12 +160,14 @@ func (f *Forwarder) Target() (string, int32) {
 	return f.targetHost, f.targetPort
 }
 
-func (f *Forwarder) InterceptInfo() *restapi.InterceptInfo {
+// InterceptInfo returns information about whether a request with the given path and headers
+// would be intercepted.
+func (f *Forwarder) InterceptInfo(path string, headers http.Header) *restapi.InterceptInfo {
 	ii := &restapi.InterceptInfo{}
 	f.mu.Lock()
-	if f.intercept != nil {
+	if ic := f.matchingIntercept(path, headers); ic != nil {
 		ii.Intercepted = true
-		ii.Metadata = f.intercept.Metadata
+		ii.Metadata = ic.info.Metadata
 	}
 	f.mu.Unlock()
 	return ii
@@ -134,41 +175,111 @@ func (f *Forwarder) InterceptInfo() *restapi.InterceptInfo {
 
 func (f *Forwarder) Intercepting() bool {
 	f.mu.Lock()
-	intercepting := f.intercept != nil
+	intercepting := len(f.intercepts) > 0
 	f.mu.Unlock()
 	return intercepting
 }
 
-func (f *Forwarder) SetIntercepting(intercept *manager.InterceptInfo) {
+// matchingIntercept returns the first intercept that matches the given path and headers, or nil
+// if no such intercept exists. Mirror intercepts are never returned, because they don't route requests
+// away from the target. The forwarder's mutex must be locked when this method is called.
+func (f *Forwarder) matchingIntercept(path string, headers http.Header) *intercept {
+	for _, ic := range f.intercepts {
+		if ic.info.Spec.Mirror {
+			continue
+		}
+		if ic.matcher == nil || ic.matcher.Matches(path, headers) {
+			return ic
+		}
+	}
+	return nil
+}
+
+// SetIntercepting sets the intercepts that this forwarder should forward to. An empty slice means that
+// all connections are forwarded to the target. An intercept that uses the "tcp" mechanism receives
+// all connections, so it must be the only intercept in the slice that isn't a mirror. Mirror intercepts
+// receive copies of the traffic that is served by the target or by other intercepts. While a "tcp"
+// intercept is active, connections aren't parsed as HTTP, so only mirrors that use the "tcp" mechanism
+// receive copies.
+func (f *Forwarder) SetIntercepting(intercepts []*manager.InterceptInfo) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
-	iceptInfo := func(ii *manager.InterceptInfo) string {
-		is := ii.Spec
-		return fmt.Sprintf("'%s' (%s:%d)", is.Name, is.Client, is.TargetPort)
+	ics := make([]*intercept, 0, len(intercepts))
+	for _, ii := range intercepts {
+		rm, err := InterceptMatcher(ii)
+		if err != nil {
+			dlog.Errorf(f.lCtx, "Unable to forward to intercept %q: %v", ii.Id, err)
+			continue
+		}
+		ics = append(ics, &intercept{info: ii, matcher: rm})
 	}
-	if intercept == nil {
-		if f.intercept == nil {
-			return
+	if sameIntercepts(f.intercepts, ics) {
+		return
+	}
+	dlog.Debugf(f.lCtx, "Forward target changed from %s to %s", f.targetString(f.intercepts), f.targetString(ics))
+
+	// Connections served using HTTP look up their intercept for each request, so they can remain as
+	// long as both the old and the new intercepts use HTTP. All other connections are dropped.
+	if !(httpOnly(f.intercepts) && httpOnly(ics)) {
+		f.tCancel()
+		f.tCtx, f.tCancel = context.WithCancel(f.lCtx)
+	}
+	f.intercepts = ics
+}
+
+func (f *Forwarder) targetString(ics []*intercept) string {
+	switch len(ics) {
+	case 0:
+		return fmt.Sprintf("%s:%d", f.targetHost, f.targetPort)
+	case 1:
+		return "intercept " + ics[0].String()
+	default:
+		ss := make([]string, len(ics))
+		for i, ic := range ics {
+			ss[i] = ic.String()
 		}
-		dlog.Debugf(f.lCtx, "Forward target changed from intercept %s to %s:%d", iceptInfo(f.intercept), f.targetHost, f.targetPort)
-	} else {
-		if f.intercept == nil {
-			dlog.Debugf(f.lCtx, "Forward target changed from %s:%d to intercept %s", f.targetHost, f.targetPort, iceptInfo(intercept))
-		} else {
-			if f.intercept.Id == intercept.Id {
-				return
-			}
-			dlog.Debugf(f.lCtx, "Forward target changed from intercept %s to intercept %q", iceptInfo(f.intercept), iceptInfo(intercept))
+		return "intercepts " + strings.Join(ss, ", ")
+	}
+}
+
+func sameIntercepts(a, b []*intercept) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].info.Id != b[i].info.Id || a[i].info.Disposition != b[i].info.Disposition {
+			return false
 		}
 	}
+	return true
+}
 
-	// Drop existing connections
-	f.tCancel()
+// isSuspended returns true if the client of the given intercept is away. The traffic-agent only forwards
+// such an intercept when the requests that it receives must be answered as unavailable.
+func isSuspended(ii *manager.InterceptInfo) bool {
+	return ii.Disposition == manager.InterceptDispositionType_SUSPENDED
+}
 
-	// Set up new target and lifetime
-	f.tCtx, f.tCancel = context.WithCancel(f.lCtx)
-	f.intercept = intercept
+// httpOnly returns true if the given slice is non-empty and contains only HTTP intercepts.
+func httpOnly(ics []*intercept) bool {
+	for _, ic := range ics {
+		if ic.matcher == nil {
+			return false
+		}
+	}
+	return len(ics) > 0
+}
+
+// tcpOnly returns the intercepts that use the "tcp" mechanism.
+func tcpOnly(ics []*intercept) []*intercept {
+	var tcps []*intercept
+	for _, ic := range ics {
+		if ic.matcher == nil {
+			tcps = append(tcps, ic)
+		}
+	}
+	return tcps
 }
 
 func (f *Forwarder) forwardConn(clientConn *net.TCPConn) error {
@@ -176,10 +287,18 @@ func (f *Forwarder) forwardConn(clientConn *net.TCPConn) error {
 	ctx := f.tCtx
 	targetHost := f.targetHost
 	targetPort := f.targetPort
-	intercept := f.intercept
+	ics := f.intercepts
 	f.mu.Unlock()
-	if intercept != nil {
-		return f.interceptConn(ctx, clientConn, intercept)
+	routes, mirrors := splitMirrors(ics)
+	switch {
+	case len(routes) > 0 && !httpOnly(routes):
+		if isSuspended(routes[0].info) {
+			dlog.Debugf(ctx, "Closing connection from %s, intercept %s is suspended", clientConn.RemoteAddr(), routes[0])
+			return clientConn.Close()
+		}
+		return f.interceptConn(ctx, clientConn, routes[0].info, tcpOnly(mirrors))
+	case len(routes) > 0 || hasHTTP(mirrors):
+		return f.forwardHTTP(ctx, clientConn)
 	}
 
 	targetAddr, err := net.ResolveTCPAddr("tcp", fmt.Sprintf("%s:%d", targetHost, targetPort))
@@ -200,18 +319,31 @@ func (f *Forwarder) forwardConn(clientConn *net.TCPConn) error {
 		return fmt.Errorf("error on dial: %w", err)
 	}
 	defer targetConn.Close()
+	atomic.AddUint64(&f.stats.ForwardedConnections, 1)
+
+	// Mirrors that use the "tcp" mechanism get a copy of all data that the client sends
+	var src io.Reader = clientConn
+	if len(mirrors) > 0 {
+		mws := f.openMirrors(ctx, clientConn.RemoteAddr(), mirrors)
+		defer mws.Close()
+		src = io.TeeReader(clientConn, mws)
+	}
 
 	done := make(chan struct{})
 
 	go func() {
-		if _, err := io.Copy(targetConn, clientConn); err != nil {
+		n, err := io.Copy(targetConn, src)
+		atomic.AddUint64(&f.stats.ForwardedBytes, uint64(n))
+		if err != nil {
 			dlog.Debugf(ctx, "Error clientConn->targetConn: %+v", err)
 		}
 		_ = targetConn.CloseWrite()
 		done <- struct{}{}
 	}()
 	go func() {
-		if _, err := io.Copy(clientConn, targetConn); err != nil {
+		n, err := io.Copy(clientConn, targetConn)
+		atomic.AddUint64(&f.stats.ForwardedBytes, uint64(n))
+		if err != nil {
 			dlog.Debugf(ctx, "Error targetConn->clientConn: %+v", err)
 		}
 		_ = clientConn.CloseWrite()
@@ -230,32 +362,142 @@ func (f *Forwarder) forwardConn(clientConn *net.TCPConn) error {
 	return nil
 }
 
-func (f *Forwarder) interceptConn(ctx context.Context, conn net.Conn, iCept *manager.InterceptInfo) error {
+// interceptConn sends the given connection to the client of the given intercept. The given mirror
+// intercepts get a copy of all data that the connection sends.
+func (f *Forwarder) interceptConn(ctx context.Context, conn net.Conn, iCept *manager.InterceptInfo, mirrors []*intercept) error {
 	dlog.Infof(ctx, "Accept got connection from %s", conn.RemoteAddr())
 
-	srcIp, srcPort, err := iputil.SplitToIPPort(conn.RemoteAddr())
+	s, release, err := f.openInterceptStream(ctx, conn.RemoteAddr(), iCept)
 	if err != nil {
-		return fmt.Errorf("failed to parse intercept source address %s", conn.RemoteAddr())
+		return err
+	}
+	defer release()
+	if len(mirrors) > 0 {
+		mws := f.openMirrors(ctx, conn.RemoteAddr(), mirrors)
+		defer mws.Close()
+		conn = &teeConn{Conn: conn, w: mws}
+	}
+	f.bridgeInterceptStream(ctx, s, conn)
+	return nil
+}
+
+// Stats are the numbers of connections that a forwarder has opened to its target and to the clients of
+// intercepts, including mirror intercepts, and the numbers of bytes that those connections have sent and
+// received.
+type Stats struct {
+	ForwardedConnections   uint64
+	ForwardedBytes         uint64
+	InterceptedConnections uint64
+	InterceptedBytes       uint64
+}
+
+// Stats returns the numbers of connections and bytes that the forwarder has handled since it was created.
+func (f *Forwarder) Stats() Stats {
+	return Stats{
+		ForwardedConnections:   atomic.LoadUint64(&f.stats.ForwardedConnections),
+		ForwardedBytes:         atomic.LoadUint64(&f.stats.ForwardedBytes),
+		InterceptedConnections: atomic.LoadUint64(&f.stats.InterceptedConnections),
+		InterceptedBytes:       atomic.LoadUint64(&f.stats.InterceptedBytes),
+	}
+}
+
+// countingConn is a net.Conn that adds the number of bytes that are read from and written to it to a counter.
+type countingConn struct {
+	net.Conn
+	count *uint64
+}
+
+func (c *countingConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	atomic.AddUint64(c.count, uint64(n))
+	return n, err
+}
+
+func (c *countingConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	atomic.AddUint64(c.count, uint64(n))
+	return n, err
+}
+
+// teeConn is a net.Conn that writes a copy of all data that is read from it to a writer.
+type teeConn struct {
+	net.Conn
+	w io.Writer
+}
+
+func (c *teeConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		_, _ = c.w.Write(b[:n])
+	}
+	return n, err
+}
+
+// openConnIDs are the IDs of the streams to intercepting clients that the forwarders of this process have
+// open. The traffic-manager pairs the streams of a client by ID, so two streams must never share one, not
+// even when they stem from the same connection, e.g. the pooled streams of an HTTP connection.
+var openConnIDs = struct {
+	sync.Mutex
+	ids map[tunnel.ConnID]struct{}
+}{ids: make(map[tunnel.ConnID]struct{})}
+
+// reserveConnID returns an ID that no other open stream has, and a function that releases it. The ID is
+// the given one when it's available. Otherwise, its source port is incremented until an available ID
+// is found.
+func reserveConnID(id tunnel.ConnID) (tunnel.ConnID, func()) {
+	openConnIDs.Lock()
+	defer openConnIDs.Unlock()
+	srcPort := id.SourcePort()
+	for {
+		if _, ok := openConnIDs.ids[id]; !ok {
+			break
+		}
+		srcPort++
+		id = tunnel.NewConnID(id.Protocol(), id.Source(), id.Destination(), srcPort, id.DestinationPort())
+	}
+	openConnIDs.ids[id] = struct{}{}
+	return id, func() {
+		openConnIDs.Lock()
+		delete(openConnIDs.ids, id)
+		openConnIDs.Unlock()
+	}
+}
+
+// openInterceptStream opens a tunnel stream to the client of the given intercept. The stream's
+// connection ID is based on the given source address, and is unique among the open streams. The
+// returned function must be called when the stream is done.
+func (f *Forwarder) openInterceptStream(ctx context.Context, srcAddr net.Addr, iCept *manager.InterceptInfo) (tunnel.Stream, func(), error) {
+	srcIp, srcPort, err := iputil.SplitToIPPort(srcAddr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse intercept source address %s", srcAddr)
 	}
 
 	spec := iCept.Spec
 	destIp := iputil.Parse(spec.TargetHost)
-	id := tunnel.NewConnID(tunnel.IPProto(conn.RemoteAddr().Network()), srcIp, destIp, srcPort, uint16(spec.TargetPort))
+	id, release := reserveConnID(tunnel.NewConnID(tunnel.IPProto(srcAddr.Network()), srcIp, destIp, srcPort, uint16(spec.TargetPort)))
 
 	ms, err := f.manager.Tunnel(ctx)
 	if err != nil {
-		return fmt.Errorf("call to manager.Tunnel() failed. Id %s: %v", id, err)
+		release()
+		return nil, nil, fmt.Errorf("call to manager.Tunnel() failed. Id %s: %v", id, err)
 	}
 
 	s, err := tunnel.NewClientStream(ctx, ms, id, f.sessionInfo.SessionId, time.Duration(spec.RoundtripLatency), time.Duration(spec.DialTimeout))
 	if err != nil {
-		return err
+		release()
+		return nil, nil, err
 	}
 	if err = s.Send(ctx, tunnel.SessionMessage(iCept.ClientSession.SessionId)); err != nil {
-		return fmt.Errorf("unable to send client session id. Id %s: %v", id, err)
+		release()
+		return nil, nil, fmt.Errorf("unable to send client session id. Id %s: %v", id, err)
 	}
-	d := tunnel.NewConnEndpoint(s, conn)
+	atomic.AddUint64(&f.stats.InterceptedConnections, 1)
+	return tunnel.WithFaults(s, spec.Faults), release, nil
+}
+
+// bridgeInterceptStream bridges the given stream with the given connection and waits until the bridge is done.
+func (f *Forwarder) bridgeInterceptStream(ctx context.Context, s tunnel.Stream, conn net.Conn) {
+	d := tunnel.NewConnEndpoint(s, &countingConn{Conn: conn, count: &f.stats.InterceptedBytes})
 	d.Start(ctx)
 	<-d.Done()
-	return nil
 }
@@ -0,0 +1,342 @@
+package forwarder
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/pkg/matcher"
+	"github.com/TinderBackend/telepresence/v2/pkg/restapi"
+	"github.com/datawire/dlib/dlog"
+)
+
+// mechanismArgs parses the --flag=value arguments of an intercept's mechanism into a map of
+// flag names to values. Flags without values are ignored.
+func mechanismArgs(ii *manager.InterceptInfo) map[string][]string {
+	args := ii.Spec.MechanismArgs
+	m := make(map[string][]string, len(args))
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "--") {
+			continue
+		}
+		if eqi := strings.IndexByte(arg, '='); eqi > 2 && eqi+1 < len(arg) {
+			k := arg[2:eqi]
+			m[k] = append(m[k], arg[eqi+1:])
+		}
+	}
+	return m
+}
+
+// InterceptMatcher returns the matcher.Request that decides what HTTP requests the given intercept
+// should receive. A nil matcher is returned for intercepts that use the "tcp" mechanism, because
+// they receive all connections.
+//
+// The "route" mechanism understands the following mechanism arguments:
+//
+//   --header=NAME=VALUE   requests must have a header NAME matching VALUE (may be a regexp)
+//   --header=auto         requests must have a x-telepresence-intercept-id header equal to the intercept ID
+//   --header=all          no header matching
+//   --path-equal=PATH     requests must have a path equal to PATH
+//   --path-prefix=PREFIX  requests must have a path that starts with PREFIX
+//   --path-regex=REGEX    requests must have a path that matches REGEX
+//   --meta=KEY=VALUE      metadata to associate with the intercept (see InterceptMetadata)
+//
+func InterceptMatcher(ii *manager.InterceptInfo) (matcher.Request, error) {
+	switch ii.Spec.Mechanism {
+	case "", "tcp":
+		return nil, nil
+	case "route":
+	default:
+		return nil, fmt.Errorf("unsupported intercept mechanism %q", ii.Spec.Mechanism)
+	}
+
+	rm := make(map[string]string)
+	for k, vs := range mechanismArgs(ii) {
+		switch k {
+		case "header":
+			for _, v := range vs {
+				switch v {
+				case "all":
+				case "auto":
+					rm[restapi.HeaderInterceptID] = ii.Id
+				default:
+					eqi := strings.IndexByte(v, '=')
+					if eqi <= 0 {
+						return nil, fmt.Errorf("invalid --%s %q, must be on the form NAME=VALUE, auto, or all", k, v)
+					}
+					rm[v[:eqi]] = v[eqi+1:]
+				}
+			}
+		case "path-equal", "path-prefix", "path-regex":
+			rm[":"+k+":"] = vs[len(vs)-1]
+		case "meta":
+		default:
+			return nil, fmt.Errorf("unknown flag --%s for mechanism %q", k, ii.Spec.Mechanism)
+		}
+	}
+	return matcher.NewRequest(rm)
+}
+
+// InterceptMetadata returns the key=value pairs given using --meta in the mechanism arguments of the given
+// intercept, or nil when no such pairs exist.
+func InterceptMetadata(ii *manager.InterceptInfo) map[string]string {
+	var md map[string]string
+	for _, v := range mechanismArgs(ii)["meta"] {
+		if eqi := strings.IndexByte(v, '='); eqi > 0 {
+			if md == nil {
+				md = make(map[string]string)
+			}
+			md[v[:eqi]] = v[eqi+1:]
+		}
+	}
+	return md
+}
+
+// MechanismArgsDesc returns a human-readable description of what the given matcher intercepts.
+func MechanismArgsDesc(rm matcher.Request) string {
+	if rm == nil {
+		return "all TCP connections"
+	}
+	desc := rm.String()
+	if strings.HasPrefix(desc, "all ") {
+		return "all HTTP requests"
+	}
+	return "HTTP " + desc
+}
+
+// errListenerDone is returned by the connListener when its connection has been closed.
+var errListenerDone = errors.New("connection closed")
+
+// connListener is a net.Listener that produces one single connection and then blocks until that
+// connection is closed.
+type connListener struct {
+	conn   net.Conn
+	done   chan struct{}
+	accept chan net.Conn
+}
+
+func newConnListener(conn net.Conn) *connListener {
+	l := &connListener{done: make(chan struct{}), accept: make(chan net.Conn, 1)}
+	l.conn = &listenerConn{Conn: conn, done: l.done}
+	l.accept <- l.conn
+	return l
+}
+
+func (l *connListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.accept:
+		return conn, nil
+	case <-l.done:
+		return nil, errListenerDone
+	}
+}
+
+func (l *connListener) Close() error {
+	return nil
+}
+
+func (l *connListener) Addr() net.Addr {
+	return l.conn.LocalAddr()
+}
+
+// listenerConn closes the done channel of its connListener when it is closed.
+type listenerConn struct {
+	net.Conn
+	once sync.Once
+	done chan struct{}
+}
+
+func (c *listenerConn) Close() error {
+	err := c.Conn.Close()
+	c.once.Do(func() { close(c.done) })
+	return err
+}
+
+// httpConn routes each HTTP request received on one client connection either to the target or to the
+// first intercept whose matcher matches the request. Copies of the request are sent to all mirror
+// intercepts that match it.
+type httpConn struct {
+	sync.Mutex
+	ctx        context.Context
+	f          *Forwarder
+	srcAddr    net.Addr
+	target     *httputil.ReverseProxy
+	intercepts map[string]*httputil.ReverseProxy
+}
+
+func (hc *httpConn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	hc.f.mu.Lock()
+	ic := hc.f.matchingIntercept(r.URL.Path, r.Header)
+	mirrors := hc.f.matchingMirrors(r.URL.Path, r.Header)
+	hc.f.mu.Unlock()
+	var h http.Handler = hc.target
+	switch {
+	case ic == nil:
+	case isSuspended(ic.info):
+		h = http.HandlerFunc(suspendedIntercept)
+	default:
+		h = hc.interceptProxy(ic.info)
+	}
+	if len(mirrors) > 0 {
+		hc.serveMirrored(w, r, h, mirrors)
+	} else {
+		h.ServeHTTP(w, r)
+	}
+}
+
+// suspendedIntercept answers a request that is routed to an intercept whose client is away.
+func suspendedIntercept(w http.ResponseWriter, _ *http.Request) {
+	http.Error(w, "The intercept that receives this request is suspended", http.StatusServiceUnavailable)
+}
+
+// interceptProxy returns the reverse proxy that sends requests to the given intercept, creating it
+// if it doesn't exist.
+func (hc *httpConn) interceptProxy(ii *manager.InterceptInfo) *httputil.ReverseProxy {
+	hc.Lock()
+	defer hc.Unlock()
+	p, ok := hc.intercepts[ii.Id]
+	if !ok {
+		spec := ii.Spec
+		p = newReverseProxy(hc.ctx, fmt.Sprintf("%s:%d", spec.TargetHost, spec.TargetPort), func(context.Context) (net.Conn, error) {
+			return hc.f.dialIntercept(hc.ctx, hc.srcAddr, ii)
+		})
+		hc.intercepts[ii.Id] = p
+	}
+	return p
+}
+
+// close closes all idle connections that the proxies of this httpConn keep open.
+func (hc *httpConn) close() {
+	hc.target.Transport.(*proxyTransport).CloseIdleConnections()
+	hc.Lock()
+	for _, p := range hc.intercepts {
+		p.Transport.(*proxyTransport).CloseIdleConnections()
+	}
+	hc.Unlock()
+}
+
+// proxyTransport sends requests that were received using HTTP/2 using h2c, and all other
+// requests using HTTP/1.1.
+type proxyTransport struct {
+	h1 *http.Transport
+	h2 *http2.Transport
+}
+
+func (t *proxyTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.ProtoMajor == 2 {
+		return t.h2.RoundTrip(r)
+	}
+	return t.h1.RoundTrip(r)
+}
+
+func (t *proxyTransport) CloseIdleConnections() {
+	t.h1.CloseIdleConnections()
+	t.h2.CloseIdleConnections()
+}
+
+// newReverseProxy creates a reverse proxy that sends all requests to the given host using connections
+// obtained from the given dial function.
+func newReverseProxy(ctx context.Context, host string, dial func(context.Context) (net.Conn, error)) *httputil.ReverseProxy {
+	return &httputil.ReverseProxy{
+		Director: func(r *http.Request) {
+			r.URL.Scheme = "http"
+			r.URL.Host = host
+		},
+		Transport: &proxyTransport{
+			h1: &http.Transport{
+				DialContext: func(dctx context.Context, _, _ string) (net.Conn, error) {
+					return dial(dctx)
+				},
+				IdleConnTimeout: 90 * time.Second,
+			},
+			h2: &http2.Transport{
+				AllowHTTP: true,
+				DialTLS: func(_, _ string, _ *tls.Config) (net.Conn, error) {
+					return dial(ctx)
+				},
+			},
+		},
+		FlushInterval: -1,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			dlog.Errorf(ctx, "HTTP proxy to %s failed: %v", host, err)
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}
+}
+
+// forwardHTTP serves the given connection using an HTTP server that understands both HTTP/1.1 and h2c. Requests
+// that match an intercept are sent to the client of that intercept. All other requests are sent to the target.
+func (f *Forwarder) forwardHTTP(ctx context.Context, clientConn *net.TCPConn) error {
+	f.mu.Lock()
+	targetHost := f.targetHost
+	targetPort := f.targetPort
+	f.mu.Unlock()
+
+	srcAddr := clientConn.RemoteAddr()
+	ctx = dlog.WithField(ctx, "client", srcAddr.String())
+	dlog.Debug(ctx, "Forwarding HTTP...")
+	defer dlog.Debug(ctx, "Done forwarding HTTP")
+
+	targetAddr := fmt.Sprintf("%s:%d", targetHost, targetPort)
+	hc := &httpConn{
+		ctx:     ctx,
+		f:       f,
+		srcAddr: srcAddr,
+		target: newReverseProxy(ctx, targetAddr, func(dctx context.Context) (net.Conn, error) {
+			var d net.Dialer
+			conn, err := d.DialContext(dctx, "tcp", targetAddr)
+			if err != nil {
+				return nil, err
+			}
+			atomic.AddUint64(&f.stats.ForwardedConnections, 1)
+			return &countingConn{Conn: conn, count: &f.stats.ForwardedBytes}, nil
+		}),
+		intercepts: make(map[string]*httputil.ReverseProxy),
+	}
+	defer hc.close()
+
+	l := newConnListener(clientConn)
+	srv := &http.Server{
+		Handler:     h2c.NewHandler(hc, &http2.Server{}),
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = srv.Close()
+			_ = l.conn.Close()
+		case <-l.done:
+		}
+	}()
+	if err := srv.Serve(l); err != nil && !errors.Is(err, errListenerDone) && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// dialIntercept opens a tunnel to the intercepting client and returns the local end of a connection that is
+// bridged with that tunnel.
+func (f *Forwarder) dialIntercept(ctx context.Context, srcAddr net.Addr, intercept *manager.InterceptInfo) (net.Conn, error) {
+	s, release, err := f.openInterceptStream(ctx, srcAddr, intercept)
+	if err != nil {
+		return nil, err
+	}
+	local, remote := net.Pipe()
+	go func() {
+		defer release()
+		f.bridgeInterceptStream(ctx, s, remote)
+	}()
+	return local, nil
+}
@@ -0,0 +1,41 @@
+package forwarder
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/pkg/matcher"
+)
+
+func TestInterceptMatcher(t *testing.T) {
+	matcherFor := func(mechanism string, args ...string) (matcher.Request, error) {
+		return InterceptMatcher(&manager.InterceptInfo{
+			Id:   "abc:route",
+			Spec: &manager.InterceptSpec{Name: "route", Mechanism: mechanism, MechanismArgs: args},
+		})
+	}
+
+	rm, err := matcherFor("tcp")
+	require.NoError(t, err)
+	assert.Nil(t, rm)
+
+	rm, err = matcherFor("route", "--header=auto", "--path-prefix=/api", "--path-equal=")
+	require.NoError(t, err)
+	assert.True(t, rm.Matches("/api/x", http.Header{"X-Telepresence-Intercept-Id": {"abc:route"}}))
+	assert.False(t, rm.Matches("/api/x", http.Header{}))
+	assert.False(t, rm.Matches("/other", http.Header{"X-Telepresence-Intercept-Id": {"abc:route"}}))
+
+	// Flags that the traffic-agent can't honor are rejected rather than ignored
+	_, err = matcherFor("route", "--plaintext=true")
+	assert.Error(t, err)
+	_, err = matcherFor("route", "--header=x-user")
+	assert.Error(t, err)
+
+	// The "http" mechanism belongs to the Ambassador Smart Agent
+	_, err = matcherFor("http", "--header=auto")
+	assert.Error(t, err)
+}
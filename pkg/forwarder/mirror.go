@@ -0,0 +1,276 @@
+package forwarder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/datawire/dlib/dlog"
+)
+
+// maxMirrorBodySize is the largest request body that is copied to mirror intercepts. Requests with larger
+// bodies are only sent to the target.
+const maxMirrorBodySize = 1 << 20
+
+// maxPendingMirrors is the maximum number of mirrored HTTP requests that a forwarder has in flight. Requests
+// that arrive when that many are pending aren't mirrored.
+const maxPendingMirrors = 32
+
+// mirrorTimeout is how long a mirrored HTTP request may take before it's abandoned.
+const mirrorTimeout = 30 * time.Second
+
+// mirrorDialTimeout is how long the tunnel to a mirror intercept may take to open before the mirror is abandoned.
+const mirrorDialTimeout = 10 * time.Second
+
+// mirrorQueueSize is the number of chunks of data that a mirrorWriter queues before it gives up on its mirror.
+const mirrorQueueSize = 0x40
+
+// splitMirrors splits the given intercepts into the ones that route traffic away from the target
+// and the ones that only receive copies of it.
+func splitMirrors(ics []*intercept) (routes, mirrors []*intercept) {
+	for _, ic := range ics {
+		if ic.info.Spec.Mirror {
+			mirrors = append(mirrors, ic)
+		} else {
+			routes = append(routes, ic)
+		}
+	}
+	return routes, mirrors
+}
+
+// hasHTTP returns true if one of the given intercepts matches HTTP requests.
+func hasHTTP(ics []*intercept) bool {
+	for _, ic := range ics {
+		if ic.matcher != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// matchingMirrors returns the mirror intercepts that match the given path and headers. Mirrors that use
+// the "tcp" mechanism match all requests. The forwarder's mutex must be locked when this method is called.
+func (f *Forwarder) matchingMirrors(path string, headers http.Header) []*intercept {
+	var mirrors []*intercept
+	for _, ic := range f.intercepts {
+		if ic.info.Spec.Mirror && (ic.matcher == nil || ic.matcher.Matches(path, headers)) {
+			mirrors = append(mirrors, ic)
+		}
+	}
+	return mirrors
+}
+
+// bodyCapture captures the data that is read from a request body, so that the body can be sent to
+// mirrors once the target has read it.
+type bodyCapture struct {
+	io.ReadCloser
+	sync.Mutex
+	buf      bytes.Buffer
+	overflow bool
+	eof      bool
+}
+
+func (bc *bodyCapture) Read(p []byte) (int, error) {
+	n, err := bc.ReadCloser.Read(p)
+	bc.Lock()
+	if !bc.overflow {
+		if bc.buf.Len()+n > maxMirrorBodySize {
+			bc.overflow = true
+			bc.buf = bytes.Buffer{}
+		} else {
+			bc.buf.Write(p[:n])
+		}
+	}
+	if errors.Is(err, io.EOF) {
+		bc.eof = true
+	}
+	bc.Unlock()
+	return n, err
+}
+
+// body returns the captured body, or false if the body wasn't read in full or is too large to be mirrored.
+func (bc *bodyCapture) body() ([]byte, bool) {
+	bc.Lock()
+	defer bc.Unlock()
+	return bc.buf.Bytes(), bc.eof && !bc.overflow
+}
+
+// serveMirrored serves the given request using the given handler, and then sends copies of it to the given
+// mirror intercepts without waiting for their responses, which are discarded.
+func (hc *httpConn) serveMirrored(w http.ResponseWriter, r *http.Request, h http.Handler, mirrors []*intercept) {
+	mr := r.Clone(hc.ctx)
+	var bc *bodyCapture
+	if r.Body != nil && r.Body != http.NoBody {
+		bc = &bodyCapture{ReadCloser: r.Body}
+		r.Body = bc
+	}
+	h.ServeHTTP(w, r)
+
+	var body []byte
+	if bc != nil {
+		var ok bool
+		if body, ok = bc.body(); !ok {
+			dlog.Debugf(hc.ctx, "Request %s %s not mirrored, its body is incomplete or too large", r.Method, r.URL.Path)
+			return
+		}
+	}
+	for _, ic := range mirrors {
+		select {
+		case hc.f.mirrorSlots <- struct{}{}:
+		default:
+			dlog.Debugf(hc.ctx, "Request %s %s not mirrored to %s, too many mirrored requests are pending", r.Method, r.URL.Path, ic)
+			continue
+		}
+		go hc.mirrorRequest(mr, body, ic)
+	}
+}
+
+// mirrorRequest sends a copy of the given request with the given body to the given mirror intercept and
+// discards the response.
+func (hc *httpConn) mirrorRequest(r *http.Request, body []byte, ic *intercept) {
+	defer func() { <-hc.f.mirrorSlots }()
+	ctx, cancel := context.WithTimeout(hc.ctx, mirrorTimeout)
+	defer cancel()
+
+	spec := ic.info.Spec
+	r = r.Clone(ctx)
+	r.RequestURI = ""
+	r.URL.Scheme = "http"
+	r.URL.Host = fmt.Sprintf("%s:%d", spec.TargetHost, spec.TargetPort)
+	if body == nil {
+		r.Body = http.NoBody
+	} else {
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := hc.interceptProxy(ic.info).Transport.RoundTrip(r)
+	if err != nil {
+		dlog.Debugf(ctx, "Request %s %s could not be mirrored to %s: %v", r.Method, r.URL.Path, ic, err)
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
+
+// mirrorWriter asynchronously writes copies of the data written to it to a connection to a mirror intercept, and
+// discards all data received on that connection. A mirror that can't keep up is abandoned, so that it never slows
+// down the traffic that it mirrors.
+type mirrorWriter struct {
+	sync.Mutex
+	ic     *intercept
+	data   chan []byte
+	closed bool
+}
+
+// newMirrorWriter returns a mirrorWriter that dials its mirror using the given function. Data written while the
+// dial is in progress is queued. The mirror is abandoned if the dial fails.
+func newMirrorWriter(ctx context.Context, ic *intercept, dial func(context.Context) (net.Conn, error)) *mirrorWriter {
+	mw := &mirrorWriter{ic: ic, data: make(chan []byte, mirrorQueueSize)}
+	go func() {
+		conn, err := dial(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				dlog.Errorf(ctx, "Unable to mirror to %s: %v", ic, err)
+			}
+			mw.Close()
+			return
+		}
+		defer conn.Close()
+		go func() {
+			_, _ = io.Copy(io.Discard, conn)
+		}()
+		for b := range mw.data {
+			if _, err := conn.Write(b); err != nil {
+				dlog.Debugf(ctx, "Unable to mirror to %s: %v", ic, err)
+				mw.Close()
+				return
+			}
+		}
+	}()
+	return mw
+}
+
+// Write queues a copy of the given data. It never fails.
+func (mw *mirrorWriter) Write(b []byte) (int, error) {
+	mw.Lock()
+	defer mw.Unlock()
+	if !mw.closed {
+		select {
+		case mw.data <- append([]byte(nil), b...):
+		default:
+			mw.closed = true
+			close(mw.data)
+		}
+	}
+	return len(b), nil
+}
+
+// Close closes the mirror connection once all queued data has been written.
+func (mw *mirrorWriter) Close() {
+	mw.Lock()
+	if !mw.closed {
+		mw.closed = true
+		close(mw.data)
+	}
+	mw.Unlock()
+}
+
+// mirrorWriters writes to all mirrors of a connection.
+type mirrorWriters []*mirrorWriter
+
+// openMirrors returns writers that send copies of data to the given mirror intercepts. It doesn't wait for the
+// mirrors to be dialed.
+func (f *Forwarder) openMirrors(ctx context.Context, srcAddr net.Addr, mirrors []*intercept) mirrorWriters {
+	mws := make(mirrorWriters, len(mirrors))
+	for i, ic := range mirrors {
+		ii := ic.info
+		mws[i] = newMirrorWriter(ctx, ic, func(ctx context.Context) (net.Conn, error) {
+			return f.dialMirror(ctx, srcAddr, ii)
+		})
+	}
+	return mws
+}
+
+// dialMirror is like dialIntercept, but gives up if the tunnel to the client of the given mirror intercept
+// isn't established within mirrorDialTimeout.
+func (f *Forwarder) dialMirror(ctx context.Context, srcAddr net.Addr, ii *manager.InterceptInfo) (net.Conn, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	timer := time.AfterFunc(mirrorDialTimeout, cancel)
+	s, release, err := f.openInterceptStream(ctx, srcAddr, ii)
+	if !timer.Stop() {
+		if err == nil {
+			release()
+		}
+		err = fmt.Errorf("no tunnel to the client within %s", mirrorDialTimeout)
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	local, remote := net.Pipe()
+	go func() {
+		defer cancel()
+		defer release()
+		f.bridgeInterceptStream(ctx, s, remote)
+	}()
+	return local, nil
+}
+
+func (mws mirrorWriters) Write(b []byte) (int, error) {
+	for _, mw := range mws {
+		_, _ = mw.Write(b)
+	}
+	return len(b), nil
+}
+
+func (mws mirrorWriters) Close() {
+	for _, mw := range mws {
+		mw.Close()
+	}
+}
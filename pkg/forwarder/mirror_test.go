@@ -0,0 +1,221 @@
+package forwarder
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/pkg/log"
+	"github.com/TinderBackend/telepresence/v2/pkg/tunnel"
+	"github.com/datawire/dlib/dlog"
+)
+
+func makeIntercept(t *testing.T, id, mechanism string, mirror bool, args ...string) *intercept {
+	ii := &manager.InterceptInfo{
+		Id:   id,
+		Spec: &manager.InterceptSpec{Name: id, Mechanism: mechanism, MechanismArgs: args, Mirror: mirror},
+	}
+	rm, err := InterceptMatcher(ii)
+	require.NoError(t, err)
+	return &intercept{info: ii, matcher: rm}
+}
+
+func TestForwarder_matchingMirrors(t *testing.T) {
+	f := NewForwarder(&net.TCPAddr{}, "127.0.0.1", 8080)
+	route := makeIntercept(t, "route", "route", false, "--header=x-user=a")
+	httpMirror := makeIntercept(t, "http-mirror", "route", true, "--path-prefix=/api")
+	tcpMirror := makeIntercept(t, "tcp-mirror", "tcp", true)
+	f.intercepts = []*intercept{httpMirror, route, tcpMirror}
+
+	routes, mirrors := splitMirrors(f.intercepts)
+	assert.Equal(t, []*intercept{route}, routes)
+	assert.Equal(t, []*intercept{httpMirror, tcpMirror}, mirrors)
+	assert.True(t, hasHTTP(mirrors))
+
+	// Mirrors never take over requests
+	assert.Nil(t, f.matchingIntercept("/api/x", http.Header{}))
+	assert.Equal(t, route, f.matchingIntercept("/api/x", http.Header{"X-User": {"a"}}))
+
+	// Mirrors that use the "tcp" mechanism match all requests
+	assert.Equal(t, []*intercept{httpMirror, tcpMirror}, f.matchingMirrors("/api/x", http.Header{}))
+	assert.Equal(t, []*intercept{tcpMirror}, f.matchingMirrors("/other", http.Header{}))
+
+	// Only the "tcp" mechanism intercepts receive UDP datagrams
+	udpRoute, udpMirrors := udpIntercepts(f.intercepts)
+	assert.Nil(t, udpRoute)
+	assert.Equal(t, []*intercept{tcpMirror}, udpMirrors)
+
+	// Only the "tcp" mechanism mirrors get copies of connections that a "tcp" intercept receives
+	assert.Equal(t, []*intercept{tcpMirror}, tcpOnly(mirrors))
+}
+
+func TestReserveConnID(t *testing.T) {
+	src := net.ParseIP("10.0.0.1")
+	dst := net.ParseIP("10.0.0.2")
+	id := tunnel.NewConnID(tunnel.IPProto("tcp"), src, dst, 4000, 8080)
+
+	// Streams from the same source address, e.g. the pooled streams of an HTTP connection, get distinct IDs
+	id1, release1 := reserveConnID(id)
+	id2, release2 := reserveConnID(id)
+	assert.Equal(t, id, id1)
+	assert.NotEqual(t, id1, id2)
+	assert.Equal(t, uint16(4001), id2.SourcePort())
+	assert.Equal(t, id.Destination(), id2.Destination())
+	assert.Equal(t, id.DestinationPort(), id2.DestinationPort())
+
+	// A released ID is available again
+	release1()
+	id3, release3 := reserveConnID(id)
+	assert.Equal(t, id, id3)
+	release2()
+	release3()
+}
+
+func TestTeeConn(t *testing.T) {
+	local, remote := net.Pipe()
+	defer remote.Close()
+	mirrored := &bytes.Buffer{}
+	tc := &teeConn{Conn: local, w: mirrored}
+	defer tc.Close()
+
+	go func() {
+		_, _ = remote.Write([]byte("hello"))
+	}()
+	b := make([]byte, 16)
+	n, err := tc.Read(b)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(b[:n]))
+	assert.Equal(t, "hello", mirrored.String())
+}
+
+func TestMirrorWriter(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+	local, remote := net.Pipe()
+	defer remote.Close()
+	mw := newMirrorWriter(ctx, makeIntercept(t, "mirror", "tcp", true), func(context.Context) (net.Conn, error) {
+		return local, nil
+	})
+
+	n, err := mw.Write([]byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+	buf := make([]byte, 5)
+	_, err = io.ReadFull(remote, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(buf))
+
+	// A mirror that doesn't keep up is abandoned without ever blocking the writer
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 2*mirrorQueueSize; i++ {
+			_, _ = mw.Write([]byte("data"))
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("write to mirror blocked")
+	}
+	mw.Lock()
+	assert.True(t, mw.closed)
+	mw.Unlock()
+}
+
+func TestBodyCapture(t *testing.T) {
+	bc := &bodyCapture{ReadCloser: io.NopCloser(bytes.NewBufferString("some body"))}
+	_, ok := bc.body()
+	assert.False(t, ok, "body isn't complete until it has been read")
+
+	data, err := io.ReadAll(bc)
+	require.NoError(t, err)
+	assert.Equal(t, "some body", string(data))
+	body, ok := bc.body()
+	assert.True(t, ok)
+	assert.Equal(t, "some body", string(body))
+
+	bc = &bodyCapture{ReadCloser: io.NopCloser(bytes.NewReader(make([]byte, maxMirrorBodySize+1)))}
+	_, err = io.ReadAll(bc)
+	require.NoError(t, err)
+	_, ok = bc.body()
+	assert.False(t, ok, "body is too large to be mirrored")
+}
+
+// stalledManager is a manager.ManagerClient whose Tunnel calls never complete. Each call is reported on
+// the tunnelCalls channel.
+type stalledManager struct {
+	manager.ManagerClient
+	tunnelCalls chan struct{}
+}
+
+func (m *stalledManager) Tunnel(ctx context.Context, _ ...grpc.CallOption) (manager.Manager_TunnelClient, error) {
+	m.tunnelCalls <- struct{}{}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestForwarder_mirrorDialDoesNotDelay(t *testing.T) {
+	// Only errors are logged, because the forwarder may still log when the connection is closed after the test
+	ctx, cancel := context.WithCancel(dlog.WithLogger(context.Background(), log.NewTestLogger(t, dlog.LogLevelError)))
+	defer cancel()
+
+	// The app echoes what it receives
+	al, err := net.ListenTCP("tcp", &net.TCPAddr{IP: net.IP{127, 0, 0, 1}})
+	require.NoError(t, err)
+	defer al.Close()
+	go func() {
+		for {
+			conn, err := al.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	mgr := &stalledManager{tunnelCalls: make(chan struct{}, 1)}
+	f := NewForwarder(&net.TCPAddr{IP: net.IP{127, 0, 0, 1}}, "127.0.0.1", int32(al.Addr().(*net.TCPAddr).Port))
+	f.SetManager(&manager.SessionInfo{SessionId: "agent-session"}, mgr, semver.MustParse("2.5.0"))
+	fl, err := f.Listen(ctx)
+	require.NoError(t, err)
+	go func() {
+		_ = f.ServeListener(ctx, fl)
+	}()
+	f.SetIntercepting([]*manager.InterceptInfo{{
+		Id:            "abc:mirror",
+		ClientSession: &manager.SessionInfo{SessionId: "client-session"},
+		Spec:          &manager.InterceptSpec{Name: "mirror", Mechanism: "tcp", Mirror: true, TargetHost: "127.0.0.1", TargetPort: 8080},
+	}})
+
+	conn, err := net.Dial("tcp", fl.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// The connection is served by the app while the tunnel to the mirror is still being opened, and long
+	// before that attempt times out.
+	require.NoError(t, conn.SetDeadline(time.Now().Add(mirrorDialTimeout/2)))
+	_, err = conn.Write([]byte("hello"))
+	require.NoError(t, err)
+	buf := make([]byte, 5)
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(buf))
+	select {
+	case <-mgr.tunnelCalls:
+	default:
+		t.Fatal("the tunnel to the mirror was never opened")
+	}
+}
@@ -0,0 +1,282 @@
+package forwarder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/TinderBackend/telepresence/v2/pkg/ipproto"
+	"github.com/TinderBackend/telepresence/v2/pkg/tunnel"
+	"github.com/datawire/dlib/dlog"
+)
+
+// udpIdleDuration controls how long the handler for a specific source address remains alive without
+// receiving or sending any datagrams.
+const udpIdleDuration = time.Minute
+
+const udpBufferSize = 0x10000
+const udpChannelSize = 0x40
+
+// udpHandler handles the datagrams that the forwarder receives from one source address. It implements
+// net.Conn so that it can be bridged with a tunnel.Stream using a tunnel.Endpoint. Reads return the datagrams
+// received from the source address and writes are sent back to that address using the forwarder's listener.
+type udpHandler struct {
+	id         tunnel.ConnID
+	lc         *net.UDPConn
+	src        *net.UDPAddr
+	fromClient chan []byte
+	idleTimer  *time.Timer
+	idleLock   sync.Mutex
+	done       chan struct{}
+	closeOnce  sync.Once
+	remove     func()
+}
+
+func newUDPHandler(lc *net.UDPConn, src *net.UDPAddr, id tunnel.ConnID, remove func()) *udpHandler {
+	return &udpHandler{
+		id:         id,
+		lc:         lc,
+		src:        src,
+		fromClient: make(chan []byte, udpChannelSize),
+		idleTimer:  time.NewTimer(udpIdleDuration),
+		done:       make(chan struct{}),
+		remove:     remove,
+	}
+}
+
+// resetIdle resets the idle timer. It returns false if the timer has already fired.
+func (h *udpHandler) resetIdle() bool {
+	h.idleLock.Lock()
+	stopped := h.idleTimer.Stop()
+	if stopped {
+		h.idleTimer.Reset(udpIdleDuration)
+	}
+	h.idleLock.Unlock()
+	return stopped
+}
+
+// handleDatagram queues a datagram received from the source address. The datagram is dropped if the
+// queue is full, so that one slow flow cannot block the listener.
+func (h *udpHandler) handleDatagram(ctx context.Context, dg []byte) {
+	select {
+	case <-h.done:
+	case h.fromClient <- dg:
+	default:
+		dlog.Debugf(ctx, "<- UDP %s, queue full, dropping datagram with len %d", h.id, len(dg))
+	}
+}
+
+func (h *udpHandler) Read(b []byte) (int, error) {
+	select {
+	case <-h.done:
+		return 0, net.ErrClosed
+	case dg := <-h.fromClient:
+		if !h.resetIdle() {
+			return 0, net.ErrClosed
+		}
+		return copy(b, dg), nil
+	}
+}
+
+func (h *udpHandler) Write(b []byte) (int, error) {
+	select {
+	case <-h.done:
+		return 0, net.ErrClosed
+	default:
+	}
+	if !h.resetIdle() {
+		return 0, net.ErrClosed
+	}
+	return h.lc.WriteToUDP(b, h.src)
+}
+
+func (h *udpHandler) Close() error {
+	h.closeOnce.Do(func() {
+		close(h.done)
+		h.remove()
+	})
+	return nil
+}
+
+func (h *udpHandler) LocalAddr() net.Addr {
+	return h.lc.LocalAddr()
+}
+
+func (h *udpHandler) RemoteAddr() net.Addr {
+	return h.src
+}
+
+func (h *udpHandler) SetDeadline(_ time.Time) error {
+	return nil
+}
+
+func (h *udpHandler) SetReadDeadline(_ time.Time) error {
+	return nil
+}
+
+func (h *udpHandler) SetWriteDeadline(_ time.Time) error {
+	return nil
+}
+
+// ListenUDP is like Listen, but for a forwarder that forwards UDP datagrams.
+func (f *Forwarder) ListenUDP(ctx context.Context) (*net.UDPConn, error) {
+	listenAddr, ok := f.startListen(ctx).(*net.UDPAddr)
+	if !ok {
+		return nil, fmt.Errorf("unable to listen for UDP datagrams on %s", f.listenAddr)
+	}
+	return net.ListenUDP("udp", listenAddr)
+}
+
+// ServeUDP reads datagrams from the given connection and dispatches them to one handler per source address.
+// Each handler forwards its datagrams to the target or, when intercepted, over a tunnel to the intercepting
+// client. A handler is closed when it has been idle for udpIdleDuration or when the intercepts change.
+func (f *Forwarder) ServeUDP(ctx context.Context, conn *net.UDPConn) error {
+	defer conn.Close()
+
+	dlog.Debugf(ctx, "Forwarding UDP from %s", f.listenAddr.String())
+	defer dlog.Debugf(ctx, "Done forwarding UDP from %s", f.listenAddr.String())
+
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	lAddr := conn.LocalAddr().(*net.UDPAddr)
+	var handlersLock sync.Mutex
+	handlers := make(map[tunnel.ConnID]*udpHandler)
+	buf := make([]byte, udpBufferSize)
+	for {
+		n, src, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			dlog.Infof(ctx, "Error on read: %+v", err)
+			continue
+		}
+		id := tunnel.NewConnID(ipproto.UDP, src.IP, lAddr.IP, uint16(src.Port), uint16(lAddr.Port))
+		handlersLock.Lock()
+		h, ok := handlers[id]
+		if !ok {
+			h = newUDPHandler(conn, src, id, func() {
+				handlersLock.Lock()
+				delete(handlers, id)
+				handlersLock.Unlock()
+			})
+			handlers[id] = h
+			go func() {
+				if err := f.forwardUDP(h); err != nil {
+					dlog.Error(ctx, err)
+				}
+			}()
+		}
+		handlersLock.Unlock()
+
+		dg := make([]byte, n)
+		copy(dg, buf[:n])
+		h.handleDatagram(ctx, dg)
+	}
+}
+
+// udpIntercepts returns the first intercept that receives all traffic, or nil if no such intercept exists,
+// and the mirror intercepts that receive copies of all traffic. Intercepts that match HTTP requests never
+// receive UDP datagrams.
+func udpIntercepts(ics []*intercept) (route *intercept, mirrors []*intercept) {
+	for _, ic := range ics {
+		if ic.matcher != nil {
+			continue
+		}
+		if ic.info.Spec.Mirror {
+			mirrors = append(mirrors, ic)
+		} else if route == nil {
+			route = ic
+		}
+	}
+	return route, mirrors
+}
+
+func (f *Forwarder) forwardUDP(h *udpHandler) error {
+	f.mu.Lock()
+	ctx := f.tCtx
+	targetHost := f.targetHost
+	targetPort := f.targetPort
+	ic, mirrors := udpIntercepts(f.intercepts)
+	f.mu.Unlock()
+
+	defer h.Close()
+	go func(ctx context.Context) {
+		select {
+		case <-ctx.Done():
+		case <-h.idleTimer.C:
+			dlog.Debugf(ctx, "   UDP %s, idle for too long", h.id)
+		case <-h.done:
+		}
+		h.Close()
+	}(ctx)
+
+	if ic != nil {
+		return f.interceptConn(ctx, h, ic.info, mirrors)
+	}
+
+	targetAddr, err := net.ResolveUDPAddr("udp", fmt.Sprintf("%s:%d", targetHost, targetPort))
+	if err != nil {
+		return fmt.Errorf("error on resolve(%s:%d): %w", targetHost, targetPort, err)
+	}
+
+	ctx = dlog.WithField(ctx, "client", h.src.String())
+	ctx = dlog.WithField(ctx, "target", targetAddr.String())
+
+	dlog.Debug(ctx, "Forwarding UDP...")
+	defer dlog.Debug(ctx, "Done forwarding UDP")
+
+	udpConn, err := net.DialUDP("udp", nil, targetAddr)
+	if err != nil {
+		return fmt.Errorf("error on dial: %w", err)
+	}
+	targetConn := &countingConn{Conn: udpConn, count: &f.stats.ForwardedBytes}
+	defer targetConn.Close()
+	atomic.AddUint64(&f.stats.ForwardedConnections, 1)
+
+	// Mirrors get a copy of each datagram that is sent to the target
+	var mws mirrorWriters
+	if len(mirrors) > 0 {
+		mws = f.openMirrors(ctx, h.src, mirrors)
+		defer mws.Close()
+	}
+
+	go func() {
+		// Closing the targetConn when the handler is closed ends this loop
+		buf := make([]byte, udpBufferSize)
+		for {
+			n, err := targetConn.Read(buf)
+			if err != nil {
+				if !errors.Is(err, net.ErrClosed) {
+					dlog.Debugf(ctx, "Error targetConn->clientConn: %+v", err)
+				}
+				h.Close()
+				return
+			}
+			if _, err = h.Write(buf[:n]); err != nil {
+				dlog.Debugf(ctx, "Error targetConn->clientConn: %+v", err)
+				return
+			}
+		}
+	}()
+
+	buf := make([]byte, udpBufferSize)
+	for {
+		n, err := h.Read(buf)
+		if err != nil {
+			return nil
+		}
+		if _, err = targetConn.Write(buf[:n]); err != nil {
+			dlog.Debugf(ctx, "Error clientConn->targetConn: %+v", err)
+			return nil
+		}
+		_, _ = mws.Write(buf[:n])
+	}
+}
@@ -0,0 +1,231 @@
+package forwarder_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/pkg/forwarder"
+	"github.com/TinderBackend/telepresence/v2/pkg/log"
+	"github.com/TinderBackend/telepresence/v2/pkg/tunnel"
+	"github.com/datawire/dlib/dlog"
+)
+
+// udpEcho starts a UDP server that responds to each datagram with the same datagram prefixed by the given prefix.
+func udpEcho(ctx context.Context, t *testing.T, prefix string) *net.UDPAddr {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IP{127, 0, 0, 1}})
+	require.NoError(t, err)
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+	go func() {
+		buf := make([]byte, 0x100)
+		for {
+			n, src, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			_, _ = conn.WriteToUDP(append([]byte(prefix), buf[:n]...), src)
+		}
+	}()
+	return conn.LocalAddr().(*net.UDPAddr)
+}
+
+// udpTestContext returns a context for tests of the UDP forwarding. Its logger only logs errors, because
+// the handlers of the forwarder may still log when they are closed after the test has completed.
+func udpTestContext(t *testing.T) (context.Context, context.CancelFunc) {
+	return context.WithCancel(dlog.WithLogger(context.Background(), log.NewTestLogger(t, dlog.LogLevelError)))
+}
+
+// serveUDP serves the given connection using the given forwarder until the test ends.
+func serveUDP(ctx context.Context, t *testing.T, f *forwarder.Forwarder, lc *net.UDPConn) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = f.ServeUDP(ctx, lc)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func TestForwarder_UDP(t *testing.T) {
+	ctx, cancel := udpTestContext(t)
+	defer cancel()
+
+	target := udpEcho(ctx, t, "echo ")
+	f := forwarder.NewForwarder(&net.UDPAddr{IP: net.IP{127, 0, 0, 1}}, "127.0.0.1", int32(target.Port))
+	lc, err := f.ListenUDP(ctx)
+	require.NoError(t, err)
+	serveUDP(ctx, t, f, lc)
+
+	// Each client gets its own handler and hence, its own responses
+	clients := make([]*net.UDPConn, 2)
+	for i := range clients {
+		clients[i], err = net.DialUDP("udp", nil, lc.LocalAddr().(*net.UDPAddr))
+		require.NoError(t, err)
+		defer clients[i].Close()
+	}
+	buf := make([]byte, 0x100)
+	for _, msg := range []string{"hello", "world"} {
+		for i, c := range clients {
+			_, err = c.Write([]byte(msg))
+			require.NoError(t, err)
+			require.NoError(t, c.SetReadDeadline(time.Now().Add(5*time.Second)))
+			n, err := c.Read(buf)
+			require.NoError(t, err, "client %d", i)
+			assert.Equal(t, "echo "+msg, string(buf[:n]))
+		}
+	}
+
+	// Each client is one forwarded connection that has sent 5 and received 10 bytes twice
+	assert.Equal(t, forwarder.Stats{ForwardedConnections: 2, ForwardedBytes: 60}, f.Stats())
+}
+
+func TestForwarder_UDPListenTCP(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+	f := forwarder.NewForwarder(&net.TCPAddr{IP: net.IP{127, 0, 0, 1}}, "127.0.0.1", 8080)
+	_, err := f.ListenUDP(ctx)
+	assert.Error(t, err)
+}
+
+// tunnelPipe is the client and the server side of an in-memory Manager.Tunnel call.
+type tunnelPipe struct {
+	grpc.ClientStream
+	cToS chan *manager.TunnelMessage
+	sToC chan *manager.TunnelMessage
+}
+
+func (p *tunnelPipe) Send(m *manager.TunnelMessage) error {
+	p.cToS <- m
+	return nil
+}
+
+func (p *tunnelPipe) Recv() (*manager.TunnelMessage, error) {
+	if m, ok := <-p.sToC; ok {
+		return m, nil
+	}
+	return nil, io.EOF
+}
+
+func (p *tunnelPipe) CloseSend() error {
+	close(p.cToS)
+	return nil
+}
+
+// tunnelServer is the traffic-manager side of a tunnelPipe.
+type tunnelServer struct {
+	*tunnelPipe
+}
+
+func (s tunnelServer) Send(m *manager.TunnelMessage) error {
+	s.sToC <- m
+	return nil
+}
+
+func (s tunnelServer) Recv() (*manager.TunnelMessage, error) {
+	if m, ok := <-s.cToS; ok {
+		return m, nil
+	}
+	return nil, io.EOF
+}
+
+// fakeManager is a manager.ManagerClient that only implements the Tunnel call. The server side of each
+// tunnel is sent to the tunnels channel.
+type fakeManager struct {
+	manager.ManagerClient
+	tunnels chan tunnel.GRPCStream
+}
+
+func (m *fakeManager) Tunnel(context.Context, ...grpc.CallOption) (manager.Manager_TunnelClient, error) {
+	p := &tunnelPipe{cToS: make(chan *manager.TunnelMessage, 10), sToC: make(chan *manager.TunnelMessage, 10)}
+	m.tunnels <- tunnelServer{p}
+	return p, nil
+}
+
+func TestForwarder_UDPIntercept(t *testing.T) {
+	ctx, cancel := udpTestContext(t)
+	defer cancel()
+
+	app := udpEcho(ctx, t, "app ")
+	workstation := udpEcho(ctx, t, "workstation ")
+
+	// The traffic-manager side of each tunnel checks the client session and then dials the workstation,
+	// just like the intercepting client does.
+	mgr := &fakeManager{tunnels: make(chan tunnel.GRPCStream)}
+	go func() {
+		for {
+			var gs tunnel.GRPCStream
+			select {
+			case <-ctx.Done():
+				return
+			case gs = <-mgr.tunnels:
+			}
+			s, err := tunnel.NewServerStream(ctx, gs)
+			if !assert.NoError(t, err) {
+				return
+			}
+			m, err := s.Receive(ctx)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, "client-session", tunnel.GetSession(m))
+			tunnel.NewDialer(s).Start(ctx)
+		}
+	}()
+
+	f := forwarder.NewForwarder(&net.UDPAddr{IP: net.IP{127, 0, 0, 1}}, "127.0.0.1", int32(app.Port))
+	f.SetManager(&manager.SessionInfo{SessionId: "agent-session"}, mgr, semver.MustParse("2.5.0"))
+	lc, err := f.ListenUDP(ctx)
+	require.NoError(t, err)
+	serveUDP(ctx, t, f, lc)
+
+	c, err := net.DialUDP("udp", nil, lc.LocalAddr().(*net.UDPAddr))
+	require.NoError(t, err)
+	defer c.Close()
+	roundtrip := func(msg string) string {
+		t.Helper()
+		_, err := c.Write([]byte(msg))
+		require.NoError(t, err)
+		buf := make([]byte, 0x100)
+		require.NoError(t, c.SetReadDeadline(time.Now().Add(5*time.Second)))
+		n, err := c.Read(buf)
+		require.NoError(t, err)
+		return string(buf[:n])
+	}
+
+	assert.Equal(t, "app hello", roundtrip("hello"))
+
+	// Datagrams are sent through the tunnel to the workstation while intercepted
+	f.SetIntercepting([]*manager.InterceptInfo{{
+		Id:            "abc:udp",
+		ClientSession: &manager.SessionInfo{SessionId: "client-session"},
+		Spec: &manager.InterceptSpec{
+			Name:       "udp",
+			Mechanism:  "tcp",
+			TargetHost: "127.0.0.1",
+			TargetPort: int32(workstation.Port),
+		},
+	}})
+	assert.Equal(t, "workstation hello", roundtrip("hello"))
+	assert.Equal(t, "workstation world", roundtrip("world"))
+	assert.Eventually(t, func() bool {
+		st := f.Stats()
+		return st.InterceptedConnections == 1 && st.InterceptedBytes == 2*5+2*17
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// And to the app again when the intercept ends
+	f.SetIntercepting(nil)
+	assert.Equal(t, "app hello", roundtrip("hello"))
+}
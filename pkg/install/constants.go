@@ -9,6 +9,7 @@ const (
 	ServicePortAnnotation     = DomainPrefix + "inject-service-port"
 	ServiceNameAnnotation     = DomainPrefix + "inject-service-name"
 	ManualInjectAnnotation    = DomainPrefix + "manually-injected"
+	EgressAnnotation          = DomainPrefix + "egress-destinations"
 	ManagerAppName            = "traffic-manager"
 	ManagerPortHTTP           = 8081
 	MutatorWebhookPortHTTPS   = 8443
@@ -10,24 +10,37 @@ import (
 const EnvPrefix = "_TEL_AGENT_"
 const InitContainerName = "tel-agent-init"
 
-// AgentContainer will return a configured traffic agent
+// AgentPort is a port of the traffic agent container together with the port of the app container that
+// the agent forwards it to.
+type AgentPort struct {
+	Port    core.ContainerPort
+	AppPort int
+}
+
+// AgentContainer will return a configured traffic agent that fronts the given ports. The container port
+// numbers of the ports must be consecutive, because the agent derives them from the number of the first one.
+// The agent also fronts the given egress destinations, using the port numbers that follow the last port.
 func AgentContainer(
 	name string,
 	imageName string,
 	appContainer *core.Container,
-	port core.ContainerPort,
-	appPort int,
+	ports []AgentPort,
+	egress []string,
 	appProto string,
 	apiPort int,
 	managerNamespace string,
 ) core.Container {
 	var securityContext *core.SecurityContext
+	cps := make([]core.ContainerPort, len(ports))
+	for i, p := range ports {
+		cps[i] = p.Port
+	}
 	return core.Container{
 		Name:            AgentContainerName,
 		Image:           imageName,
 		Args:            []string{"agent"},
-		Ports:           []core.ContainerPort{port},
-		Env:             agentEnvironment(name, appContainer, appPort, appProto, apiPort, managerNamespace, port),
+		Ports:           cps,
+		Env:             agentEnvironment(name, appContainer, ports, egress, appProto, apiPort, managerNamespace),
 		EnvFrom:         appContainer.EnvFrom,
 		VolumeMounts:    agentVolumeMounts(appContainer.VolumeMounts),
 		SecurityContext: securityContext,
@@ -41,12 +54,14 @@ func AgentContainer(
 	}
 }
 
-// InitContainer will return a configured init container for an agent.
-func InitContainer(imageName string, port core.ContainerPort, appPort int) core.Container {
+// InitContainer will return a configured init container for an agent that fronts the given ports and
+// egress destinations. The container port numbers of the ports must be consecutive.
+func InitContainer(imageName string, ports []AgentPort, egress []string) core.Container {
+	port := ports[0].Port
 	env := []core.EnvVar{
 		{
 			Name:  "APP_PORT",
-			Value: strconv.Itoa(appPort),
+			Value: strconv.Itoa(ports[0].AppPort),
 		},
 		{
 			Name:  "AGENT_PORT",
@@ -57,6 +72,24 @@ func InitContainer(imageName string, port core.ContainerPort, appPort int) core.
 			Value: string(port.Protocol),
 		},
 	}
+	if len(ports) > 1 {
+		env = append(env, core.EnvVar{
+			Name:  "ADDITIONAL_APP_PORTS",
+			Value: additionalAppPorts(ports),
+		})
+		if protos := additionalAppProtocols(ports); protos != "" {
+			env = append(env, core.EnvVar{
+				Name:  "ADDITIONAL_APP_PROTOCOLS",
+				Value: protos,
+			})
+		}
+	}
+	if len(egress) > 0 {
+		env = append(env, core.EnvVar{
+			Name:  "EGRESS_DESTINATIONS",
+			Value: strings.Join(egress, ","),
+		})
+	}
 	return core.Container{
 		Name:  InitContainerName,
 		Image: imageName,
@@ -72,16 +105,43 @@ func InitContainer(imageName string, port core.ContainerPort, appPort int) core.
 	}
 }
 
+// additionalAppPorts returns a comma separated list of the app ports of all but the first of the given ports.
+func additionalAppPorts(ports []AgentPort) string {
+	aps := make([]string, len(ports)-1)
+	for i, p := range ports[1:] {
+		aps[i] = strconv.Itoa(p.AppPort)
+	}
+	return strings.Join(aps, ",")
+}
+
+// additionalAppProtocols returns a comma separated list of the protocols of all but the first of the given
+// ports, or an empty string when all of them use TCP.
+func additionalAppProtocols(ports []AgentPort) string {
+	aps := make([]string, len(ports)-1)
+	allTCP := true
+	for i, p := range ports[1:] {
+		proto := protocol(p.Port.Protocol)
+		if proto != core.ProtocolTCP {
+			allTCP = false
+		}
+		aps[i] = string(proto)
+	}
+	if allTCP {
+		return ""
+	}
+	return strings.Join(aps, ",")
+}
+
 func agentEnvironment(
 	agentName string,
 	appContainer *core.Container,
-	appPort int,
+	ports []AgentPort,
+	egress []string,
 	appProto string,
 	apiPort int,
-	managerNamespace string,
-	port core.ContainerPort) []core.EnvVar {
+	managerNamespace string) []core.EnvVar {
 	appEnv := appEnvironment(appContainer, apiPort)
-	env := make([]core.EnvVar, len(appEnv), len(appEnv)+7)
+	env := make([]core.EnvVar, len(appEnv), len(appEnv)+8)
 	copy(env, appEnv)
 	env = append(env,
 		core.EnvVar{
@@ -110,13 +170,37 @@ func agentEnvironment(
 		},
 		core.EnvVar{
 			Name:  EnvPrefix + "APP_PORT",
-			Value: strconv.Itoa(appPort),
+			Value: strconv.Itoa(ports[0].AppPort),
 		},
 		core.EnvVar{
 			Name:  EnvPrefix + "PORT",
-			Value: strconv.Itoa(int(port.ContainerPort)),
+			Value: strconv.Itoa(int(ports[0].Port.ContainerPort)),
 		},
 	)
+	if len(ports) > 1 {
+		env = append(env, core.EnvVar{
+			Name:  EnvPrefix + "ADDITIONAL_APP_PORTS",
+			Value: additionalAppPorts(ports),
+		})
+		if protos := additionalAppProtocols(ports); protos != "" {
+			env = append(env, core.EnvVar{
+				Name:  EnvPrefix + "ADDITIONAL_APP_PROTOCOLS",
+				Value: protos,
+			})
+		}
+	}
+	if len(egress) > 0 {
+		env = append(env, core.EnvVar{
+			Name:  EnvPrefix + "EGRESS_DESTINATIONS",
+			Value: strings.Join(egress, ","),
+		})
+	}
+	if ports[0].Port.Protocol == core.ProtocolUDP {
+		env = append(env, core.EnvVar{
+			Name:  EnvPrefix + "PROTOCOL",
+			Value: string(core.ProtocolUDP),
+		})
+	}
 	if appProto != "" {
 		env = append(env, core.EnvVar{
 			Name:  EnvPrefix + "APP_PROTO",
@@ -199,3 +283,29 @@ func HiddenPortName(name string, ordinal int) string {
 	}
 	return hiddenName
 }
+
+// AgentAppPort returns the number of the app port that the given traffic agent container forwards the given
+// agent port to, or false if the agent doesn't forward that port.
+func AgentAppPort(agent *core.Container, agentPort int32) (int32, bool) {
+	var firstAgentPort int
+	var appPorts []string
+	for _, ev := range agent.Env {
+		switch ev.Name {
+		case EnvPrefix + "PORT":
+			firstAgentPort, _ = strconv.Atoi(ev.Value)
+		case EnvPrefix + "APP_PORT":
+			appPorts = append([]string{ev.Value}, appPorts...)
+		case EnvPrefix + "ADDITIONAL_APP_PORTS":
+			appPorts = append(appPorts, strings.Split(ev.Value, ",")...)
+		}
+	}
+	i := int(agentPort) - firstAgentPort
+	if firstAgentPort == 0 || i < 0 || i >= len(appPorts) {
+		return 0, false
+	}
+	appPort, err := strconv.Atoi(appPorts[i])
+	if err != nil {
+		return 0, false
+	}
+	return int32(appPort), true
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 
@@ -116,6 +117,65 @@ func FindMatchingService(c context.Context, portNameOrNumber, svcName, namespace
 	return nil, fmt.Errorf("found %s services with a selector matching labels %v%s in namespace %s%s", count, labels, portRef, namespace, suffix)
 }
 
+// FindMatchingPorts finds all ports of the given service that map to the same container as the
+// service port identified by portNameOrNumber. When portNameOrNumber is empty, the container of the
+// first service port that maps to a container is used. The identified port is always first in the
+// returned slices. The ports may use different protocols. A container port index is -1 when the
+// container doesn't declare the port.
+func FindMatchingPorts(cns []core.Container, portNameOrNumber string, svc *core.Service) (
+	sPorts []*core.ServicePort,
+	cn *core.Container,
+	cPortIndexes []int,
+	err error,
+) {
+	if portNameOrNumber != "" || len(svc.Spec.Ports) == 1 {
+		sPort, c, cPortIndex, err := FindMatchingPort(cns, portNameOrNumber, svc)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		sPorts = append(sPorts, sPort)
+		cn = c
+		cPortIndexes = append(cPortIndexes, cPortIndex)
+	}
+	for i := range svc.Spec.Ports {
+		port := &svc.Spec.Ports[i]
+		if len(sPorts) > 0 && sPorts[0] == port {
+			continue
+		}
+		sPort, c, cPortIndex, err := FindMatchingPort(cns, ServicePortIdentifier(port), svc)
+		if err != nil {
+			continue
+		}
+		if cn == nil {
+			cn = c
+		} else if c != cn {
+			continue
+		}
+		sPorts = append(sPorts, sPort)
+		cPortIndexes = append(cPortIndexes, cPortIndex)
+	}
+	if cn == nil {
+		return nil, nil, nil, errors.New("found no Service with a port that matches any container in this workload")
+	}
+	return sPorts, cn, cPortIndexes, nil
+}
+
+// ServicePortIdentifier returns the name of the given service port, or its number if it has no name.
+func ServicePortIdentifier(port *core.ServicePort) string {
+	if port.Name != "" {
+		return port.Name
+	}
+	return strconv.Itoa(int(port.Port))
+}
+
+// protocol returns the given protocol, or TCP if it is empty.
+func protocol(p core.Protocol) core.Protocol {
+	if p == "" {
+		return core.ProtocolTCP
+	}
+	return p
+}
+
 // FindMatchingPort finds the matching container associated with portNameOrNumber
 // in the given service.
 func FindMatchingPort(cns []core.Container, portNameOrNumber string, svc *core.Service) (
@@ -193,3 +253,33 @@ Please specify the Service port you want to intercept by passing the --port=loca
 	}
 	return matchingServicePort, matchingContainer, containerPortIndex, nil
 }
+
+// EgressDestinations returns the "host:port" destinations declared in the EgressAnnotation of the given annotations.
+// The traffic-agent of a workload fronts these destinations so that the workload's outbound connections to them can
+// be intercepted. The init container resolves a host name once, when the pod starts, so a destination is only
+// redirected while the host keeps the addresses that it had then.
+func EgressDestinations(annotations map[string]string) ([]string, error) {
+	ann := strings.TrimSpace(annotations[EgressAnnotation])
+	if ann == "" {
+		return nil, nil
+	}
+	dests := strings.Split(ann, ",")
+	for i, dest := range dests {
+		dest = strings.TrimSpace(dest)
+		host, port, err := net.SplitHostPort(dest)
+		if err == nil && host == "" {
+			err = errors.New("missing host")
+		}
+		if err == nil {
+			var pn uint64
+			if pn, err = strconv.ParseUint(port, 10, 16); err == nil && pn == 0 {
+				err = errors.New("port must be greater than zero")
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid destination %q in the %s annotation: %w", dest, EgressAnnotation, err)
+		}
+		dests[i] = dest
+	}
+	return dests, nil
+}
@@ -0,0 +1,198 @@
+package k8sapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// RolloutGVR is the GroupVersionResource of an Argo Rollout.
+var RolloutGVR = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}
+
+func GetRollout(c context.Context, name, namespace string) (Workload, error) {
+	ri, err := rollouts(c, namespace)
+	if err != nil {
+		return nil, err
+	}
+	u, err := ri.Get(c, name, meta.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return Rollout(u)
+}
+
+// Rollouts returns all Argo Rollouts found in the given Namespace
+func Rollouts(c context.Context, namespace string, labelSelector labels.Set) ([]Workload, error) {
+	ri, err := rollouts(c, namespace)
+	if err != nil {
+		return nil, err
+	}
+	ls, err := ri.List(c, listOptions(labelSelector))
+	if err != nil {
+		return nil, err
+	}
+	is := ls.Items
+	os := make([]Workload, 0, len(is))
+	for i := range is {
+		o, err := Rollout(&is[i])
+		if err != nil {
+			return nil, err
+		}
+		os = append(os, o)
+	}
+	return os, nil
+}
+
+// Rollout returns the Workload of the given Argo Rollout. An error is returned if the rollout
+// has no pod template of its own, i.e. if it refers to the template of another workload.
+func Rollout(u *unstructured.Unstructured) (Workload, error) {
+	o := &rollout{Unstructured: u}
+	if err := o.decodeTemplate(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// RolloutImpl casts the given Object as an *unstructured.Unstructured Argo Rollout and returns
+// it together with a status flag indicating whether the cast was possible
+func RolloutImpl(o Object) (*unstructured.Unstructured, bool) {
+	if s, ok := o.(*rollout); ok {
+		return s.Unstructured, true
+	}
+	return nil, false
+}
+
+// rollout is an Argo Rollout. It's accessed using the dynamic Interface, so that Telepresence
+// doesn't depend on the Argo Rollouts API. The pod template is decoded from the unstructured
+// content and written back to it on Update.
+type rollout struct {
+	*unstructured.Unstructured
+	template *core.PodTemplateSpec
+}
+
+func rollouts(c context.Context, namespace string) (dynamic.ResourceInterface, error) {
+	di := GetDynamicInterface(c)
+	if di == nil {
+		return nil, errors.New("unable to access Argo Rollouts: no dynamic interface has been configured")
+	}
+	return di.Resource(RolloutGVR).Namespace(namespace), nil
+}
+
+func (o *rollout) decodeTemplate() error {
+	tm, ok, err := unstructured.NestedMap(o.Object, "spec", "template")
+	if err != nil {
+		return fmt.Errorf("invalid pod template in Rollout %s.%s: %w", o.GetName(), o.GetNamespace(), err)
+	}
+	if !ok {
+		return fmt.Errorf("rollout %s.%s has no pod template. Rollouts that use a workloadRef are not supported",
+			o.GetName(), o.GetNamespace())
+	}
+	var tpl core.PodTemplateSpec
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(tm, &tpl); err != nil {
+		return fmt.Errorf("invalid pod template in Rollout %s.%s: %w", o.GetName(), o.GetNamespace(), err)
+	}
+	o.template = &tpl
+	return nil
+}
+
+func (o *rollout) set(u *unstructured.Unstructured) error {
+	o.Unstructured = u
+	return o.decodeTemplate()
+}
+
+func (o *rollout) GetKind() string {
+	return "Rollout"
+}
+
+func (o *rollout) Delete(c context.Context) error {
+	ri, err := rollouts(c, o.GetNamespace())
+	if err != nil {
+		return err
+	}
+	return ri.Delete(c, o.GetName(), meta.DeleteOptions{})
+}
+
+func (o *rollout) GetPodTemplate() *core.PodTemplateSpec {
+	return o.template
+}
+
+func (o *rollout) Patch(c context.Context, pt types.PatchType, data []byte, subresources ...string) error {
+	ri, err := rollouts(c, o.GetNamespace())
+	if err != nil {
+		return err
+	}
+	u, err := ri.Patch(c, o.GetName(), pt, data, meta.PatchOptions{}, subresources...)
+	if err != nil {
+		return err
+	}
+	return o.set(u)
+}
+
+func (o *rollout) Refresh(c context.Context) error {
+	ri, err := rollouts(c, o.GetNamespace())
+	if err != nil {
+		return err
+	}
+	u, err := ri.Get(c, o.GetName(), meta.GetOptions{})
+	if err != nil {
+		return err
+	}
+	return o.set(u)
+}
+
+func (o *rollout) Replicas() int {
+	r, ok := o.nestedInt("spec", "replicas")
+	if !ok {
+		// Same default as for a Deployment
+		return 1
+	}
+	return int(r)
+}
+
+func (o *rollout) Update(c context.Context) error {
+	ri, err := rollouts(c, o.GetNamespace())
+	if err != nil {
+		return err
+	}
+	tm, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o.template)
+	if err != nil {
+		return err
+	}
+	if err = unstructured.SetNestedMap(o.Object, tm, "spec", "template"); err != nil {
+		return err
+	}
+	u, err := ri.Update(c, o.Unstructured, meta.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	return o.set(u)
+}
+
+func (o *rollout) Updated(origGeneration int64) bool {
+	// The Argo Rollouts controller reports the observed generation as a string
+	observed, _, _ := unstructured.NestedString(o.Object, "status", "observedGeneration")
+	replicas, _ := o.nestedInt("status", "replicas")
+	updated, _ := o.nestedInt("status", "updatedReplicas")
+	available, _ := o.nestedInt("status", "availableReplicas")
+	generation := o.GetGeneration()
+	applied := generation >= origGeneration &&
+		observed == strconv.FormatInt(generation, 10) &&
+		updated == replicas &&
+		available == replicas
+	return applied
+}
+
+func (o *rollout) nestedInt(fields ...string) (int64, bool) {
+	v, ok, err := unstructured.NestedInt64(o.Object, fields...)
+	return v, ok && err == nil
+}
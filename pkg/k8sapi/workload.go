@@ -5,13 +5,16 @@ import (
 	"fmt"
 
 	apps "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
 	core "k8s.io/api/core/v1"
 	errors2 "k8s.io/apimachinery/pkg/api/errors"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	typedApps "k8s.io/client-go/kubernetes/typed/apps/v1"
+	typedBatch "k8s.io/client-go/kubernetes/typed/batch/v1"
 )
 
 type Workload interface {
@@ -27,6 +30,10 @@ type Workload interface {
 //   1. Deployments
 //   2. ReplicaSets
 //   3. StatefulSets
+//   4. DaemonSets
+//   5. Jobs
+//   6. CronJobs
+//   7. Argo Rollouts, provided that the context carries a dynamic Interface
 //
 // The first match is returned.
 func GetWorkload(c context.Context, name, namespace, workloadKind string) (obj Workload, err error) {
@@ -37,12 +44,26 @@ func GetWorkload(c context.Context, name, namespace, workloadKind string) (obj W
 		obj, err = GetReplicaSet(c, name, namespace)
 	case "StatefulSet":
 		obj, err = GetStatefulSet(c, name, namespace)
+	case "DaemonSet":
+		obj, err = GetDaemonSet(c, name, namespace)
+	case "Job":
+		obj, err = GetJob(c, name, namespace)
+	case "CronJob":
+		obj, err = GetCronJob(c, name, namespace)
+	case "Rollout":
+		obj, err = GetRollout(c, name, namespace)
 	case "":
-		for _, wk := range []string{"Deployment", "ReplicaSet", "StatefulSet"} {
+		kinds := []string{"Deployment", "ReplicaSet", "StatefulSet", "DaemonSet", "Job", "CronJob"}
+		if GetDynamicInterface(c) != nil {
+			kinds = append(kinds, "Rollout")
+		}
+		for i, wk := range kinds {
 			if obj, err = GetWorkload(c, name, namespace, wk); err == nil {
 				return obj, nil
 			}
-			if !errors2.IsNotFound(err) {
+			// Access to the kinds that were added after the first three is often not granted, and
+			// shouldn't prevent that the search continues.
+			if !(errors2.IsNotFound(err) || i >= 3 && errors2.IsForbidden(err)) {
 				return nil, err
 			}
 		}
@@ -61,6 +82,17 @@ func WrapWorkload(workload runtime.Object) (Workload, error) {
 		return ReplicaSet(workload), nil
 	case *apps.StatefulSet:
 		return StatefulSet(workload), nil
+	case *apps.DaemonSet:
+		return DaemonSet(workload), nil
+	case *batch.Job:
+		return Job(workload), nil
+	case *batch.CronJob:
+		return CronJob(workload), nil
+	case *unstructured.Unstructured:
+		if workload.GetKind() == "Rollout" {
+			return Rollout(workload)
+		}
+		return nil, fmt.Errorf("unsupported workload kind %q", workload.GetKind())
 	default:
 		return nil, fmt.Errorf("unsupported workload type %T", workload)
 	}
@@ -171,6 +203,111 @@ func StatefulSetImpl(o Object) (*apps.StatefulSet, bool) {
 	return nil, false
 }
 
+func GetDaemonSet(c context.Context, name, namespace string) (Workload, error) {
+	d, err := daemonSets(c, namespace).Get(c, name, meta.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &daemonSet{d}, nil
+}
+
+// DaemonSets returns all daemon sets found in the given Namespace
+func DaemonSets(c context.Context, namespace string, labelSelector labels.Set) ([]Workload, error) {
+	ls, err := daemonSets(c, namespace).List(c, listOptions(labelSelector))
+	if err != nil {
+		return nil, err
+	}
+	is := ls.Items
+	os := make([]Workload, len(is))
+	for i := range is {
+		os[i] = DaemonSet(&is[i])
+	}
+	return os, nil
+}
+
+func DaemonSet(d *apps.DaemonSet) Workload {
+	return &daemonSet{d}
+}
+
+// DaemonSetImpl casts the given Object as an *apps.DaemonSet and returns
+// it together with a status flag indicating whether the cast was possible
+func DaemonSetImpl(o Object) (*apps.DaemonSet, bool) {
+	if s, ok := o.(*daemonSet); ok {
+		return s.DaemonSet, true
+	}
+	return nil, false
+}
+
+func GetJob(c context.Context, name, namespace string) (Workload, error) {
+	d, err := jobs(c, namespace).Get(c, name, meta.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &job{d}, nil
+}
+
+// Jobs returns all jobs found in the given Namespace
+func Jobs(c context.Context, namespace string, labelSelector labels.Set) ([]Workload, error) {
+	ls, err := jobs(c, namespace).List(c, listOptions(labelSelector))
+	if err != nil {
+		return nil, err
+	}
+	is := ls.Items
+	os := make([]Workload, len(is))
+	for i := range is {
+		os[i] = Job(&is[i])
+	}
+	return os, nil
+}
+
+func Job(d *batch.Job) Workload {
+	return &job{d}
+}
+
+// JobImpl casts the given Object as an *batch.Job and returns
+// it together with a status flag indicating whether the cast was possible
+func JobImpl(o Object) (*batch.Job, bool) {
+	if s, ok := o.(*job); ok {
+		return s.Job, true
+	}
+	return nil, false
+}
+
+func GetCronJob(c context.Context, name, namespace string) (Workload, error) {
+	d, err := cronJobs(c, namespace).Get(c, name, meta.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &cronJob{d}, nil
+}
+
+// CronJobs returns all cron jobs found in the given Namespace
+func CronJobs(c context.Context, namespace string, labelSelector labels.Set) ([]Workload, error) {
+	ls, err := cronJobs(c, namespace).List(c, listOptions(labelSelector))
+	if err != nil {
+		return nil, err
+	}
+	is := ls.Items
+	os := make([]Workload, len(is))
+	for i := range is {
+		os[i] = CronJob(&is[i])
+	}
+	return os, nil
+}
+
+func CronJob(d *batch.CronJob) Workload {
+	return &cronJob{d}
+}
+
+// CronJobImpl casts the given Object as an *batch.CronJob and returns
+// it together with a status flag indicating whether the cast was possible
+func CronJobImpl(o Object) (*batch.CronJob, bool) {
+	if s, ok := o.(*cronJob); ok {
+		return s.CronJob, true
+	}
+	return nil, false
+}
+
 type deployment struct {
 	*apps.Deployment
 }
@@ -353,3 +490,182 @@ func (o *statefulSet) Updated(origGeneration int64) bool {
 		o.Status.CurrentReplicas == o.Status.Replicas
 	return applied
 }
+
+type daemonSet struct {
+	*apps.DaemonSet
+}
+
+func daemonSets(c context.Context, namespace string) typedApps.DaemonSetInterface {
+	return GetK8sInterface(c).AppsV1().DaemonSets(namespace)
+}
+
+func (o *daemonSet) ki(c context.Context) typedApps.DaemonSetInterface {
+	return daemonSets(c, o.Namespace)
+}
+
+func (o *daemonSet) GetKind() string {
+	return "DaemonSet"
+}
+
+func (o *daemonSet) Delete(c context.Context) error {
+	return o.ki(c).Delete(c, o.Name, meta.DeleteOptions{})
+}
+
+func (o *daemonSet) GetPodTemplate() *core.PodTemplateSpec {
+	return &o.Spec.Template
+}
+
+func (o *daemonSet) Patch(c context.Context, pt types.PatchType, data []byte, subresources ...string) error {
+	d, err := o.ki(c).Patch(c, o.Name, pt, data, meta.PatchOptions{}, subresources...)
+	if err == nil {
+		o.DaemonSet = d
+	}
+	return err
+}
+
+func (o *daemonSet) Refresh(c context.Context) error {
+	d, err := o.ki(c).Get(c, o.Name, meta.GetOptions{})
+	if err == nil {
+		o.DaemonSet = d
+	}
+	return err
+}
+
+func (o *daemonSet) Replicas() int {
+	return int(o.Status.DesiredNumberScheduled)
+}
+
+func (o *daemonSet) Update(c context.Context) error {
+	d, err := o.ki(c).Update(c, o.DaemonSet, meta.UpdateOptions{})
+	if err == nil {
+		o.DaemonSet = d
+	}
+	return err
+}
+
+func (o *daemonSet) Updated(origGeneration int64) bool {
+	applied := o.ObjectMeta.Generation >= origGeneration &&
+		o.Status.ObservedGeneration == o.ObjectMeta.Generation &&
+		o.Status.UpdatedNumberScheduled == o.Status.DesiredNumberScheduled &&
+		o.Status.NumberAvailable == o.Status.DesiredNumberScheduled
+	return applied
+}
+
+// job is a Job. The pod template of a Job is immutable, so an agent can only be added to the pods of
+// a Job by the traffic-manager's agent injector.
+type job struct {
+	*batch.Job
+}
+
+func jobs(c context.Context, namespace string) typedBatch.JobInterface {
+	return GetK8sInterface(c).BatchV1().Jobs(namespace)
+}
+
+func (o *job) ki(c context.Context) typedBatch.JobInterface {
+	return jobs(c, o.Namespace)
+}
+
+func (o *job) GetKind() string {
+	return "Job"
+}
+
+func (o *job) Delete(c context.Context) error {
+	return o.ki(c).Delete(c, o.Name, meta.DeleteOptions{})
+}
+
+func (o *job) GetPodTemplate() *core.PodTemplateSpec {
+	return &o.Spec.Template
+}
+
+func (o *job) Patch(c context.Context, pt types.PatchType, data []byte, subresources ...string) error {
+	d, err := o.ki(c).Patch(c, o.Name, pt, data, meta.PatchOptions{}, subresources...)
+	if err == nil {
+		o.Job = d
+	}
+	return err
+}
+
+func (o *job) Refresh(c context.Context) error {
+	d, err := o.ki(c).Get(c, o.Name, meta.GetOptions{})
+	if err == nil {
+		o.Job = d
+	}
+	return err
+}
+
+func (o *job) Replicas() int {
+	return int(o.Status.Active)
+}
+
+func (o *job) Update(c context.Context) error {
+	d, err := o.ki(c).Update(c, o.Job, meta.UpdateOptions{})
+	if err == nil {
+		o.Job = d
+	}
+	return err
+}
+
+func (o *job) Updated(origGeneration int64) bool {
+	// The job controller doesn't report an observed generation
+	return o.ObjectMeta.Generation >= origGeneration
+}
+
+// cronJob is a CronJob. Changes to its pod template apply to the Jobs that it creates after the change.
+type cronJob struct {
+	*batch.CronJob
+}
+
+func cronJobs(c context.Context, namespace string) typedBatch.CronJobInterface {
+	return GetK8sInterface(c).BatchV1().CronJobs(namespace)
+}
+
+func (o *cronJob) ki(c context.Context) typedBatch.CronJobInterface {
+	return cronJobs(c, o.Namespace)
+}
+
+func (o *cronJob) GetKind() string {
+	return "CronJob"
+}
+
+func (o *cronJob) Delete(c context.Context) error {
+	return o.ki(c).Delete(c, o.Name, meta.DeleteOptions{})
+}
+
+func (o *cronJob) GetPodTemplate() *core.PodTemplateSpec {
+	return &o.Spec.JobTemplate.Spec.Template
+}
+
+func (o *cronJob) Patch(c context.Context, pt types.PatchType, data []byte, subresources ...string) error {
+	d, err := o.ki(c).Patch(c, o.Name, pt, data, meta.PatchOptions{}, subresources...)
+	if err == nil {
+		o.CronJob = d
+	}
+	return err
+}
+
+func (o *cronJob) Refresh(c context.Context) error {
+	d, err := o.ki(c).Get(c, o.Name, meta.GetOptions{})
+	if err == nil {
+		o.CronJob = d
+	}
+	return err
+}
+
+func (o *cronJob) Replicas() int {
+	return len(o.Status.Active)
+}
+
+func (o *cronJob) Update(c context.Context) error {
+	d, err := o.ki(c).Update(c, o.CronJob, meta.UpdateOptions{})
+	if err == nil {
+		o.CronJob = d
+	}
+	return err
+}
+
+// Updated returns true as soon as the change is stored. The cronjob controller doesn't report an observed
+// generation, and the change only affects the jobs that are created after it, so there's nothing to wait
+// for. An agent that is added to a CronJob therefore arrives when the next job is created.
+func (o *cronJob) Updated(origGeneration int64) bool {
+	return o.ObjectMeta.Generation >= origGeneration
+}
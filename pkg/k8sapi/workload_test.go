@@ -0,0 +1,131 @@
+package k8sapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/datawire/dlib/dlog"
+)
+
+func podTemplate(app string) core.PodTemplateSpec {
+	return core.PodTemplateSpec{
+		ObjectMeta: meta.ObjectMeta{Labels: map[string]string{"app": app}},
+		Spec: core.PodSpec{Containers: []core.Container{{
+			Name:  app,
+			Image: "example/" + app,
+			Ports: []core.ContainerPort{{Name: "http", ContainerPort: 8080}},
+		}}},
+	}
+}
+
+func newRollout(name string) *unstructured.Unstructured {
+	tpl, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&core.PodTemplateSpec{
+		ObjectMeta: meta.ObjectMeta{Labels: map[string]string{"app": name}},
+		Spec:       core.PodSpec{Containers: []core.Container{{Name: name, Image: "example/" + name}}},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Rollout",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+			"template": tpl,
+		},
+	}}
+}
+
+func TestGetWorkload(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+	ctx = WithK8sInterface(ctx, fake.NewSimpleClientset(
+		&apps.DaemonSet{
+			ObjectMeta: meta.ObjectMeta{Name: "node-agent", Namespace: "default"},
+			Spec:       apps.DaemonSetSpec{Template: podTemplate("node-agent")},
+		},
+		&batch.Job{
+			ObjectMeta: meta.ObjectMeta{Name: "migrate", Namespace: "default"},
+			Spec:       batch.JobSpec{Template: podTemplate("migrate")},
+		},
+		&batch.CronJob{
+			ObjectMeta: meta.ObjectMeta{Name: "report", Namespace: "default"},
+			Spec: batch.CronJobSpec{JobTemplate: batch.JobTemplateSpec{
+				Spec: batch.JobSpec{Template: podTemplate("report")},
+			}},
+		},
+	))
+
+	t.Run("by kind", func(t *testing.T) {
+		wl, err := GetWorkload(ctx, "node-agent", "default", "DaemonSet")
+		require.NoError(t, err)
+		assert.Equal(t, "DaemonSet", wl.GetKind())
+		_, ok := DaemonSetImpl(wl)
+		assert.True(t, ok)
+	})
+
+	t.Run("search", func(t *testing.T) {
+		for name, kind := range map[string]string{"node-agent": "DaemonSet", "migrate": "Job", "report": "CronJob"} {
+			wl, err := GetWorkload(ctx, name, "default", "")
+			require.NoError(t, err)
+			assert.Equal(t, kind, wl.GetKind())
+			assert.Equal(t, name, wl.GetPodTemplate().Labels["app"])
+		}
+		_, err := GetWorkload(ctx, "missing", "default", "")
+		assert.True(t, errors2.IsNotFound(err))
+	})
+
+	t.Run("rollout without dynamic interface", func(t *testing.T) {
+		_, err := GetWorkload(ctx, "canary", "default", "Rollout")
+		assert.Error(t, err)
+	})
+
+	t.Run("rollout", func(t *testing.T) {
+		dc := dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{RolloutGVR: "RolloutList"}, newRollout("canary"))
+		ctx := WithDynamicInterface(ctx, dc)
+
+		wl, err := GetWorkload(ctx, "canary", "default", "")
+		require.NoError(t, err)
+		assert.Equal(t, "Rollout", wl.GetKind())
+		assert.Equal(t, 3, wl.Replicas())
+
+		// Changes to the pod template are written back to the Rollout on Update
+		tpl := wl.GetPodTemplate()
+		tpl.Spec.Containers = append(tpl.Spec.Containers, core.Container{Name: "sidecar", Image: "example/sidecar"})
+		require.NoError(t, wl.Update(ctx))
+
+		wl, err = GetRollout(ctx, "canary", "default")
+		require.NoError(t, err)
+		cns := wl.GetPodTemplate().Spec.Containers
+		require.Len(t, cns, 2)
+		assert.Equal(t, "sidecar", cns[1].Name)
+
+		wls, err := Rollouts(ctx, "default", nil)
+		require.NoError(t, err)
+		assert.Len(t, wls, 1)
+	})
+}
+
+func TestRolloutWithoutTemplate(t *testing.T) {
+	u := newRollout("canary")
+	unstructured.RemoveNestedField(u.Object, "spec", "template")
+	require.NoError(t, unstructured.SetNestedField(u.Object, "canary", "spec", "workloadRef", "name"))
+	_, err := WrapWorkload(u)
+	assert.Error(t, err)
+}
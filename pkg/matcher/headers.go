@@ -54,6 +54,17 @@ func (m headers) Matches(h http.Header) bool {
 	return true
 }
 
+// overlaps returns true unless it can be determined that no http.Header exists that would be
+// matched by both m and o.
+func (m headers) overlaps(o headers) bool {
+	for name, vm := range m {
+		if ov, ok := o[name]; ok && !valuesOverlap(vm, ov) {
+			return false
+		}
+	}
+	return true
+}
+
 func (m headers) String() string {
 	sb := strings.Builder{}
 	m.appendString(&sb, "")
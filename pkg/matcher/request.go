@@ -18,6 +18,10 @@ type Request interface {
 	// Matches returns true if both the path Value matcher and the Headers matcher in this instance are
 	// matched by the given http.Request.
 	Matches(path string, headers http.Header) bool
+
+	// Overlaps returns true unless it can be determined that no http.Request exists that would
+	// be matched by both this instance and the given Request.
+	Overlaps(other Request) bool
 }
 
 type request struct {
@@ -92,6 +96,19 @@ func (r *request) Matches(path string, headers http.Header) bool {
 	return r == nil || (r.path == nil || r.path.Matches(path)) && (r.headers == nil || r.headers.Matches(headers))
 }
 
+// Overlaps returns true unless it can be determined that no http.Request exists that would
+// be matched by both this instance and the given Request. A nil Request overlaps everything.
+func (r *request) Overlaps(other Request) bool {
+	o, ok := other.(*request)
+	if r == nil || !ok || o == nil {
+		return true
+	}
+	if r.path != nil && o.path != nil && !valuesOverlap(r.path, o.path) {
+		return false
+	}
+	return r.headers.overlaps(o.headers)
+}
+
 func (r *request) String() string {
 	sb := strings.Builder{}
 	if r == nil || r.path == nil && len(r.headers) == 0 {
@@ -171,6 +171,82 @@ func Test_request_Matches(t *testing.T) {
 	}
 }
 
+func Test_request_Overlaps(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *request
+		b    *request
+		want bool
+	}{
+		{
+			name: "nil",
+			a:    nil,
+			b:    &request{headers: headers(map[string]Value{"A": NewEqual("b")})},
+			want: true,
+		},
+		{
+			name: "empty",
+			a:    &request{},
+			b:    &request{path: NewEqual("/some/path")},
+			want: true,
+		},
+		{
+			name: "different headers",
+			a:    &request{headers: headers(map[string]Value{"A": NewEqual("b")})},
+			b:    &request{headers: headers(map[string]Value{"B": NewEqual("b")})},
+			want: true,
+		},
+		{
+			name: "different header values",
+			a:    &request{headers: headers(map[string]Value{"A": NewEqual("b")})},
+			b:    &request{headers: headers(map[string]Value{"A": NewEqual("c")})},
+			want: false,
+		},
+		{
+			name: "header value and regex",
+			a:    &request{headers: headers(map[string]Value{"A": NewEqual("b")})},
+			b:    &request{headers: headers(map[string]Value{"A": rxValue{regexp.MustCompile("^[a-c]$")}})},
+			want: true,
+		},
+		{
+			name: "header value and regex mismatch",
+			a:    &request{headers: headers(map[string]Value{"A": rxValue{regexp.MustCompile("^[a-c]$")}})},
+			b:    &request{headers: headers(map[string]Value{"A": NewEqual("d")})},
+			want: false,
+		},
+		{
+			name: "nested path prefixes",
+			a:    &request{path: NewPrefix("/some")},
+			b:    &request{path: NewPrefix("/some/path")},
+			want: true,
+		},
+		{
+			name: "distinct path prefixes",
+			a:    &request{path: NewPrefix("/some")},
+			b:    &request{path: NewPrefix("/other")},
+			want: false,
+		},
+		{
+			name: "path-equal and path-prefix",
+			a:    &request{path: NewEqual("/other/path")},
+			b:    &request{path: NewPrefix("/some")},
+			want: false,
+		},
+		{
+			name: "distinct paths and same headers",
+			a:    &request{path: NewEqual("/some"), headers: headers(map[string]Value{"A": NewEqual("b")})},
+			b:    &request{path: NewEqual("/other"), headers: headers(map[string]Value{"A": NewEqual("b")})},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equalf(t, tt.want, tt.a.Overlaps(tt.b), "%v.Overlaps(%v)", tt.a, tt.b)
+			assert.Equalf(t, tt.want, tt.b.Overlaps(tt.a), "%v.Overlaps(%v)", tt.b, tt.a)
+		})
+	}
+}
+
 func Test_request_String(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -87,3 +87,25 @@ func NewPrefix(v string) Value {
 func NewEqual(v string) Value {
 	return textValue(v)
 }
+
+// valuesOverlap returns true unless it can be determined that no string exists that would be
+// matched by both a and b. A pair of regular expressions, or a regular expression and a prefix, are
+// always considered to overlap.
+func valuesOverlap(a, b Value) bool {
+	switch av := a.(type) {
+	case textValue:
+		return b.Matches(string(av))
+	case prefixValue:
+		switch bv := b.(type) {
+		case textValue:
+			return av.Matches(string(bv))
+		case prefixValue:
+			return strings.HasPrefix(string(av), string(bv)) || strings.HasPrefix(string(bv), string(av))
+		}
+	default:
+		if bv, ok := b.(textValue); ok {
+			return a.Matches(string(bv))
+		}
+	}
+	return true
+}
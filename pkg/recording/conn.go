@@ -0,0 +1,64 @@
+package recording
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/TinderBackend/telepresence/v2/pkg/tunnel"
+	"github.com/datawire/dlib/dlog"
+)
+
+// recordingConn records all data that is written to and read from a net.Conn.
+type recordingConn struct {
+	net.Conn
+	ctx       context.Context
+	id        string
+	recorder  *Recorder
+	closeOnce sync.Once
+}
+
+// RecordConn records an Open event for the given connection and returns a connection that records all data
+// written to it as Request events and all data read from it as Response events.
+func (r *Recorder) RecordConn(ctx context.Context, id tunnel.ConnID, conn net.Conn) net.Conn {
+	rc := &recordingConn{Conn: conn, ctx: ctx, id: id.String(), recorder: r}
+	rc.record(&Record{Event: Open, Network: strings.TrimRight(id.ProtocolString(), "46")})
+	return rc
+}
+
+func (c *recordingConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.recordPayload(Response, b[:n])
+	}
+	return n, err
+}
+
+func (c *recordingConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	if n > 0 {
+		c.recordPayload(Request, b[:n])
+	}
+	return n, err
+}
+
+func (c *recordingConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.record(&Record{Event: Close})
+	})
+	return c.Conn.Close()
+}
+
+func (c *recordingConn) recordPayload(event Event, data []byte) {
+	rec := &Record{Event: event}
+	rec.setPayload(data)
+	c.record(rec)
+}
+
+func (c *recordingConn) record(rec *Record) {
+	rec.ConnID = c.id
+	if err := c.recorder.Record(rec); err != nil {
+		dlog.Errorf(c.ctx, "!! CONN %s, unable to record %s to %s: %v", c.id, rec.Event, c.recorder.Name(), err)
+	}
+}
@@ -0,0 +1,184 @@
+// Package recording contains the file format used when recording the connections that an intercept delivers
+// to the workstation, along with the means to write and read such files.
+//
+// A recording is a text file with one JSON object per line. The first line is a Header. Each following line is
+// a Record describing one event of a connection: that it was opened, that data was sent in either direction,
+// or that it was closed. Payloads that are valid UTF-8 are stored as text so that a recording is easy to read
+// and diff when it's kept under version control.
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Version is the version of the recording file format.
+const Version = 1
+
+// Header is the first line of a recording.
+type Header struct {
+	Version   int       `json:"version"`
+	Intercept string    `json:"intercept"`
+	Namespace string    `json:"namespace,omitempty"`
+	Workload  string    `json:"workload,omitempty"`
+	Mechanism string    `json:"mechanism,omitempty"`
+	Started   time.Time `json:"started"`
+}
+
+// Event is the kind of event that a Record describes.
+type Event string
+
+const (
+	// Open is recorded when a connection is established. Its Network is the network to use when replaying it.
+	Open = Event("open")
+
+	// Request is recorded when data that the intercept received is sent to the local process.
+	Request = Event("request")
+
+	// Response is recorded when data that the local process responded with is sent back to the intercept.
+	Response = Event("response")
+
+	// Close is recorded when the connection is closed.
+	Close = Event("close")
+)
+
+// Record is one event of a recorded connection.
+type Record struct {
+	Time    time.Time `json:"time"`
+	ConnID  string    `json:"conn_id"`
+	Event   Event     `json:"event"`
+	Network string    `json:"network,omitempty"`
+	Text    string    `json:"text,omitempty"`
+	Data    []byte    `json:"data,omitempty"`
+}
+
+// Payload returns the data of a Request or Response record.
+func (r *Record) Payload() []byte {
+	if r.Text != "" {
+		return []byte(r.Text)
+	}
+	return r.Data
+}
+
+func (r *Record) setPayload(data []byte) {
+	if utf8.Valid(data) {
+		r.Text = string(data)
+	} else {
+		r.Data = append([]byte(nil), data...)
+	}
+}
+
+// Recorder writes records to a recording file. It's safe for concurrent use.
+type Recorder struct {
+	mu     sync.Mutex
+	file   *os.File
+	enc    *json.Encoder
+	closed bool
+}
+
+// Create creates the recording file at the given path, truncating any existing file, and writes the header.
+func Create(path string, hdr *Header) (*Recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	hdr.Version = Version
+	if hdr.Started.IsZero() {
+		hdr.Started = time.Now()
+	}
+	enc := json.NewEncoder(file)
+	if err = enc.Encode(hdr); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return &Recorder{file: file, enc: enc}, nil
+}
+
+// Name returns the name of the recording file.
+func (r *Recorder) Name() string {
+	return r.file.Name()
+}
+
+// Record writes the given record to the recording file. Records written after Close are discarded.
+func (r *Recorder) Record(rec *Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil
+	}
+	if rec.Time.IsZero() {
+		rec.Time = time.Now()
+	}
+	return r.enc.Encode(rec)
+}
+
+// Close closes the recording file.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	return r.file.Close()
+}
+
+// Conn is the records of one connection of a recording, in the order that they were recorded.
+type Conn struct {
+	ID      string
+	Network string
+	Records []*Record
+}
+
+// Read reads a recording and returns its header and its connections in the order that they were opened.
+func Read(rd io.Reader) (*Header, []*Conn, error) {
+	dec := json.NewDecoder(bufio.NewReader(rd))
+	var hdr Header
+	if err := dec.Decode(&hdr); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, errors.New("recording is empty")
+		}
+		return nil, nil, fmt.Errorf("unable to read recording header: %w", err)
+	}
+	if hdr.Version != Version {
+		return nil, nil, fmt.Errorf("unsupported recording version %d", hdr.Version)
+	}
+
+	var conns []*Conn
+	byID := make(map[string]*Conn)
+	for line := 2; ; line++ {
+		rec := &Record{}
+		if err := dec.Decode(rec); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, nil, fmt.Errorf("unable to read record on line %d: %w", line, err)
+		}
+		c, ok := byID[rec.ConnID]
+		if !ok || rec.Event == Open {
+			// A connection ID may be reused once the connection that used it has been closed.
+			c = &Conn{ID: rec.ConnID, Network: rec.Network}
+			byID[rec.ConnID] = c
+			conns = append(conns, c)
+		}
+		c.Records = append(c.Records, rec)
+	}
+	return &hdr, conns, nil
+}
+
+// ReadFile reads the recording file at the given path.
+func ReadFile(path string) (*Header, []*Conn, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
@@ -0,0 +1,147 @@
+package recording
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TinderBackend/telepresence/v2/pkg/ipproto"
+	"github.com/TinderBackend/telepresence/v2/pkg/iputil"
+	"github.com/TinderBackend/telepresence/v2/pkg/tunnel"
+	"github.com/datawire/dlib/dlog"
+)
+
+// startEchoServer starts a TCP server that echoes everything it receives and returns its address.
+func startEchoServer(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+	return l.Addr().String()
+}
+
+func TestRecorder_RecordConn(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+	addr := startEchoServer(t)
+	path := filepath.Join(t.TempDir(), "recording.jsonl")
+
+	r, err := Create(path, &Header{Intercept: "echo", Namespace: "default"})
+	require.NoError(t, err)
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	id := tunnel.NewConnID(ipproto.TCP, iputil.Parse("10.0.0.1"), iputil.Parse("127.0.0.1"), 34567, 8080)
+	rc := r.RecordConn(ctx, id, conn)
+
+	binary := []byte{0xff, 0x00, 0xfe}
+	buf := make([]byte, 10)
+	for _, data := range [][]byte{[]byte("hello"), binary} {
+		_, err = rc.Write(data)
+		require.NoError(t, err)
+		n, err := io.ReadFull(rc, buf[:len(data)])
+		require.NoError(t, err)
+		require.Equal(t, data, buf[:n])
+	}
+	require.NoError(t, rc.Close())
+	_ = rc.Close() // only the first close is recorded
+	require.NoError(t, r.Close())
+
+	hdr, conns, err := ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, Version, hdr.Version)
+	assert.Equal(t, "echo", hdr.Intercept)
+	assert.Equal(t, "default", hdr.Namespace)
+	require.Len(t, conns, 1)
+
+	c := conns[0]
+	assert.Equal(t, id.String(), c.ID)
+	assert.Equal(t, "tcp", c.Network)
+	var events []Event
+	for _, rec := range c.Records {
+		events = append(events, rec.Event)
+		assert.False(t, rec.Time.IsZero())
+	}
+	assert.Equal(t, []Event{Open, Request, Response, Request, Response, Close}, events)
+	assert.Equal(t, "hello", c.Records[1].Text)
+	assert.Equal(t, []byte("hello"), c.Records[2].Payload())
+	assert.Empty(t, c.Records[3].Text)
+	assert.Equal(t, binary, c.Records[3].Payload())
+}
+
+func TestRead_errors(t *testing.T) {
+	_, _, err := Read(bytes.NewReader(nil))
+	assert.EqualError(t, err, "recording is empty")
+
+	_, _, err = Read(bytes.NewBufferString(`{"version":2,"intercept":"x"}` + "\n"))
+	assert.EqualError(t, err, "unsupported recording version 2")
+
+	_, _, err = Read(bytes.NewBufferString(`{"version":1,"intercept":"x"}` + "\n{bad\n"))
+	assert.Error(t, err)
+}
+
+func TestReplay(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+	addr := startEchoServer(t)
+
+	c := &Conn{
+		ID:      "tcp 10.0.0.1:34567 -> 127.0.0.1:8080",
+		Network: "tcp",
+		Records: []*Record{
+			{Event: Open},
+			{Event: Request, Text: "ping"},
+			{Event: Response, Text: "ping"},
+			{Event: Request, Data: []byte{0xff, 0x00}},
+			{Event: Response, Data: []byte{0xff, 0x00}},
+			{Event: Close},
+		},
+	}
+	responses := &bytes.Buffer{}
+	result, err := Replay(ctx, c, addr, time.Second, responses)
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Sent: 6, Received: 6, Recorded: 6}, result)
+	assert.Equal(t, append([]byte("ping"), 0xff, 0x00), responses.Bytes())
+}
+
+func TestReplay_noResponse(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	go func() {
+		// Accept and read, but never respond
+		conn, err := l.Accept()
+		if err == nil {
+			_, _ = io.Copy(io.Discard, conn)
+		}
+	}()
+
+	c := &Conn{
+		ID: "tcp 10.0.0.1:34567 -> 127.0.0.1:8080",
+		Records: []*Record{
+			{Event: Open},
+			{Event: Request, Text: "ping"},
+			{Event: Response, Text: "pong"},
+		},
+	}
+	result, err := Replay(ctx, c, l.Addr().String(), 50*time.Millisecond, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Sent: 4, Received: 0, Recorded: 4}, result)
+}
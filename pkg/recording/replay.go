@@ -0,0 +1,109 @@
+package recording
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"time"
+)
+
+// Result summarizes the replay of one connection.
+type Result struct {
+	// Sent is the number of bytes sent to the local process.
+	Sent int
+
+	// Received is the number of bytes that the local process responded with.
+	Received int
+
+	// Recorded is the number of bytes that the recorded responses contained.
+	Recorded int
+}
+
+// Replay sends the recorded requests of the given connection to the given address. Before a request is sent,
+// the responses that were recorded ahead of it are awaited, so that protocols that alternate between requests
+// and responses are replayed faithfully. A wait gives up when no data arrives within the given timeout. All
+// data that the local process responds with is written to the given writer unless it is nil.
+func Replay(ctx context.Context, c *Conn, addr string, timeout time.Duration, responses io.Writer) (*Result, error) {
+	network := c.Network
+	if network == "" {
+		network = "tcp"
+	}
+	d := net.Dialer{Timeout: timeout}
+	conn, err := d.DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		_ = conn.SetDeadline(time.Now())
+	}()
+
+	r := &replayer{conn: conn, timeout: timeout, responses: responses, buf: make([]byte, 0x10000)}
+	for _, rec := range c.Records {
+		switch rec.Event {
+		case Request:
+			if err = r.awaitResponses(); err == nil {
+				var n int
+				n, err = conn.Write(rec.Payload())
+				r.result.Sent += n
+			}
+		case Response:
+			r.result.Recorded += len(rec.Payload())
+		}
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = r.awaitResponses()
+	}
+	if err != nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	return &r.result, err
+}
+
+type replayer struct {
+	conn      net.Conn
+	timeout   time.Duration
+	responses io.Writer
+	buf       []byte
+	result    Result
+	eof       bool
+}
+
+// awaitResponses reads from the connection until all recorded responses have been matched by received data, the
+// local process closes the connection, or the timeout expires.
+func (r *replayer) awaitResponses() error {
+	for !r.eof && r.result.Received < r.result.Recorded {
+		if err := r.conn.SetReadDeadline(time.Now().Add(r.timeout)); err != nil {
+			return err
+		}
+		n, err := r.conn.Read(r.buf)
+		if n > 0 {
+			r.result.Received += n
+			if r.responses != nil {
+				if _, err := r.responses.Write(r.buf[:n]); err != nil {
+					return err
+				}
+			}
+		}
+		if err != nil {
+			switch {
+			case errors.Is(err, io.EOF):
+				r.eof = true
+			case errors.Is(err, os.ErrDeadlineExceeded):
+				return nil
+			default:
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -16,6 +16,7 @@ const HeaderCallerInterceptID = "x-telepresence-caller-intercept-id"
 const HeaderInterceptID = "x-telepresence-intercept-id"
 const EndPointConsumeHere = "/consume-here"
 const EndPointInterceptInfo = "/intercept-info"
+const EndPointMetrics = "/metrics"
 
 type InterceptInfo struct {
 	// True if the service is being intercepted
@@ -34,6 +35,11 @@ type AgentState interface {
 	InterceptInfo(ctx context.Context, callerID, path string, headers http.Header) (*InterceptInfo, error)
 }
 
+// MetricsProvider is implemented by an AgentState that serves Prometheus metrics on the EndPointMetrics endpoint.
+type MetricsProvider interface {
+	MetricsHandler() http.Handler
+}
+
 type Server interface {
 	ListenAndServe(context.Context, int) error
 	Serve(context.Context, net.Listener) error
@@ -103,6 +109,9 @@ func (s *server) Serve(c context.Context, ln net.Listener) error {
 	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	})
+	if mp, ok := s.agent.(MetricsProvider); ok {
+		mux.Handle(EndPointMetrics, mp.MetricsHandler())
+	}
 
 	server := &dhttp.ServerConfig{Handler: mux}
 	info := fmt.Sprintf("Telepresnece API server on %v", ln.Addr())
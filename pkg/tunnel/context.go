@@ -1,6 +1,9 @@
 package tunnel
 
-import "context"
+import (
+	"context"
+	"net"
+)
 
 type poolKey struct{}
 
@@ -16,3 +19,25 @@ func GetPool(ctx context.Context) *Pool {
 	}
 	return pool
 }
+
+// ConnRecorder records the traffic of the connections that a dialer establishes.
+type ConnRecorder interface {
+	// RecordConn returns a connection that records all traffic that passes through the given
+	// connection, or the given connection itself when connections with the given ID aren't recorded.
+	RecordConn(ctx context.Context, id ConnID, conn net.Conn) net.Conn
+}
+
+type connRecorderKey struct{}
+
+// WithConnRecorder returns a context with the given ConnRecorder
+func WithConnRecorder(ctx context.Context, recorder ConnRecorder) context.Context {
+	return context.WithValue(ctx, connRecorderKey{}, recorder)
+}
+
+func GetConnRecorder(ctx context.Context) ConnRecorder {
+	recorder, ok := ctx.Value(connRecorderKey{}).(ConnRecorder)
+	if !ok {
+		return nil
+	}
+	return recorder
+}
@@ -44,7 +44,13 @@ type dialer struct {
 //
 // The handler remains active until it's been idle for idleDuration, at which time it will automatically close
 // and call the release function it got from the tunnel.Pool to ensure that it gets properly released.
+//
+// Streams for ICMP have no connection to dial, so a pinger is returned for them.
 func NewDialer(stream Stream) Endpoint {
+	switch stream.ID().Protocol() {
+	case ipproto.ICMP, ipproto.ICMPV6:
+		return NewPinger(stream)
+	}
 	return NewConnEndpoint(stream, nil)
 }
 
@@ -93,6 +99,9 @@ func (h *dialer) Start(ctx context.Context) {
 				return
 			}
 			dlog.Debugf(ctx, "   CONN %s, dial answered", id)
+			if recorder := GetConnRecorder(ctx); recorder != nil {
+				conn = recorder.RecordConn(ctx, id, conn)
+			}
 			h.conn = conn
 
 		case connecting:
@@ -0,0 +1,265 @@
+package tunnel
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/pkg/ipproto"
+	"github.com/datawire/dlib/dlog"
+)
+
+// prefetchSize is the number of messages that a stream with added latency reads ahead of its consumer.
+const prefetchSize = 64
+
+// WithFaults returns a Stream that injects the faults described by the given spec into the given
+// Stream. The given Stream is returned unchanged when the spec doesn't describe any faults.
+//
+// The decisions to reset a connection, and to drop all data of a TCP connection, are made once, when
+// the Stream is created. UDP datagrams and ICMP echoes are dropped one by one. Control messages are
+// never dropped or delayed by the bandwidth limit.
+func WithFaults(s Stream, spec *rpc.FaultSpec) Stream {
+	if isEmptyFaultSpec(spec) {
+		return s
+	}
+	fs := &faultyStream{
+		Stream:  s,
+		latency: time.Duration(spec.Latency),
+		reset:   chance(spec.ResetPercentage),
+	}
+	if s.ID().Protocol() == ipproto.TCP {
+		fs.blackHole = chance(spec.DropPercentage)
+	} else {
+		fs.dropPercentage = spec.DropPercentage
+	}
+	if spec.Bandwidth > 0 {
+		fs.sendLimit = &bandwidthLimiter{bps: spec.Bandwidth}
+		fs.recvLimit = &bandwidthLimiter{bps: spec.Bandwidth}
+	}
+	return fs
+}
+
+func isEmptyFaultSpec(spec *rpc.FaultSpec) bool {
+	return spec == nil || spec.Latency <= 0 && spec.Bandwidth <= 0 && spec.ResetPercentage <= 0 && spec.DropPercentage <= 0
+}
+
+// chance returns true with the given percentage of probability.
+func chance(percentage float32) bool {
+	return percentage > 0 && rand.Float32()*100 < percentage
+}
+
+type delayedMessage struct {
+	m   Message
+	err error
+	at  time.Time
+}
+
+type faultyStream struct {
+	Stream
+	latency        time.Duration
+	dropPercentage float32
+	blackHole      bool
+	reset          bool
+	isReset        int32
+	sendLimit      *bandwidthLimiter
+	recvLimit      *bandwidthLimiter
+
+	prefetchOnce sync.Once
+	prefetched   chan delayedMessage
+}
+
+func (s *faultyStream) Receive(ctx context.Context) (Message, error) {
+	for {
+		m, err := s.receive(ctx)
+		if err != nil || m.Code() != Normal {
+			return m, err
+		}
+		if s.reset {
+			if atomic.CompareAndSwapInt32(&s.isReset, 0, 1) {
+				dlog.Debugf(ctx, "   %s %s, injecting connection reset", s.Tag(), s.ID())
+				_ = s.Stream.Send(ctx, NewMessage(Disconnect, nil))
+				return NewMessage(Disconnect, nil), nil
+			}
+			continue
+		}
+		if s.blackHole || chance(s.dropPercentage) {
+			continue
+		}
+		if err = s.recvLimit.wait(ctx, len(m.Payload())); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+}
+
+func (s *faultyStream) Send(ctx context.Context, m Message) error {
+	if m.Code() == Normal {
+		if atomic.LoadInt32(&s.isReset) != 0 {
+			return net.ErrClosed
+		}
+		if s.blackHole || chance(s.dropPercentage) {
+			return nil
+		}
+		if err := s.sendLimit.wait(ctx, len(m.Payload())); err != nil {
+			return err
+		}
+	}
+	return s.Stream.Send(ctx, m)
+}
+
+// receive receives the next message from the underlying Stream. When latency is added, the messages are
+// read ahead by a goroutine that timestamps them, so that the latency is added to each message rather than
+// accumulated.
+func (s *faultyStream) receive(ctx context.Context) (Message, error) {
+	if s.latency <= 0 {
+		return s.Stream.Receive(ctx)
+	}
+	s.prefetchOnce.Do(func() {
+		s.prefetched = make(chan delayedMessage, prefetchSize)
+		go s.prefetch(ctx)
+	})
+	var dm delayedMessage
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case m, ok := <-s.prefetched:
+		if !ok {
+			return nil, net.ErrClosed
+		}
+		dm = m
+	}
+	if err := sleepUntil(ctx, dm.at); err != nil {
+		return nil, err
+	}
+	return dm.m, dm.err
+}
+
+func (s *faultyStream) prefetch(ctx context.Context) {
+	defer close(s.prefetched)
+	for {
+		m, err := s.Stream.Receive(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case s.prefetched <- delayedMessage{m: m, err: err, at: time.Now().Add(s.latency)}:
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// bandwidthLimiter limits the rate at which data passes in one direction. A nil limiter doesn't limit anything.
+type bandwidthLimiter struct {
+	sync.Mutex
+	bps  int64
+	next time.Time
+}
+
+// wait waits until n more bytes may pass.
+func (l *bandwidthLimiter) wait(ctx context.Context, n int) error {
+	if l == nil {
+		return nil
+	}
+	l.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	at := l.next
+	l.next = at.Add(time.Duration(int64(n) * int64(time.Second) / l.bps))
+	l.Unlock()
+	return sleepUntil(ctx, at)
+}
+
+func sleepUntil(ctx context.Context, at time.Time) error {
+	d := time.Until(at)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// ParseFaultSpec parses a comma separated list of faults, e.g. "latency=100ms,bandwidth=64Ki,reset=5,drop=10".
+// The latency is a duration, the bandwidth is a quantity of bytes per second, and reset and drop are percentages.
+func ParseFaultSpec(s string) (*rpc.FaultSpec, error) {
+	spec := &rpc.FaultSpec{}
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		eq := strings.IndexByte(f, '=')
+		if eq < 0 {
+			return nil, fmt.Errorf("invalid fault %q, expected <name>=<value>", f)
+		}
+		name, value := f[:eq], f[eq+1:]
+		switch name {
+		case "latency":
+			d, err := time.ParseDuration(value)
+			if err != nil || d < 0 {
+				return nil, fmt.Errorf("invalid latency %q", value)
+			}
+			spec.Latency = int64(d)
+		case "bandwidth":
+			q, err := resource.ParseQuantity(value)
+			if err != nil || q.Sign() < 0 {
+				return nil, fmt.Errorf("invalid bandwidth %q", value)
+			}
+			spec.Bandwidth = q.Value()
+		case "reset", "drop":
+			p, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 32)
+			if err != nil || p < 0 || p > 100 {
+				return nil, fmt.Errorf("invalid %s percentage %q, must be a number between 0 and 100", name, value)
+			}
+			if name == "reset" {
+				spec.ResetPercentage = float32(p)
+			} else {
+				spec.DropPercentage = float32(p)
+			}
+		default:
+			return nil, fmt.Errorf("unknown fault %q, must be one of latency, bandwidth, reset, or drop", name)
+		}
+	}
+	if isEmptyFaultSpec(spec) {
+		return nil, fmt.Errorf("no faults specified in %q", s)
+	}
+	return spec, nil
+}
+
+// FormatFaultSpec returns the given spec in the format accepted by ParseFaultSpec.
+func FormatFaultSpec(spec *rpc.FaultSpec) string {
+	if isEmptyFaultSpec(spec) {
+		return ""
+	}
+	var fs []string
+	if spec.Latency > 0 {
+		fs = append(fs, "latency="+time.Duration(spec.Latency).String())
+	}
+	if spec.Bandwidth > 0 {
+		fs = append(fs, "bandwidth="+resource.NewQuantity(spec.Bandwidth, resource.BinarySI).String())
+	}
+	if spec.ResetPercentage > 0 {
+		fs = append(fs, "reset="+strconv.FormatFloat(float64(spec.ResetPercentage), 'g', -1, 32))
+	}
+	if spec.DropPercentage > 0 {
+		fs = append(fs, "drop="+strconv.FormatFloat(float64(spec.DropPercentage), 'g', -1, 32))
+	}
+	return strings.Join(fs, ",")
+}
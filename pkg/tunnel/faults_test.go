@@ -0,0 +1,149 @@
+package tunnel
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rpc "github.com/TinderBackend/telepresence/rpc/v2/manager"
+	"github.com/TinderBackend/telepresence/v2/pkg/ipproto"
+	"github.com/TinderBackend/telepresence/v2/pkg/iputil"
+	"github.com/datawire/dlib/dlog"
+)
+
+// chanStream is a Stream that receives the messages of its in channel and sends messages to its out channel.
+type chanStream struct {
+	id  ConnID
+	in  chan Message
+	out chan Message
+}
+
+func newChanStream(proto int) *chanStream {
+	return &chanStream{
+		id:  NewConnID(proto, iputil.Parse("10.0.0.1"), iputil.Parse("10.0.0.2"), 34567, 8080),
+		in:  make(chan Message, 10),
+		out: make(chan Message, 10),
+	}
+}
+
+func (s *chanStream) Tag() string                     { return "test" }
+func (s *chanStream) ID() ConnID                      { return s.id }
+func (s *chanStream) PeerVersion() uint16             { return Version }
+func (s *chanStream) SessionID() string               { return "" }
+func (s *chanStream) DialTimeout() time.Duration      { return time.Second }
+func (s *chanStream) RoundtripLatency() time.Duration { return time.Second }
+
+func (s *chanStream) Receive(ctx context.Context) (Message, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case m, ok := <-s.in:
+		if !ok {
+			return nil, net.ErrClosed
+		}
+		return m, nil
+	}
+}
+
+func (s *chanStream) Send(_ context.Context, m Message) error {
+	s.out <- m
+	return nil
+}
+
+func (s *chanStream) CloseSend(context.Context) error {
+	return nil
+}
+
+func TestParseFaultSpec(t *testing.T) {
+	spec, err := ParseFaultSpec("latency=100ms,bandwidth=64Ki, reset=5,drop=12.5%")
+	require.NoError(t, err)
+	assert.Equal(t, int64(100*time.Millisecond), spec.Latency)
+	assert.Equal(t, int64(64*1024), spec.Bandwidth)
+	assert.Equal(t, float32(5), spec.ResetPercentage)
+	assert.Equal(t, float32(12.5), spec.DropPercentage)
+	assert.Equal(t, "latency=100ms,bandwidth=64Ki,reset=5,drop=12.5", FormatFaultSpec(spec))
+
+	for _, bad := range []string{"", "latency", "latency=x", "bandwidth=-1", "drop=101", "reset=-1", "jitter=1ms"} {
+		_, err = ParseFaultSpec(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestWithFaults_none(t *testing.T) {
+	s := newChanStream(ipproto.TCP)
+	assert.Same(t, Stream(s), WithFaults(s, nil))
+	assert.Same(t, Stream(s), WithFaults(s, &rpc.FaultSpec{}))
+}
+
+func TestWithFaults_drop(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+	s := newChanStream(ipproto.UDP)
+	fs := WithFaults(s, &rpc.FaultSpec{DropPercentage: 100})
+
+	// All datagrams are dropped, but control messages pass
+	require.NoError(t, fs.Send(ctx, NewMessage(Normal, []byte("data"))))
+	require.NoError(t, fs.Send(ctx, NewMessage(KeepAlive, nil)))
+	assert.Equal(t, KeepAlive, (<-s.out).Code())
+
+	s.in <- NewMessage(Normal, []byte("data"))
+	s.in <- NewMessage(Disconnect, nil)
+	m, err := fs.Receive(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Disconnect, m.Code())
+}
+
+func TestWithFaults_reset(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+	s := newChanStream(ipproto.TCP)
+	fs := WithFaults(s, &rpc.FaultSpec{ResetPercentage: 100})
+
+	require.NoError(t, fs.Send(ctx, NewMessage(Normal, []byte("request"))))
+	assert.Equal(t, Normal, (<-s.out).Code())
+
+	// The first data from the peer resets the connection at both ends
+	s.in <- NewMessage(Normal, []byte("response"))
+	m, err := fs.Receive(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Disconnect, m.Code())
+	assert.Equal(t, Disconnect, (<-s.out).Code())
+	assert.ErrorIs(t, fs.Send(ctx, NewMessage(Normal, []byte("more"))), net.ErrClosed)
+}
+
+func TestWithFaults_latency(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+	s := newChanStream(ipproto.TCP)
+	fs := WithFaults(s, &rpc.FaultSpec{Latency: int64(100 * time.Millisecond)})
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		s.in <- NewMessage(Normal, []byte("data"))
+	}
+	for i := 0; i < 5; i++ {
+		_, err := fs.Receive(ctx)
+		require.NoError(t, err)
+	}
+	elapsed := time.Since(start)
+
+	// The latency is added to each message, not accumulated
+	assert.GreaterOrEqual(t, elapsed, 100*time.Millisecond)
+	assert.Less(t, elapsed, 400*time.Millisecond)
+}
+
+func TestWithFaults_bandwidth(t *testing.T) {
+	ctx := dlog.NewTestContext(t, false)
+	s := newChanStream(ipproto.TCP)
+	fs := WithFaults(s, &rpc.FaultSpec{Bandwidth: 1000})
+
+	start := time.Now()
+	data := make([]byte, 100)
+	for i := 0; i < 4; i++ {
+		require.NoError(t, fs.Send(ctx, NewMessage(Normal, data)))
+		<-s.out
+	}
+	// The first 100 bytes pass at once, the following 300 bytes take 300ms
+	assert.GreaterOrEqual(t, time.Since(start), 300*time.Millisecond)
+}
@@ -0,0 +1,192 @@
+package tunnel
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+
+	"github.com/TinderBackend/telepresence/v2/pkg/ipproto"
+	"github.com/datawire/dlib/dlog"
+)
+
+// icmpConnTTL controls how long a pinger remains alive without receiving any echo requests.
+const icmpConnTTL = 10 * time.Second
+
+// defaultEchoTimeout is used when the stream doesn't declare a dial timeout.
+const defaultEchoTimeout = 2 * time.Second
+
+// echoProbePort is the port that a pinger connects to when it isn't permitted to open an ICMP socket. A host
+// that accepts or refuses the connection is considered reachable.
+const echoProbePort = 80
+
+// The pinger performs ICMP echo requests on behalf of a peer. Each Normal message received on the stream contains
+// the identifier, sequence number, and data of an echo request, and a Normal message with the same layout is sent
+// back when the echo is answered. Requests that aren't answered are silently dropped, just like unanswered pings.
+type pinger struct {
+	stream Stream
+	done   chan struct{}
+}
+
+// NewPinger creates a new Endpoint that performs the echo requests that it receives on the given stream.
+func NewPinger(stream Stream) Endpoint {
+	return &pinger{stream: stream, done: make(chan struct{})}
+}
+
+func (p *pinger) Start(ctx context.Context) {
+	go func() {
+		defer close(p.done)
+
+		id := p.stream.ID()
+		outgoing := make(chan Message, 5)
+		WriteLoop(ctx, p.stream, outgoing)
+
+		// Wait for pending echoes before closing the outgoing channel
+		wg := sync.WaitGroup{}
+		defer func() {
+			wg.Wait()
+			close(outgoing)
+		}()
+
+		idleTimer := time.NewTimer(icmpConnTTL)
+		defer idleTimer.Stop()
+
+		incoming, errCh := ReadLoop(ctx, p.stream)
+		dlog.Debugf(ctx, "   PING %s, pinger started", id)
+		defer dlog.Debugf(ctx, "   PING %s, pinger ended", id)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-idleTimer.C:
+				return
+			case err := <-errCh:
+				dlog.Error(ctx, err)
+			case m := <-incoming:
+				if m == nil {
+					return
+				}
+				switch m.Code() {
+				case Normal:
+				case Disconnect:
+					return
+				default:
+					continue
+				}
+				if !idleTimer.Stop() {
+					<-idleTimer.C
+				}
+				idleTimer.Reset(icmpConnTTL)
+				wg.Add(1)
+				go func(rq []byte) {
+					defer wg.Done()
+					rp, err := p.echo(ctx, rq)
+					if err != nil {
+						dlog.Debugf(ctx, "!! PING %s, %v", id, err)
+						return
+					}
+					select {
+					case <-ctx.Done():
+					case outgoing <- NewMessage(Normal, rp):
+					}
+				}(m.Payload())
+			}
+		}
+	}()
+}
+
+func (p *pinger) Done() <-chan struct{} {
+	return p.done
+}
+
+// echo sends an echo request with the given identifier, sequence number, and data to the destination of the
+// stream and returns the reply. A TCP connect probe is used when no unprivileged ICMP socket can be opened.
+func (p *pinger) echo(ctx context.Context, rq []byte) ([]byte, error) {
+	if len(rq) < 4 {
+		return nil, fmt.Errorf("echo request is too short (%d bytes)", len(rq))
+	}
+	timeout := p.stream.DialTimeout()
+	if timeout <= 0 {
+		timeout = defaultEchoTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	id := p.stream.ID()
+	var network string
+	var proto int
+	var echoType, replyType icmp.Type
+	if id.IsIPv4() {
+		network, proto, echoType, replyType = "udp4", ipproto.ICMP, ipv4.ICMPTypeEcho, ipv4.ICMPTypeEchoReply
+	} else {
+		network, proto, echoType, replyType = "udp6", ipproto.ICMPV6, ipv6.ICMPTypeEchoRequest, ipv6.ICMPTypeEchoReply
+	}
+	conn, err := icmp.ListenPacket(network, "")
+	if err != nil {
+		dlog.Debugf(ctx, "   PING %s, unable to open ICMP socket, using TCP probe: %v", id, err)
+		return rq, tcpProbe(ctx, id.Destination())
+	}
+	defer conn.Close()
+	go func() {
+		<-ctx.Done()
+		_ = conn.SetReadDeadline(time.Now())
+	}()
+
+	seq := int(binary.BigEndian.Uint16(rq[2:]))
+	msg := icmp.Message{
+		Type: echoType,
+		Body: &icmp.Echo{ID: int(binary.BigEndian.Uint16(rq)), Seq: seq, Data: rq[4:]},
+	}
+	wb, err := msg.Marshal(nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = conn.WriteTo(wb, &net.UDPAddr{IP: id.Destination()}); err != nil {
+		return nil, err
+	}
+
+	rb := make([]byte, 0x10000)
+	for {
+		n, _, err := conn.ReadFrom(rb)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("no echo reply within %s", timeout)
+			}
+			return nil, err
+		}
+		rm, err := icmp.ParseMessage(proto, rb[:n])
+		if err != nil || rm.Type != replyType {
+			continue
+		}
+		// The kernel replaces the identifier of the request with its own, so only the sequence is compared.
+		if body, ok := rm.Body.(*icmp.Echo); ok && body.Seq == seq {
+			rp := make([]byte, 4+len(body.Data))
+			copy(rp, rq[:4])
+			copy(rp[4:], body.Data)
+			return rp, nil
+		}
+	}
+}
+
+// tcpProbe returns nil if the given IP accepts or refuses a TCP connection.
+func tcpProbe(ctx context.Context, ip net.IP) error {
+	d := net.Dialer{}
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(ip.String(), strconv.Itoa(echoProbePort)))
+	if err == nil {
+		_ = conn.Close()
+		return nil
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return nil
+	}
+	return err
+}
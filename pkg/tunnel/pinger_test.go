@@ -0,0 +1,51 @@
+package tunnel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TinderBackend/telepresence/v2/pkg/ipproto"
+	"github.com/TinderBackend/telepresence/v2/pkg/iputil"
+)
+
+func TestPinger_Echo(t *testing.T) {
+	ctx, cancel := testContext(t, 10*time.Second)
+	defer cancel()
+
+	tunnel := newBidi(10, ctx.Done())
+	id := NewConnID(ipproto.ICMP, iputil.Parse("127.0.0.1"), iputil.Parse("127.0.0.1"), 0x1234, 0)
+	si := uuid.New().String()
+
+	go func() {
+		server, err := NewServerStream(ctx, tunnel.serverSide())
+		if err != nil {
+			return
+		}
+		d := NewDialer(server)
+		assert.IsType(t, &pinger{}, d)
+		d.Start(ctx)
+		<-d.Done()
+	}()
+
+	client, err := NewClientStream(ctx, tunnel.clientSide(), id, si, 0, time.Second)
+	require.NoError(t, err)
+
+	// identifier 0x1234, sequence 7, and some data
+	rq := []byte{0x12, 0x34, 0x00, 0x07, 'p', 'i', 'n', 'g'}
+	require.NoError(t, client.Send(ctx, NewMessage(Normal, rq)))
+	rdCh, errCh := ReadLoop(ctx, client)
+	select {
+	case <-ctx.Done():
+		t.Fatal("no echo reply")
+	case err := <-errCh:
+		t.Fatal(err)
+	case m := <-rdCh:
+		require.NotNil(t, m)
+		assert.Equal(t, Normal, m.Code())
+		assert.Equal(t, rq, m.Payload())
+	}
+}
@@ -0,0 +1,163 @@
+package icmp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+
+	"github.com/TinderBackend/telepresence/v2/pkg/tunnel"
+	"github.com/TinderBackend/telepresence/v2/pkg/vif/ip"
+	"github.com/datawire/dlib/dlog"
+)
+
+type PacketHandler interface {
+	tunnel.Handler
+	HandlePacket(ctx context.Context, pkt Packet)
+}
+
+// IsEchoRequest returns true if the given packet is an ICMP or ICMPv6 echo request.
+func IsEchoRequest(pkt Packet) bool {
+	t := pkt.Header().MessageType()
+	if pkt.IPHeader().Version() == ipv4.Version {
+		return t == int(ipv4.ICMPTypeEcho)
+	}
+	return t == int(ipv6.ICMPTypeEchoRequest)
+}
+
+// EchoID returns the identifier of an echo request.
+func EchoID(pkt Packet) uint16 {
+	rh := pkt.Header().RestOfHeader()
+	return uint16(rh[0])<<8 | uint16(rh[1])
+}
+
+// echoHandler sends the echo requests that it receives from the TUN device over a tunnel stream. The peer
+// performs the echo and the handler synthesizes the echo reply and writes it to the TUN device.
+type echoHandler struct {
+	id        tunnel.ConnID
+	stream    tunnel.Stream
+	toTun     ip.Writer
+	fromTun   chan Packet
+	idleTimer *time.Timer
+	idleLock  sync.Mutex
+	remove    func()
+}
+
+const ioChannelSize = 0x10
+const idleDuration = 10 * time.Second
+
+// NewEchoHandler creates a handler for the echo requests of the given connection ID. The ID's source port
+// is the echo identifier.
+func NewEchoHandler(stream tunnel.Stream, toTun ip.Writer, id tunnel.ConnID, remove func()) PacketHandler {
+	return &echoHandler{
+		id:      id,
+		stream:  stream,
+		toTun:   toTun,
+		fromTun: make(chan Packet, ioChannelSize),
+		remove:  remove,
+	}
+}
+
+func (h *echoHandler) HandlePacket(ctx context.Context, pkt Packet) {
+	select {
+	case <-ctx.Done():
+		pkt.Release()
+	case h.fromTun <- pkt:
+	}
+}
+
+func (h *echoHandler) Start(ctx context.Context) {
+	h.idleTimer = time.NewTimer(idleDuration)
+	go h.readLoop(ctx)
+	go h.writeLoop(ctx)
+}
+
+func (h *echoHandler) Close(_ context.Context) {
+	h.remove()
+}
+
+func (h *echoHandler) resetIdle() bool {
+	h.idleLock.Lock()
+	stopped := h.idleTimer.Stop()
+	if stopped {
+		h.idleTimer.Reset(idleDuration)
+	}
+	h.idleLock.Unlock()
+	return stopped
+}
+
+// writeLoop sends the identifier, sequence number, and data of each echo request to the stream.
+func (h *echoHandler) writeLoop(ctx context.Context) {
+	defer func() {
+		h.Close(ctx)
+		if err := h.stream.CloseSend(ctx); err != nil {
+			dlog.Debugf(ctx, "!! MGR %s, CloseSend failed: %v", h.id, err)
+		}
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.idleTimer.C:
+			return
+		case pkt := <-h.fromTun:
+			if !h.resetIdle() {
+				pkt.Release()
+				return
+			}
+			dlog.Debugf(ctx, "<- TUN %s", pkt)
+			hdr := pkt.Header()
+			err := h.stream.Send(ctx, tunnel.NewMessage(tunnel.Normal, hdr[4:HeaderLen+pkt.PayloadLen()]))
+			pkt.Release()
+			if err != nil {
+				if ctx.Err() == nil {
+					dlog.Errorf(ctx, "failed to send echo request: %v", err)
+				}
+				return
+			}
+		}
+	}
+}
+
+// readLoop writes an echo reply to the TUN device for each echo reply received on the stream.
+func (h *echoHandler) readLoop(ctx context.Context) {
+	incoming, errCh := tunnel.ReadLoop(ctx, h.stream)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err := <-errCh:
+			dlog.Error(ctx, err)
+		case m := <-incoming:
+			if m == nil {
+				return
+			}
+			if m.Code() != tunnel.Normal || len(m.Payload()) < 4 {
+				continue
+			}
+			h.resetIdle()
+			sendEchoReplyToTun(ctx, h.id, m.Payload(), h.toTun)
+		}
+	}
+}
+
+func sendEchoReplyToTun(ctx context.Context, id tunnel.ConnID, payload []byte, toTun ip.Writer) {
+	pkt := NewPacket(HeaderLen-4+len(payload), id.Destination(), id.Source())
+	defer pkt.Release()
+
+	ipHdr := pkt.IPHeader()
+	icmpHdr := Header(ipHdr.Payload())
+	if ipHdr.Version() == ipv4.Version {
+		icmpHdr.SetMessageType(int(ipv4.ICMPTypeEchoReply))
+	} else {
+		icmpHdr.SetMessageType(int(ipv6.ICMPTypeEchoReply))
+	}
+	icmpHdr.SetCode(0)
+	copy(icmpHdr[4:], payload)
+	icmpHdr.SetChecksum(ipHdr)
+	if err := toTun.Write(ctx, pkt); err != nil {
+		dlog.Errorf(ctx, "!! TUN %s: %v", id, err)
+	}
+}
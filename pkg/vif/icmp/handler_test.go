@@ -0,0 +1,67 @@
+package icmp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+
+	"github.com/TinderBackend/telepresence/v2/pkg/ipproto"
+	"github.com/TinderBackend/telepresence/v2/pkg/iputil"
+	"github.com/TinderBackend/telepresence/v2/pkg/tunnel"
+	"github.com/TinderBackend/telepresence/v2/pkg/vif/ip"
+)
+
+type captureWriter struct {
+	pkts [][]byte
+}
+
+func (w *captureWriter) Write(_ context.Context, pkt ip.Packet) error {
+	w.pkts = append(w.pkts, append([]byte(nil), pkt.IPHeader().Packet()...))
+	return nil
+}
+
+func TestSendEchoReplyToTun(t *testing.T) {
+	payload := []byte{0x12, 0x34, 0x00, 0x07, 'p', 'i', 'n', 'g'}
+	tests := []struct {
+		name      string
+		proto     int
+		src       string
+		dst       string
+		replyType icmp.Type
+	}{
+		{"IPv4", ipproto.ICMP, "10.0.0.1", "10.1.0.1", ipv4.ICMPTypeEchoReply},
+		{"IPv6", ipproto.ICMPV6, "fd00::1", "fd01::1", ipv6.ICMPTypeEchoReply},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := tunnel.NewConnID(tt.proto, iputil.Parse(tt.src), iputil.Parse(tt.dst), 0x1234, 0)
+			w := &captureWriter{}
+			sendEchoReplyToTun(context.Background(), id, payload, w)
+			require.Len(t, w.pkts, 1)
+
+			ipHdr, err := ip.ParseHeader(w.pkts[0])
+			require.NoError(t, err)
+			assert.True(t, ipHdr.Source().Equal(iputil.Parse(tt.dst)))
+			assert.True(t, ipHdr.Destination().Equal(iputil.Parse(tt.src)))
+			assert.Equal(t, tt.proto, ipHdr.L4Protocol())
+
+			m, err := icmp.ParseMessage(tt.proto, ipHdr.Payload())
+			require.NoError(t, err)
+			assert.Equal(t, tt.replyType, m.Type)
+			echo, ok := m.Body.(*icmp.Echo)
+			require.True(t, ok)
+			assert.Equal(t, 0x1234, echo.ID)
+			assert.Equal(t, 7, echo.Seq)
+			assert.Equal(t, []byte("ping"), echo.Data)
+			if tt.proto == ipproto.ICMP {
+				// A valid checksum makes the checksum of the whole message zero
+				assert.Equal(t, uint16(0), checksum(ipHdr.Payload()))
+			}
+		})
+	}
+}
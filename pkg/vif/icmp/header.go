@@ -43,11 +43,28 @@ func (h Header) Payload() []byte {
 }
 
 func (h Header) SetChecksum(ipHdr ip.Header) {
-	var proto int
 	if ipHdr.Version() == ipv4.Version {
-		proto = ipproto.ICMP
+		// Unlike ICMPv6, the ICMP checksum doesn't include an IP pseudo header
+		h[2] = 0
+		h[3] = 0
+		binary.BigEndian.PutUint16(h[2:], checksum(h[:ipHdr.PayloadLen()]))
 	} else {
-		proto = ipproto.ICMPV6
+		ip.L4Checksum(ipHdr, 2, ipproto.ICMPV6)
 	}
-	ip.L4Checksum(ipHdr, 2, proto)
+}
+
+func checksum(b []byte) uint16 {
+	s := 0
+	l := len(b)
+	if l%2 != 0 {
+		l--
+		s = int(b[l]) << 8
+	}
+	for i := 0; i < l; i += 2 {
+		s += int(b[i])<<8 | int(b[i+1])
+	}
+	for s > 0xffff {
+		s = (s >> 16) + (s & 0xffff)
+	}
+	return ^uint16(s)
 }
@@ -3,6 +3,8 @@ package ip
 import (
 	"encoding/binary"
 	"net"
+	"sort"
+	"time"
 
 	"golang.org/x/net/ipv6"
 
@@ -100,7 +102,218 @@ func (h V6Header) PseudoHeader(l4Proto int) []byte {
 	return b
 }
 
-func (h V6Header) ProcessFragments(data *buffer.Data, fragsMap map[uint16][]*buffer.Data) *buffer.Data {
-	// TODO: Implement based on Extension headers
-	return nil
+// IPv6 extension headers that may precede a fragment header.
+const (
+	hopByHopHeader = 0
+	routingHeader  = 43
+	fragmentHeader = 44
+	destOptsHeader = 60
+)
+
+const fragmentHeaderLen = 8
+
+// fragmentHeaderPos returns the position of the fragment header, and the position of the next header
+// field that refers to it. Both positions are -1 when the packet has no fragment header.
+func (h V6Header) fragmentHeaderPos() (pos, nextHeaderPos int) {
+	nextHeaderPos = 6
+	pos = ipv6.HeaderLen
+	end := ipv6.HeaderLen + h.PayloadLen()
+	if end > len(h) {
+		return -1, -1
+	}
+	for {
+		switch h[nextHeaderPos] {
+		case fragmentHeader:
+			if pos+fragmentHeaderLen > end {
+				return -1, -1
+			}
+			return pos, nextHeaderPos
+		case hopByHopHeader, routingHeader, destOptsHeader:
+			if pos+2 > end {
+				return -1, -1
+			}
+			nextHeaderPos = pos
+			pos += (int(h[pos+1]) + 1) * 8
+		default:
+			return -1, -1
+		}
+	}
+}
+
+// IsFragment returns true if this packet has a fragment header.
+func (h V6Header) IsFragment() bool {
+	pos, _ := h.fragmentHeaderPos()
+	return pos >= 0
+}
+
+// v6Fragment is a fragment of an IPv6 packet that awaits reassembly.
+type v6Fragment struct {
+	data          *buffer.Data
+	fragPos       int // position of the fragment header
+	nextHeaderPos int // position of the next header field that refers to the fragment header
+	offset        int // offset of the fragment data in the reassembled payload
+}
+
+func (f *v6Fragment) header() V6Header {
+	return f.data.Buf()
+}
+
+// payload returns the fragment data, i.e. what follows the fragment header.
+func (f *v6Fragment) payload() []byte {
+	h := f.header()
+	return h[f.fragPos+fragmentHeaderLen : ipv6.HeaderLen+h.PayloadLen()]
+}
+
+type v6Fragments struct {
+	created   time.Time
+	fragments []*v6Fragment
+	totalLen  int // length of the reassembled fragmentable part, or -1 until the last fragment has arrived
+}
+
+func (fs *v6Fragments) release() {
+	for _, f := range fs.fragments {
+		buffer.DataPool.Put(f.data)
+	}
+}
+
+// maxV6Fragments is the maximum number of fragments that a packet may consist of.
+const maxV6Fragments = 128
+
+// V6FragmentCache holds fragments of IPv6 packets until they can be reassembled. It is bounded by
+// the number of packets that it holds and the fragments of a packet are discarded if the packet isn't
+// reassembled within the cache timeout. The cache is not safe for concurrent use.
+type V6FragmentCache struct {
+	maxPackets int
+	timeout    time.Duration
+	packets    map[string]*v6Fragments
+}
+
+// NewV6FragmentCache returns a cache that holds the fragments of at most maxPackets packets, each for at
+// most the given timeout. RFC 8200 recommends a timeout of 60 seconds.
+func NewV6FragmentCache(maxPackets int, timeout time.Duration) *V6FragmentCache {
+	return &V6FragmentCache{
+		maxPackets: maxPackets,
+		timeout:    timeout,
+		packets:    make(map[string]*v6Fragments),
+	}
+}
+
+// Len returns the number of packets that await reassembly.
+func (c *V6FragmentCache) Len() int {
+	return len(c.packets)
+}
+
+// makeRoom discards the fragments of expired packets, and the fragments of the oldest packet if the cache
+// is still full.
+func (c *V6FragmentCache) makeRoom(now time.Time) {
+	var oldestKey string
+	var oldest *v6Fragments
+	for k, fs := range c.packets {
+		if now.Sub(fs.created) >= c.timeout {
+			fs.release()
+			delete(c.packets, k)
+		} else if oldest == nil || fs.created.Before(oldest.created) {
+			oldestKey, oldest = k, fs
+		}
+	}
+	if len(c.packets) >= c.maxPackets && oldest != nil {
+		oldest.release()
+		delete(c.packets, oldestKey)
+	}
+}
+
+func (c *V6FragmentCache) drop(key string) {
+	if fs, ok := c.packets[key]; ok {
+		fs.release()
+		delete(c.packets, key)
+	}
+}
+
+// ConcatFragments adds the fragment in the given data to the cache. The reassembled packet is returned when all
+// fragments of a packet have arrived, and nil is returned otherwise. The reassembled packet has no fragment header.
+// Data that isn't a fragment is returned unchanged.
+func (h V6Header) ConcatFragments(data *buffer.Data, cache *V6FragmentCache) *buffer.Data {
+	fragPos, nextHeaderPos := h.fragmentHeaderPos()
+	if fragPos < 0 {
+		return data
+	}
+	fh := h[fragPos : fragPos+fragmentHeaderLen]
+	offsetAndFlags := binary.BigEndian.Uint16(fh[2:])
+	frag := &v6Fragment{
+		data:          data,
+		fragPos:       fragPos,
+		nextHeaderPos: nextHeaderPos,
+		offset:        int(offsetAndFlags &^ 7),
+	}
+	moreFragments := offsetAndFlags&1 != 0
+	if moreFragments && len(frag.payload())%8 != 0 {
+		// All fragments but the last must be a multiple of 8 octets long
+		buffer.DataPool.Put(data)
+		return nil
+	}
+
+	// Fragments are identified by source, destination, and identification
+	key := string(h[8:40]) + string(fh[4:8])
+	now := time.Now()
+	fs, ok := cache.packets[key]
+	if ok && now.Sub(fs.created) >= cache.timeout {
+		cache.drop(key)
+		ok = false
+	}
+	if !ok {
+		cache.makeRoom(now)
+		fs = &v6Fragments{created: now, totalLen: -1}
+		cache.packets[key] = fs
+	}
+	if len(fs.fragments) >= maxV6Fragments {
+		cache.drop(key)
+		buffer.DataPool.Put(data)
+		return nil
+	}
+	fs.fragments = append(fs.fragments, frag)
+	if !moreFragments {
+		fs.totalLen = frag.offset + len(frag.payload())
+	}
+	if fs.totalLen < 0 {
+		// last fragment hasn't arrived yet.
+		return nil
+	}
+
+	sort.Slice(fs.fragments, func(i, j int) bool {
+		return fs.fragments[i].offset < fs.fragments[j].offset
+	})
+
+	// Ensure that there are no holes in the fragment chain. Overlapping fragments cause the
+	// whole packet to be discarded (RFC 5722).
+	expectedOffset := 0
+	for _, f := range fs.fragments {
+		switch {
+		case f.offset > expectedOffset:
+			// There's a gap. Await more fragments
+			return nil
+		case f.offset < expectedOffset:
+			cache.drop(key)
+			return nil
+		}
+		expectedOffset = f.offset + len(f.payload())
+	}
+	first := fs.fragments[0]
+	unfragmentable := first.header()[:first.fragPos]
+	if expectedOffset != fs.totalLen || len(unfragmentable)-ipv6.HeaderLen+fs.totalLen > 0xffff {
+		cache.drop(key)
+		return nil
+	}
+
+	final := buffer.DataPool.Get(len(unfragmentable) + fs.totalLen)
+	fb := final.Buf()
+	copy(fb, unfragmentable)
+	fb[first.nextHeaderPos] = first.header()[first.fragPos] // next header of the fragment header
+	for _, f := range fs.fragments {
+		copy(fb[len(unfragmentable)+f.offset:], f.payload())
+	}
+	cache.drop(key)
+
+	finalHeader := V6Header(fb)
+	finalHeader.SetPayloadLen(len(unfragmentable) - ipv6.HeaderLen + fs.totalLen)
+	return final
 }
@@ -0,0 +1,140 @@
+package ip
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/ipv6"
+
+	"github.com/TinderBackend/telepresence/v2/pkg/ipproto"
+	"github.com/TinderBackend/telepresence/v2/pkg/iputil"
+	"github.com/TinderBackend/telepresence/v2/pkg/vif/buffer"
+)
+
+// makeV6Fragments splits the given payload into fragments of at most fragLen bytes (a multiple of 8).
+func makeV6Fragments(t *testing.T, id uint32, src, dst string, payload []byte, fragLen int) []*buffer.Data {
+	t.Helper()
+	var frags []*buffer.Data
+	for offset := 0; offset < len(payload); offset += fragLen {
+		end := offset + fragLen
+		more := uint16(1)
+		if end >= len(payload) {
+			end = len(payload)
+			more = 0
+		}
+		data := buffer.DataPool.Get(ipv6.HeaderLen + fragmentHeaderLen + end - offset)
+		h := V6Header(data.Buf())
+		h.Initialize()
+		h.SetSource(iputil.Parse(src))
+		h.SetDestination(iputil.Parse(dst))
+		h.SetL4Protocol(fragmentHeader)
+		h.SetPayloadLen(fragmentHeaderLen + end - offset)
+		fh := h[ipv6.HeaderLen:]
+		fh[0] = ipproto.UDP
+		binary.BigEndian.PutUint16(fh[2:], uint16(offset)|more)
+		binary.BigEndian.PutUint32(fh[4:], id)
+		copy(fh[fragmentHeaderLen:], payload[offset:end])
+		require.True(t, h.IsFragment())
+		frags = append(frags, data)
+	}
+	return frags
+}
+
+func makePayload(n int) []byte {
+	p := make([]byte, n)
+	for i := range p {
+		p[i] = byte(i)
+	}
+	return p
+}
+
+func TestV6Header_ConcatFragments(t *testing.T) {
+	payload := makePayload(3000)
+	cache := NewV6FragmentCache(10, time.Minute)
+	frags := makeV6Fragments(t, 1, "fd00::1", "fd01::1", payload, 1232)
+	require.Len(t, frags, 3)
+
+	// Out of order arrival
+	var final *buffer.Data
+	for _, i := range []int{2, 0, 1} {
+		require.Nil(t, final)
+		final = V6Header(frags[i].Buf()).ConcatFragments(frags[i], cache)
+	}
+	require.NotNil(t, final)
+	assert.Equal(t, 0, cache.Len())
+
+	h := V6Header(final.Buf())
+	assert.False(t, h.IsFragment())
+	assert.Equal(t, ipproto.UDP, h.L4Protocol())
+	assert.Equal(t, len(payload), h.PayloadLen())
+	assert.Equal(t, payload, h.Payload())
+	assert.True(t, h.Source().Equal(iputil.Parse("fd00::1")))
+}
+
+func TestV6Header_ConcatFragments_notFragment(t *testing.T) {
+	data := buffer.DataPool.Get(ipv6.HeaderLen + 8)
+	h := V6Header(data.Buf())
+	h.Initialize()
+	h.SetL4Protocol(ipproto.UDP)
+	h.SetPayloadLen(8)
+	assert.False(t, h.IsFragment())
+	assert.Same(t, data, h.ConcatFragments(data, NewV6FragmentCache(1, time.Minute)))
+}
+
+func TestV6Header_ConcatFragments_interleaved(t *testing.T) {
+	cache := NewV6FragmentCache(10, time.Minute)
+	p1 := makePayload(2000)
+	p2 := makePayload(1500)
+	f1 := makeV6Fragments(t, 1, "fd00::1", "fd01::1", p1, 1024)
+	f2 := makeV6Fragments(t, 2, "fd00::1", "fd01::1", p2, 1024)
+	assert.Nil(t, V6Header(f1[0].Buf()).ConcatFragments(f1[0], cache))
+	assert.Nil(t, V6Header(f2[0].Buf()).ConcatFragments(f2[0], cache))
+	assert.Equal(t, 2, cache.Len())
+
+	final := V6Header(f2[1].Buf()).ConcatFragments(f2[1], cache)
+	require.NotNil(t, final)
+	assert.Equal(t, p2, V6Header(final.Buf()).Payload())
+
+	final = V6Header(f1[1].Buf()).ConcatFragments(f1[1], cache)
+	require.NotNil(t, final)
+	assert.Equal(t, p1, V6Header(final.Buf()).Payload())
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestV6FragmentCache_bounded(t *testing.T) {
+	cache := NewV6FragmentCache(2, time.Minute)
+	var firsts [][]*buffer.Data
+	for id := uint32(1); id <= 3; id++ {
+		frags := makeV6Fragments(t, id, "fd00::1", "fd01::1", makePayload(2000), 1024)
+		assert.Nil(t, V6Header(frags[0].Buf()).ConcatFragments(frags[0], cache))
+		firsts = append(firsts, frags)
+	}
+	assert.Equal(t, 2, cache.Len())
+
+	// The fragments of the oldest packet were discarded, so its last fragment can't complete it
+	assert.Nil(t, V6Header(firsts[0][1].Buf()).ConcatFragments(firsts[0][1], cache))
+	assert.NotNil(t, V6Header(firsts[2][1].Buf()).ConcatFragments(firsts[2][1], cache))
+}
+
+func TestV6FragmentCache_timeout(t *testing.T) {
+	cache := NewV6FragmentCache(10, 10*time.Millisecond)
+	frags := makeV6Fragments(t, 1, "fd00::1", "fd01::1", makePayload(2000), 1024)
+	assert.Nil(t, V6Header(frags[0].Buf()).ConcatFragments(frags[0], cache))
+	time.Sleep(20 * time.Millisecond)
+
+	// The first fragment has expired, so the last fragment starts a new packet
+	assert.Nil(t, V6Header(frags[1].Buf()).ConcatFragments(frags[1], cache))
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestV6FragmentCache_overlap(t *testing.T) {
+	cache := NewV6FragmentCache(10, time.Minute)
+	frags := makeV6Fragments(t, 1, "fd00::1", "fd01::1", makePayload(2000), 1024)
+	overlapping := makeV6Fragments(t, 1, "fd00::1", "fd01::1", makePayload(2000), 1016)
+	assert.Nil(t, V6Header(frags[0].Buf()).ConcatFragments(frags[0], cache))
+	assert.Nil(t, V6Header(overlapping[1].Buf()).ConcatFragments(overlapping[1], cache))
+	assert.Equal(t, 0, cache.Len())
+}
@@ -37,6 +37,13 @@ type DaemonClient interface {
 	SetDnsSearchPath(ctx context.Context, in *Paths, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// SetLogLevel will temporarily set the log-level for the daemon for a duration that is determined b the request.
 	SetLogLevel(ctx context.Context, in *manager.LogLevelRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// FlushDNS removes all entries from the cache of the local DNS resolver.
+	FlushDNS(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetDNSStats returns the request and cache statistics of the local DNS resolver.
+	GetDNSStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DNSStats, error)
+	// TraceDNS streams the queries that the local DNS resolver answers. The stream
+	// starts with the most recent queries of the resolver's query log.
+	TraceDNS(ctx context.Context, in *TraceDNSRequest, opts ...grpc.CallOption) (Daemon_TraceDNSClient, error)
 }
 
 type daemonClient struct {
@@ -119,6 +126,56 @@ func (c *daemonClient) SetLogLevel(ctx context.Context, in *manager.LogLevelRequ
 	return out, nil
 }
 
+func (c *daemonClient) FlushDNS(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/telepresence.daemon.Daemon/FlushDNS", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) GetDNSStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DNSStats, error) {
+	out := new(DNSStats)
+	err := c.cc.Invoke(ctx, "/telepresence.daemon.Daemon/GetDNSStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) TraceDNS(ctx context.Context, in *TraceDNSRequest, opts ...grpc.CallOption) (Daemon_TraceDNSClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[0], "/telepresence.daemon.Daemon/TraceDNS", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daemonTraceDNSClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Daemon_TraceDNSClient interface {
+	Recv() (*DNSQuery, error)
+	grpc.ClientStream
+}
+
+type daemonTraceDNSClient struct {
+	grpc.ClientStream
+}
+
+func (x *daemonTraceDNSClient) Recv() (*DNSQuery, error) {
+	m := new(DNSQuery)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // DaemonServer is the server API for Daemon service.
 // All implementations must embed UnimplementedDaemonServer
 // for forward compatibility
@@ -139,6 +196,13 @@ type DaemonServer interface {
 	SetDnsSearchPath(context.Context, *Paths) (*emptypb.Empty, error)
 	// SetLogLevel will temporarily set the log-level for the daemon for a duration that is determined b the request.
 	SetLogLevel(context.Context, *manager.LogLevelRequest) (*emptypb.Empty, error)
+	// FlushDNS removes all entries from the cache of the local DNS resolver.
+	FlushDNS(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	// GetDNSStats returns the request and cache statistics of the local DNS resolver.
+	GetDNSStats(context.Context, *emptypb.Empty) (*DNSStats, error)
+	// TraceDNS streams the queries that the local DNS resolver answers. The stream
+	// starts with the most recent queries of the resolver's query log.
+	TraceDNS(*TraceDNSRequest, Daemon_TraceDNSServer) error
 	mustEmbedUnimplementedDaemonServer()
 }
 
@@ -170,6 +234,15 @@ func (UnimplementedDaemonServer) SetDnsSearchPath(context.Context, *Paths) (*emp
 func (UnimplementedDaemonServer) SetLogLevel(context.Context, *manager.LogLevelRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
 }
+func (UnimplementedDaemonServer) FlushDNS(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FlushDNS not implemented")
+}
+func (UnimplementedDaemonServer) GetDNSStats(context.Context, *emptypb.Empty) (*DNSStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDNSStats not implemented")
+}
+func (UnimplementedDaemonServer) TraceDNS(*TraceDNSRequest, Daemon_TraceDNSServer) error {
+	return status.Errorf(codes.Unimplemented, "method TraceDNS not implemented")
+}
 func (UnimplementedDaemonServer) mustEmbedUnimplementedDaemonServer() {}
 
 // UnsafeDaemonServer may be embedded to opt out of forward compatibility for this service.
@@ -327,6 +400,63 @@ func _Daemon_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Daemon_FlushDNS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).FlushDNS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/telepresence.daemon.Daemon/FlushDNS",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).FlushDNS(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_GetDNSStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).GetDNSStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/telepresence.daemon.Daemon/GetDNSStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).GetDNSStats(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_TraceDNS_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TraceDNSRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).TraceDNS(m, &daemonTraceDNSServer{stream})
+}
+
+type Daemon_TraceDNSServer interface {
+	Send(*DNSQuery) error
+	grpc.ServerStream
+}
+
+type daemonTraceDNSServer struct {
+	grpc.ServerStream
+}
+
+func (x *daemonTraceDNSServer) Send(m *DNSQuery) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Daemon_ServiceDesc is the grpc.ServiceDesc for Daemon service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -366,7 +496,21 @@ var Daemon_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetLogLevel",
 			Handler:    _Daemon_SetLogLevel_Handler,
 		},
+		{
+			MethodName: "FlushDNS",
+			Handler:    _Daemon_FlushDNS_Handler,
+		},
+		{
+			MethodName: "GetDNSStats",
+			Handler:    _Daemon_GetDNSStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "TraceDNS",
+			Handler:       _Daemon_TraceDNS_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpc/daemon/daemon.proto",
 }